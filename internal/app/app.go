@@ -2,10 +2,14 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"time"
 
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc"
 	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http"
 	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/storage"
 	"github.com/sivistrukov/vk-assigment/internal/services/validator"
 )
 
@@ -20,7 +24,12 @@ func Run() error {
 	if err != nil {
 		return err
 	}
-	initContainer(db)
+
+	fileStorage, err := storage.NewFileSystem(cfg.Storage)
+	if err != nil {
+		return err
+	}
+	initContainer(db, fileStorage, cfg.Cache, cfg.RateLimit)
 
 	validate := validator.New()
 
@@ -30,19 +39,94 @@ func Run() error {
 		container.UserService(),
 		container.ActorService(),
 		container.FilmService(),
+		container.CollectionService(),
+		container.MediaService(),
+		container.Storage().Handler(),
+		container.AwardService(),
+		container.TranslationService(),
+		container.FranchiseService(),
+		container.SimilarService(),
+		container.StatsService(),
+		container.ImportService(),
+		container.ExportService(),
+		container.TxManager(),
+		container.CastService(),
+		container.DuplicateService(),
+		container.CatalogService(),
+		container.EventService(),
+		container.WebhookService(),
+		container.Cache(),
+		container.RateLimiter(),
 	)
 
+	refreshCtx, stopRefresh := context.WithCancel(context.Background())
+	go container.StatsService().RunRefresh(refreshCtx, cfg.Stats.RefreshInterval)
+	closer.Add(func(_ context.Context) error {
+		stopRefresh()
+		return nil
+	})
+
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	go container.EventService().Run(eventsCtx, postgresql.NewEventListener(cfg.Database))
+	closer.Add(func(_ context.Context) error {
+		stopEvents()
+		return nil
+	})
+
+	webhooksCtx, stopWebhooks := context.WithCancel(context.Background())
+	go container.WebhookService().Run(webhooksCtx, cfg.Webhooks.PollInterval)
+	closer.Add(func(_ context.Context) error {
+		stopWebhooks()
+		return nil
+	})
+
 	srv := http.NewServer(cfg.Http, httpHandler)
 	closer.Add(srv.Shutdown)
 
+	grpcServer := grpc.NewServer(
+		validate,
+		container.AuthService(),
+		container.UserService(),
+		container.ActorService(),
+		container.FilmService(),
+		container.RateLimiter(),
+	)
+	closer.Add(func(ctx context.Context) error {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+			return nil
+		case <-ctx.Done():
+			grpcServer.Stop()
+			return ctx.Err()
+		}
+	})
+
 	closer.Add(func(_ context.Context) error { return db.Close() })
 
-	exit := make(chan error)
+	listener, err := net.Listen(
+		"tcp", fmt.Sprintf("%s:%s", cfg.Grpc.Host, cfg.Grpc.Port),
+	)
+	if err != nil {
+		return err
+	}
+
+	exit := make(chan error, 2)
 	go func() {
 		err := srv.ListenAndServe()
 
 		exit <- err
 	}()
+	go func() {
+		err := grpcServer.Serve(listener)
+
+		exit <- err
+	}()
 
 	return <-exit
 }
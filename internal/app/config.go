@@ -1,18 +1,36 @@
 package app
 
 import (
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc"
 	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http"
 	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/storage"
+	"github.com/sivistrukov/vk-assigment/internal/services/cache"
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
+	"github.com/sivistrukov/vk-assigment/internal/services/stats"
+	"github.com/sivistrukov/vk-assigment/internal/services/webhooks"
 )
 
 type Config struct {
-	Http     http.Config
-	Database postgresql.Config
+	Http      http.Config
+	Grpc      grpc.Config
+	Database  postgresql.Config
+	Storage   storage.Config
+	Stats     stats.Config
+	Webhooks  webhooks.Config
+	Cache     cache.Config
+	RateLimit ratelimit.Config
 }
 
 func NewConfig() Config {
 	return Config{
-		Http:     http.NewConfig(),
-		Database: postgresql.NewConfig(),
+		Http:      http.NewConfig(),
+		Grpc:      grpc.NewConfig(),
+		Database:  postgresql.NewConfig(),
+		Storage:   storage.NewConfig(),
+		Stats:     stats.NewConfig(),
+		Webhooks:  webhooks.NewConfig(),
+		Cache:     cache.NewConfig(),
+		RateLimit: ratelimit.NewConfig(),
 	}
 }
@@ -5,10 +5,27 @@ import (
 	"sync"
 
 	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/storage"
 	"github.com/sivistrukov/vk-assigment/internal/services/actors"
 	"github.com/sivistrukov/vk-assigment/internal/services/auth"
+	"github.com/sivistrukov/vk-assigment/internal/services/awards"
+	"github.com/sivistrukov/vk-assigment/internal/services/cache"
+	"github.com/sivistrukov/vk-assigment/internal/services/cast"
+	"github.com/sivistrukov/vk-assigment/internal/services/catalog"
+	"github.com/sivistrukov/vk-assigment/internal/services/collections"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+	"github.com/sivistrukov/vk-assigment/internal/services/events"
+	"github.com/sivistrukov/vk-assigment/internal/services/exports"
 	"github.com/sivistrukov/vk-assigment/internal/services/films"
+	"github.com/sivistrukov/vk-assigment/internal/services/franchises"
+	"github.com/sivistrukov/vk-assigment/internal/services/imports"
+	"github.com/sivistrukov/vk-assigment/internal/services/media"
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
+	"github.com/sivistrukov/vk-assigment/internal/services/similar"
+	"github.com/sivistrukov/vk-assigment/internal/services/stats"
+	"github.com/sivistrukov/vk-assigment/internal/services/translations"
 	"github.com/sivistrukov/vk-assigment/internal/services/users"
+	"github.com/sivistrukov/vk-assigment/internal/services/webhooks"
 )
 
 var (
@@ -17,7 +34,19 @@ var (
 )
 
 type Container struct {
-	psqlConn *sql.DB
+	psqlConn    *sql.DB
+	storage     *storage.FileSystem
+	cacheConfig cache.Config
+	rateConfig  ratelimit.Config
+
+	onceEvents   sync.Once
+	eventService *events.Service
+
+	onceCache sync.Once
+	cache     *cache.Cache
+
+	onceLimiter sync.Once
+	limiter     *ratelimit.Limiter
 }
 
 func GetContainer() *Container {
@@ -28,9 +57,19 @@ func GetContainer() *Container {
 	return container
 }
 
-func initContainer(conn *sql.DB) *Container {
+func initContainer(
+	conn *sql.DB,
+	storage *storage.FileSystem,
+	cacheConfig cache.Config,
+	rateConfig ratelimit.Config,
+) *Container {
 	onceContainer.Do(func() {
-		container = &Container{psqlConn: conn}
+		container = &Container{
+			psqlConn:    conn,
+			storage:     storage,
+			cacheConfig: cacheConfig,
+			rateConfig:  rateConfig,
+		}
 	})
 
 	return container
@@ -48,18 +87,181 @@ func (c *Container) FilmRepo() *postgresql.FilmRepo {
 	return postgresql.NewFilmRepo(c.psqlConn)
 }
 
+func (c *Container) CollectionRepo() *postgresql.CollectionRepo {
+	return postgresql.NewCollectionRepo(c.psqlConn)
+}
+
+func (c *Container) ImageRepo() *postgresql.ImageRepo {
+	return postgresql.NewImageRepo(c.psqlConn)
+}
+
+func (c *Container) AwardRepo() *postgresql.AwardRepo {
+	return postgresql.NewAwardRepo(c.psqlConn)
+}
+
+func (c *Container) TranslationRepo() *postgresql.TranslationRepo {
+	return postgresql.NewTranslationRepo(c.psqlConn)
+}
+
+func (c *Container) FranchiseRepo() *postgresql.FranchiseRepo {
+	return postgresql.NewFranchiseRepo(c.psqlConn)
+}
+
+func (c *Container) StatsRepo() *postgresql.StatsRepo {
+	return postgresql.NewStatsRepo(c.psqlConn)
+}
+
+func (c *Container) ImportRepo() *postgresql.ImportRepo {
+	return postgresql.NewImportRepo(c.psqlConn)
+}
+
+func (c *Container) ExportRepo() *postgresql.ExportRepo {
+	return postgresql.NewExportRepo(c.psqlConn)
+}
+
+func (c *Container) CastRepo() *postgresql.CastRepo {
+	return postgresql.NewCastRepo(c.psqlConn)
+}
+
+func (c *Container) DuplicateRepo() *postgresql.DuplicateRepo {
+	return postgresql.NewDuplicateRepo(c.psqlConn)
+}
+
+func (c *Container) CatalogRepo() *postgresql.CatalogRepo {
+	return postgresql.NewCatalogRepo(c.psqlConn)
+}
+
+func (c *Container) EventRepo() *postgresql.EventRepo {
+	return postgresql.NewEventRepo(c.psqlConn)
+}
+
+func (c *Container) WebhookRepo() *postgresql.WebhookRepo {
+	return postgresql.NewWebhookRepo(c.psqlConn)
+}
+
+func (c *Container) CacheStore() cache.Store {
+	if c.cacheConfig.Store == cache.StorePostgres {
+		return postgresql.NewCacheStore(c.psqlConn)
+	}
+
+	return cache.NewLRU(c.cacheConfig.Size)
+}
+
+func (c *Container) RateLimitStore() ratelimit.Store {
+	if c.rateConfig.Store == ratelimit.StorePostgres {
+		return postgresql.NewRateLimitStore(c.psqlConn)
+	}
+
+	return ratelimit.NewMemory()
+}
+
+func (c *Container) TxManager() *postgresql.TxManager {
+	return postgresql.NewTxManager(c.psqlConn)
+}
+
+func (c *Container) Storage() *storage.FileSystem {
+	return c.storage
+}
+
 func (c *Container) AuthService() *auth.Service {
 	return auth.NewService(c.UserRepo())
 }
 
 func (c *Container) ActorService() *actors.Service {
-	return actors.NewService(c.ActorRepo())
+	return actors.NewService(c.ActorRepo(), c.MediaService(), c.EventService(), c.Cache())
 }
 
 func (c *Container) FilmService() *films.Service {
-	return films.NewService(c.FilmRepo())
+	return films.NewService(c.FilmRepo(), c.MediaService(), c.EventService(), c.Cache())
 }
 
 func (c *Container) UserService() *users.Service {
 	return users.NewService(c.UserRepo())
 }
+
+func (c *Container) CollectionService() *collections.Service {
+	return collections.NewService(c.CollectionRepo())
+}
+
+func (c *Container) MediaService() *media.Service {
+	return media.NewService(c.Storage(), c.ImageRepo(), c.Cache())
+}
+
+func (c *Container) AwardService() *awards.Service {
+	return awards.NewService(c.AwardRepo(), c.Cache())
+}
+
+func (c *Container) TranslationService() *translations.Service {
+	return translations.NewService(c.TranslationRepo(), c.Cache())
+}
+
+func (c *Container) FranchiseService() *franchises.Service {
+	return franchises.NewService(c.FranchiseRepo())
+}
+
+func (c *Container) SimilarService() *similar.Service {
+	return similar.NewService(c.FilmRepo())
+}
+
+func (c *Container) StatsService() *stats.Service {
+	return stats.NewService(c.StatsRepo())
+}
+
+func (c *Container) ImportService() *imports.Service {
+	return imports.NewService(
+		c.TxManager(),
+		c.ImportRepo(),
+		c.ActorService(),
+		c.FilmService(),
+		c.CastService(),
+	)
+}
+
+func (c *Container) ExportService() *exports.Service {
+	return exports.NewService(c.ExportRepo())
+}
+
+func (c *Container) CastService() *cast.Service {
+	return cast.NewService(c.CastRepo(), c.EventService(), c.Cache())
+}
+
+func (c *Container) DuplicateService() *duplicates.Service {
+	return duplicates.NewService(c.DuplicateRepo())
+}
+
+func (c *Container) CatalogService() *catalog.Service {
+	return catalog.NewService(c.CatalogRepo())
+}
+
+func (c *Container) WebhookService() *webhooks.Service {
+	return webhooks.NewService(c.WebhookRepo())
+}
+
+// EventService is shared as it holds the subscribers of the instance.
+func (c *Container) EventService() *events.Service {
+	c.onceEvents.Do(func() {
+		c.eventService = events.NewService(c.EventRepo())
+	})
+
+	return c.eventService
+}
+
+// Cache is shared by the films and actors as their lists embed each other
+// and the in-process store holds the values of the instance.
+func (c *Container) Cache() *cache.Cache {
+	c.onceCache.Do(func() {
+		c.cache = cache.New(c.CacheStore(), "catalog", c.cacheConfig.TTL)
+	})
+
+	return c.cache
+}
+
+// RateLimiter is shared as the in-memory store holds the buckets of the
+// instance.
+func (c *Container) RateLimiter() *ratelimit.Limiter {
+	c.onceLimiter.Do(func() {
+		c.limiter = ratelimit.NewLimiter(c.RateLimitStore(), c.rateConfig.Reads, c.rateConfig.Writes)
+	})
+
+	return c.limiter
+}
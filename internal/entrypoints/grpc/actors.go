@@ -0,0 +1,84 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc/pb"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type ActorServer struct {
+	pb.UnimplementedActorServiceServer
+	service  v1.ActorService
+	validate *validator.Validate
+}
+
+func NewActorServer(service v1.ActorService, validate *validator.Validate) *ActorServer {
+	return &ActorServer{
+		service:  service,
+		validate: validate,
+	}
+}
+
+func (s *ActorServer) AddActor(ctx context.Context, req *pb.AddActorRequest) (*pb.Actor, error) {
+	schema, err := actorRequest(req.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.validate.Struct(schema); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	actor, err := s.service.AddActor(ctx, schema, req.Force)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return newActor(actor), nil
+}
+
+func (s *ActorServer) UpdateActor(ctx context.Context, req *pb.UpdateActorRequest) (*emptypb.Empty, error) {
+	request, err := actorRequest(req.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := schemas.UpdateActorRequest(request)
+	if err = s.validate.Struct(schema); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	err = s.service.UpdateActor(ctx, uint(req.Id), schema)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ActorServer) RemoveActor(ctx context.Context, req *pb.RemoveActorRequest) (*emptypb.Empty, error) {
+	err := s.service.RemoveActor(ctx, uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ActorServer) ListActors(ctx context.Context, _ *emptypb.Empty) (*pb.ListActorsResponse, error) {
+	actors, err := s.service.GetActorsWithFilms(ctx, schemas.DefaultListOptions)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &pb.ListActorsResponse{Actors: make([]*pb.Actor, 0, len(actors))}
+	for _, actor := range actors {
+		resp.Actors = append(resp.Actors, newActorWithFilms(actor))
+	}
+
+	return resp, nil
+}
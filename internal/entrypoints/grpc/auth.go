@@ -0,0 +1,155 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc/pb"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type AuthService interface {
+	Authenticate(string, string) (models.User, error)
+}
+
+type userKey struct{}
+
+var (
+	errUnauthenticated = status.Error(codes.Unauthenticated, "401 unauthorized")
+	errForbidden       = status.Error(codes.PermissionDenied, "403 forbidden")
+)
+
+// publicMethods are called without credentials.
+var publicMethods = map[string]bool{
+	pb.UserService_CreateUser_FullMethodName: true,
+}
+
+// adminMethods are allowed to admins only.
+var adminMethods = map[string]bool{
+	pb.FilmService_AddFilm_FullMethodName:      true,
+	pb.FilmService_UpdateFilm_FullMethodName:   true,
+	pb.FilmService_RemoveFilm_FullMethodName:   true,
+	pb.ActorService_AddActor_FullMethodName:    true,
+	pb.ActorService_UpdateActor_FullMethodName: true,
+	pb.ActorService_RemoveActor_FullMethodName: true,
+}
+
+// unaryAuth checks the basic credentials of the "authorization" metadata
+// and stores the user in the context of the call. The calls are limited
+// as the HTTP requests are.
+func unaryAuth(auth AuthService, limiter RateLimiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, err := authenticate(ctx, auth, limiter, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// streamAuth is unaryAuth for the streaming calls.
+func streamAuth(auth AuthService, limiter RateLimiter) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authenticate(ss.Context(), auth, limiter, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(
+	ctx context.Context, auth AuthService, limiter RateLimiter, method string,
+) (context.Context, error) {
+	// the public methods create records, so they are counted as writes
+	write := publicMethods[method] || adminMethods[method]
+	if publicMethods[method] {
+		return ctx, limit(ctx, limiter, "ip:"+peerIP(ctx), write)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		if err := limit(ctx, limiter, "ip:"+peerIP(ctx), write); err != nil {
+			return nil, err
+		}
+		return nil, errUnauthenticated
+	}
+
+	// the addresses guessing passwords are rejected before the check
+	if err := checkFailures(ctx, limiter); err != nil {
+		return nil, err
+	}
+
+	user, err := checkCredentials(auth, values[0])
+	if err != nil {
+		countFailure(ctx, limiter)
+		return nil, err
+	}
+
+	if err = limit(ctx, limiter, "user:"+user.Username, write); err != nil {
+		return nil, err
+	}
+
+	if adminMethods[method] && !user.IsAdmin {
+		return nil, errForbidden
+	}
+
+	return context.WithValue(ctx, userKey{}, user), nil
+}
+
+// checkCredentials returns the user of the basic credentials.
+func checkCredentials(auth AuthService, value string) (models.User, error) {
+	credentials := strings.SplitN(value, " ", 2)
+	if len(credentials) != 2 || credentials[0] != "Basic" {
+		return models.User{}, errUnauthenticated
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(credentials[1])
+	if err != nil {
+		return models.User{}, errUnauthenticated
+	}
+
+	pair := strings.SplitN(string(payload), ":", 2)
+	if len(pair) != 2 {
+		return models.User{}, errUnauthenticated
+	}
+
+	user, err := auth.Authenticate(pair[0], pair[1])
+	if err != nil {
+		return models.User{}, errUnauthenticated
+	}
+
+	return user, nil
+}
+
+func currentUser(ctx context.Context) models.User {
+	user, _ := ctx.Value(userKey{}).(models.User)
+	return user
+}
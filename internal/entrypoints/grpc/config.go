@@ -0,0 +1,15 @@
+package grpc
+
+import "os"
+
+type Config struct {
+	Host string
+	Port string
+}
+
+func NewConfig() Config {
+	return Config{
+		Host: os.Getenv("SERVER_HOST"),
+		Port: os.Getenv("GRPC_PORT"),
+	}
+}
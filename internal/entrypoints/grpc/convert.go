@@ -0,0 +1,213 @@
+package grpc
+
+import (
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc/pb"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errMissingFilm   = status.Error(codes.InvalidArgument, "film is required")
+	errMissingActor  = status.Error(codes.InvalidArgument, "actor is required")
+	errInvalidRating = status.Error(codes.InvalidArgument, "rating must be between 0 and 10")
+)
+
+func addFilmRequest(film *pb.FilmInput) (schemas.AddFilmRequest, error) {
+	if film == nil {
+		return schemas.AddFilmRequest{}, errMissingFilm
+	}
+
+	if film.Rating > 10 {
+		return schemas.AddFilmRequest{}, errInvalidRating
+	}
+
+	return schemas.AddFilmRequest{
+		Title:       film.Title,
+		Description: film.Description,
+		ReleaseDate: schemas.Date(film.ReleaseDate),
+		Rating:      uint8(film.Rating),
+		Runtime:     uintPtr(film.Runtime),
+		Countries:   film.Countries,
+		Language:    film.Language,
+		AgeRating:   ageRatingPtr(film.AgeRating),
+		Budget:      film.Budget,
+		BoxOffice:   film.BoxOffice,
+		Currency:    film.Currency,
+		ActorsIDs:   uints(film.ActorsIds),
+	}, nil
+}
+
+func updateFilmRequest(film *pb.FilmInput) (schemas.UpdateFilmRequest, error) {
+	request, err := addFilmRequest(film)
+	if err != nil {
+		return schemas.UpdateFilmRequest{}, err
+	}
+
+	return schemas.UpdateFilmRequest{
+		Title:       request.Title,
+		Description: request.Description,
+		ReleaseDate: request.ReleaseDate,
+		Rating:      request.Rating,
+		Runtime:     request.Runtime,
+		Countries:   request.Countries,
+		Language:    request.Language,
+		AgeRating:   request.AgeRating,
+		Budget:      request.Budget,
+		BoxOffice:   request.BoxOffice,
+		Currency:    request.Currency,
+		ActorsIds:   request.ActorsIDs,
+	}, nil
+}
+
+func filmsFilter(req *pb.ListFilmsRequest) schemas.FilmsFilter {
+	return schemas.FilmsFilter{
+		HasAwards:   req.HasAwards,
+		AwardWinner: req.AwardWinner,
+		Country:     req.Country,
+		Language:    req.Language,
+		AgeRating:   req.AgeRating,
+		MinRuntime:  uintPtr(req.MinRuntime),
+		MaxRuntime:  uintPtr(req.MaxRuntime),
+	}
+}
+
+func actorRequest(actor *pb.ActorInput) (schemas.AddActorRequest, error) {
+	if actor == nil {
+		return schemas.AddActorRequest{}, errMissingActor
+	}
+
+	return schemas.AddActorRequest{
+		FirstName:  actor.FirstName,
+		LastName:   actor.LastName,
+		MiddleName: actor.MiddleName,
+		Sex:        models.Sex(actor.Sex),
+		Birthday:   schemas.Date(actor.Birthday),
+	}, nil
+}
+
+func newFilm(film models.Film) *pb.Film {
+	return newFilmInfo(schemas.NewFilmInfo(film))
+}
+
+func newFilmInfo(film schemas.FilmInfo) *pb.Film {
+	return newFilmMessage(
+		film.ID, film.Title, film.Description, film.ReleaseDate, film.Rating,
+		film.FilmMetadata,
+	)
+}
+
+func newFilmWithActors(film schemas.FilmWithActorsResponse) *pb.Film {
+	message := newFilmMessage(
+		film.ID, film.Title, film.Description, film.ReleaseDate, film.Rating,
+		film.FilmMetadata,
+	)
+
+	message.Actors = make([]*pb.Actor, 0, len(film.Actors))
+	for _, actor := range film.Actors {
+		message.Actors = append(message.Actors, newActorInfo(actor))
+	}
+
+	return message
+}
+
+func newFilmMessage(
+	id uint,
+	title string,
+	description string,
+	releaseDate schemas.Date,
+	rating uint8,
+	metadata schemas.FilmMetadata,
+) *pb.Film {
+	return &pb.Film{
+		Id:          uint64(id),
+		Title:       title,
+		Description: description,
+		ReleaseDate: string(releaseDate),
+		Rating:      uint32(rating),
+		Runtime:     uint32Ptr(metadata.Runtime),
+		Countries:   metadata.Countries,
+		Language:    metadata.Language,
+		AgeRating:   (*string)(metadata.AgeRating),
+		Budget:      metadata.Budget,
+		BoxOffice:   metadata.BoxOffice,
+		Currency:    metadata.Currency,
+	}
+}
+
+func newActor(actor models.Actor) *pb.Actor {
+	return newActorInfo(schemas.NewActorInfo(actor))
+}
+
+func newActorInfo(actor schemas.ActorInfo) *pb.Actor {
+	return &pb.Actor{
+		Id:         uint64(actor.ID),
+		FirstName:  actor.FirstName,
+		LastName:   actor.LastName,
+		MiddleName: actor.MiddleName,
+		Sex:        string(actor.Sex),
+		Birthday:   string(actor.Birthday),
+	}
+}
+
+func newActorWithFilms(actor schemas.ActorWithFilmsResponse) *pb.Actor {
+	films := make([]*pb.Film, 0, len(actor.Films))
+	for _, film := range actor.Films {
+		films = append(films, newFilmInfo(film))
+	}
+
+	return &pb.Actor{
+		Id:         uint64(actor.ID),
+		FirstName:  actor.FirstName,
+		LastName:   actor.LastName,
+		MiddleName: actor.MiddleName,
+		Sex:        string(actor.Sex),
+		Birthday:   string(actor.Birthday),
+		Films:      films,
+	}
+}
+
+func newUser(user models.User) *pb.User {
+	return &pb.User{
+		Id:       uint64(user.ID),
+		Username: user.Username,
+		IsAdmin:  user.IsAdmin,
+	}
+}
+
+func uints(values []uint64) []uint {
+	result := make([]uint, 0, len(values))
+	for _, v := range values {
+		result = append(result, uint(v))
+	}
+
+	return result
+}
+
+func uintPtr(v *uint32) *uint {
+	if v == nil {
+		return nil
+	}
+
+	value := uint(*v)
+	return &value
+}
+
+func uint32Ptr(v *uint) *uint32 {
+	if v == nil {
+		return nil
+	}
+
+	value := uint32(*v)
+	return &value
+}
+
+func ageRatingPtr(v *string) *models.AgeRating {
+	if v == nil {
+		return nil
+	}
+
+	value := models.AgeRating(*v)
+	return &value
+}
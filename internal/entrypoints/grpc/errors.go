@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"errors"
+	"log"
+
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errInternal = status.Error(codes.Internal, "internal server error")
+
+// statusError maps the errors of the services to the status codes, the
+// errors which are not caused by the request are hidden.
+func statusError(err error) error {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	var duplicateErr *duplicates.ErrDuplicate
+	switch {
+	case errors.As(err, &notFoundErr):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &existsErr), errors.As(err, &duplicateErr):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		log.Printf("grpc: %v", err)
+		return errInternal
+	}
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
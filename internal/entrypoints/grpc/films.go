@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc/pb"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type FilmServer struct {
+	pb.UnimplementedFilmServiceServer
+	service  v1.FilmService
+	validate *validator.Validate
+}
+
+func NewFilmServer(service v1.FilmService, validate *validator.Validate) *FilmServer {
+	return &FilmServer{
+		service:  service,
+		validate: validate,
+	}
+}
+
+func (s *FilmServer) AddFilm(ctx context.Context, req *pb.AddFilmRequest) (*pb.Film, error) {
+	schema, err := addFilmRequest(req.Film)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.validate.Struct(schema); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	film, err := s.service.AddFilm(ctx, schema, req.Force)
+	if err != nil {
+		var notFoundErr *postgresql.ErrRecordNotFound
+		if errors.As(err, &notFoundErr) {
+			return nil, status.Error(codes.InvalidArgument, "invalid actor id")
+		}
+		return nil, statusError(err)
+	}
+
+	return newFilm(film), nil
+}
+
+func (s *FilmServer) UpdateFilm(ctx context.Context, req *pb.UpdateFilmRequest) (*emptypb.Empty, error) {
+	schema, err := updateFilmRequest(req.Film)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.validate.Struct(schema); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	err = s.service.UpdateFilm(ctx, uint(req.Id), schema)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *FilmServer) RemoveFilm(ctx context.Context, req *pb.RemoveFilmRequest) (*emptypb.Empty, error) {
+	err := s.service.RemoveFilm(ctx, uint(req.Id))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *FilmServer) ListFilms(ctx context.Context, req *pb.ListFilmsRequest) (*pb.ListFilmsResponse, error) {
+	filter := filmsFilter(req)
+	if err := s.validate.Struct(filter); err != nil {
+		return nil, invalidArgument(err)
+	}
+	if _, err := schemas.ParseSort(req.SortBy, schemas.FilmSortFields); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	films, err := s.service.GetFilmsWithActors(ctx, req.Search, req.SortBy, filter, schemas.DefaultListOptions)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := &pb.ListFilmsResponse{Films: make([]*pb.Film, 0, len(films))}
+	for _, film := range films {
+		resp.Films = append(resp.Films, newFilmWithActors(film))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"log"
+	"math"
+	"net"
+	"strconv"
+
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimiter counts the calls in the buckets shared with the HTTP API.
+type RateLimiter interface {
+	Take(ctx context.Context, key string, write bool) (ratelimit.Result, error)
+	Check(ctx context.Context, key string, write bool) (ratelimit.Result, error)
+}
+
+var errRateLimited = status.Error(codes.ResourceExhausted, "rate limit exceeded")
+
+// limit counts the call in the bucket of the key. The calls are passed on
+// if the limiter fails.
+func limit(ctx context.Context, limiter RateLimiter, key string, write bool) error {
+	result, err := limiter.Take(ctx, key, write)
+	if err != nil {
+		log.Printf("grpc: error limiting rate of %s: %v", key, err)
+		return nil
+	}
+
+	if !result.Allowed {
+		return rateLimited(ctx, result)
+	}
+
+	return nil
+}
+
+// checkFailures rejects the call from the address which used up its writes
+// with the wrong credentials, before the credentials are checked.
+func checkFailures(ctx context.Context, limiter RateLimiter) error {
+	key := failedCredentialsKey(ctx)
+	result, err := limiter.Check(ctx, key, true)
+	if err != nil {
+		log.Printf("grpc: error limiting rate of %s: %v", key, err)
+		return nil
+	}
+
+	if !result.Allowed {
+		return rateLimited(ctx, result)
+	}
+
+	return nil
+}
+
+// countFailure counts the wrong credentials as the write of the address.
+func countFailure(ctx context.Context, limiter RateLimiter) {
+	key := failedCredentialsKey(ctx)
+	if _, err := limiter.Take(ctx, key, true); err != nil {
+		log.Printf("grpc: error limiting rate of %s: %v", key, err)
+	}
+}
+
+// rateLimited returns the error of the rejected call and tells the client
+// when to retry it in the "retry-after" header.
+func rateLimited(ctx context.Context, result ratelimit.Result) error {
+	retryAfter := strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds())))
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter))
+
+	return errRateLimited
+}
+
+// failedCredentialsKey returns the bucket of the wrong credentials sent
+// from the address, the HTTP API counts them in the same bucket.
+func failedCredentialsKey(ctx context.Context) string {
+	return "credentials:" + peerIP(ctx)
+}
+
+// peerIP returns the address of the client connected to the server.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc"
+)
+
+// unaryRecover turns the panic of the handler into the internal error, so
+// the server keeps serving other calls.
+func unaryRecover() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("grpc: %s got error: %v", info.FullMethod, p)
+				resp, err = nil, errInternal
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// streamRecover is unaryRecover for the streaming calls.
+func streamRecover() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) (err error) {
+		defer func() {
+			if p := recover(); p != nil {
+				log.Printf("grpc: %s got error: %v", info.FullMethod, p)
+				err = errInternal
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
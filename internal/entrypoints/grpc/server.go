@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc/pb"
+	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+	"google.golang.org/grpc"
+)
+
+// NewServer returns new grpc server with the film, actor and user
+// services registered. The calls share the rate limits with the HTTP API.
+func NewServer(
+	validate *validator.Validate,
+	authService AuthService,
+	userService v1.UserService,
+	actorService v1.ActorService,
+	filmService v1.FilmService,
+	limiter RateLimiter,
+) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryRecover(), unaryAuth(authService, limiter)),
+		grpc.ChainStreamInterceptor(streamRecover(), streamAuth(authService, limiter)),
+	)
+
+	pb.RegisterUserServiceServer(srv, NewUserServer(userService, validate))
+	pb.RegisterActorServiceServer(srv, NewActorServer(actorService, validate))
+	pb.RegisterFilmServiceServer(srv, NewFilmServer(filmService, validate))
+
+	return srv
+}
@@ -0,0 +1,343 @@
+package grpc
+
+import (
+	"context"
+	"encoding/base64"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc/pb"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/auth"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
+	"github.com/sivistrukov/vk-assigment/internal/services/validator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type fakeAuth struct{}
+
+func (fakeAuth) Authenticate(username string, password string) (models.User, error) {
+	switch {
+	case username == "admin" && password == "admin":
+		return models.User{ID: 1, Username: "admin", IsAdmin: true}, nil
+	case username == "reader" && password == "reader":
+		return models.User{ID: 2, Username: "reader"}, nil
+	default:
+		return models.User{}, auth.ErrNotAuthorized
+	}
+}
+
+type fakeFilms struct {
+	v1.FilmService
+}
+
+func (fakeFilms) AddFilm(
+	_ context.Context, request schemas.AddFilmRequest, force bool,
+) (models.Film, error) {
+	if request.Title == "Panic" {
+		panic("film service failed")
+	}
+	if request.Title == "Drive" && !force {
+		return models.Film{}, &duplicates.ErrDuplicate{Kind: "film", ID: 1}
+	}
+	return models.Film{ID: 7, Title: request.Title, ReleaseDate: request.ReleaseDate.ToTime()}, nil
+}
+
+func (fakeFilms) GetFilmsWithActors(
+	context.Context, string, string, schemas.FilmsFilter, schemas.ListOptions,
+) ([]schemas.FilmWithActorsResponse, error) {
+	return []schemas.FilmWithActorsResponse{{
+		ID:          1,
+		Title:       "Drive",
+		ReleaseDate: "03-11-2011",
+		Actors:      []schemas.ActorInfo{{ID: 2, FirstName: "Ryan", LastName: "Gosling"}},
+	}}, nil
+}
+
+type fakeUsers struct{}
+
+func (fakeUsers) CreateUser(
+	_ context.Context, request schemas.CreateUserRequest,
+) (models.User, error) {
+	return models.User{ID: 3, Username: request.Username, IsAdmin: request.IsAdmin}, nil
+}
+
+func newTestClient(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	limit := ratelimit.Limit{Requests: 1000, Period: time.Minute}
+	return newLimitedClient(t, ratelimit.NewLimiter(ratelimit.NewMemory(), limit, limit))
+}
+
+func newLimitedClient(t *testing.T, limiter RateLimiter) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	srv := NewServer(validator.New(), fakeAuth{}, fakeUsers{}, nil, fakeFilms{}, limiter)
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func withCredentials(username, password string) context.Context {
+	token := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return metadata.AppendToOutgoingContext(
+		context.Background(), "authorization", "Basic "+token,
+	)
+}
+
+func TestAuth(t *testing.T) {
+	conn := newTestClient(t)
+	films := pb.NewFilmServiceClient(conn)
+	users := pb.NewUserServiceClient(conn)
+	film := &pb.FilmInput{Title: "Barbie", ReleaseDate: "20-07-2023", ActorsIds: []uint64{4}}
+
+	tests := []struct {
+		name string
+		call func() error
+		code codes.Code
+	}{
+		{
+			name: "without credentials",
+			call: func() error {
+				_, err := films.ListFilms(context.Background(), &pb.ListFilmsRequest{})
+				return err
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "wrong password",
+			call: func() error {
+				_, err := films.ListFilms(withCredentials("reader", "admin"), &pb.ListFilmsRequest{})
+				return err
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "reader lists films",
+			call: func() error {
+				_, err := films.ListFilms(withCredentials("reader", "reader"), &pb.ListFilmsRequest{})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name: "reader adds film",
+			call: func() error {
+				_, err := films.AddFilm(withCredentials("reader", "reader"), &pb.AddFilmRequest{Film: film})
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "admin adds film",
+			call: func() error {
+				_, err := films.AddFilm(withCredentials("admin", "admin"), &pb.AddFilmRequest{Film: film})
+				return err
+			},
+			code: codes.OK,
+		},
+		{
+			name: "create user without credentials",
+			call: func() error {
+				_, err := users.CreateUser(
+					context.Background(),
+					&pb.CreateUserRequest{Username: "user", Password: "secret"},
+				)
+				return err
+			},
+			code: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := status.Code(tt.call()); code != tt.code {
+				t.Errorf("got %s, want %s", code, tt.code)
+			}
+		})
+	}
+}
+
+func TestCreateUser_NotAdmin(t *testing.T) {
+	users := pb.NewUserServiceClient(newTestClient(t))
+
+	user, err := users.CreateUser(
+		context.Background(),
+		&pb.CreateUserRequest{Username: "user", Password: "secret", IsAdmin: true},
+	)
+	if err != nil {
+		t.Fatalf("CreateUser() error = %v", err)
+	}
+
+	if user.IsAdmin {
+		t.Errorf("CreateUser() created an admin without credentials")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	conn := newLimitedClient(t, ratelimit.NewLimiter(
+		ratelimit.NewMemory(),
+		ratelimit.Limit{Requests: 2, Period: time.Minute},
+		ratelimit.Limit{Requests: 2, Period: time.Minute},
+	))
+	films := pb.NewFilmServiceClient(conn)
+
+	list := func(username, password string) codes.Code {
+		_, err := films.ListFilms(withCredentials(username, password), &pb.ListFilmsRequest{})
+		return status.Code(err)
+	}
+
+	for _, want := range []codes.Code{codes.OK, codes.OK, codes.ResourceExhausted} {
+		if code := list("reader", "reader"); code != want {
+			t.Errorf("reader lists films: got %s, want %s", code, want)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := list("admin", "guess"); code != codes.Unauthenticated {
+			t.Errorf("wrong password: got %s, want %s", code, codes.Unauthenticated)
+		}
+	}
+	if code := list("admin", "admin"); code != codes.ResourceExhausted {
+		t.Errorf("after wrong passwords: got %s, want %s", code, codes.ResourceExhausted)
+	}
+}
+
+func TestGetCurrentUser(t *testing.T) {
+	users := pb.NewUserServiceClient(newTestClient(t))
+
+	user, err := users.GetCurrentUser(withCredentials("reader", "reader"), &emptypb.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Id != 2 || user.Username != "reader" || user.IsAdmin {
+		t.Errorf("unexpected user %v", user)
+	}
+}
+
+func TestAddFilm(t *testing.T) {
+	films := pb.NewFilmServiceClient(newTestClient(t))
+	ctx := withCredentials("admin", "admin")
+
+	tests := []struct {
+		name string
+		req  *pb.AddFilmRequest
+		code codes.Code
+	}{
+		{
+			name: "missing film",
+			req:  &pb.AddFilmRequest{},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "invalid release date",
+			req: &pb.AddFilmRequest{Film: &pb.FilmInput{
+				Title: "Barbie", ReleaseDate: "2023-07-20", ActorsIds: []uint64{4},
+			}},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "invalid rating",
+			req: &pb.AddFilmRequest{Film: &pb.FilmInput{
+				Title: "Barbie", ReleaseDate: "20-07-2023", Rating: 300, ActorsIds: []uint64{4},
+			}},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "duplicate",
+			req: &pb.AddFilmRequest{Film: &pb.FilmInput{
+				Title: "Drive", ReleaseDate: "03-11-2011", ActorsIds: []uint64{2},
+			}},
+			code: codes.AlreadyExists,
+		},
+		{
+			name: "panic",
+			req: &pb.AddFilmRequest{Film: &pb.FilmInput{
+				Title: "Panic", ReleaseDate: "03-11-2011", ActorsIds: []uint64{2},
+			}},
+			code: codes.Internal,
+		},
+		{
+			name: "forced duplicate",
+			req: &pb.AddFilmRequest{Force: true, Film: &pb.FilmInput{
+				Title: "Drive", ReleaseDate: "03-11-2011", ActorsIds: []uint64{2},
+			}},
+			code: codes.OK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			film, err := films.AddFilm(ctx, tt.req)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("got %s, want %s: %v", code, tt.code, err)
+			}
+
+			if err == nil && (film.Id != 7 || film.ReleaseDate != tt.req.Film.ReleaseDate) {
+				t.Errorf("unexpected film %v", film)
+			}
+		})
+	}
+}
+
+func TestListFilms(t *testing.T) {
+	films := pb.NewFilmServiceClient(newTestClient(t))
+
+	resp, err := films.ListFilms(withCredentials("reader", "reader"), &pb.ListFilmsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Films) != 1 || len(resp.Films[0].Actors) != 1 ||
+		resp.Films[0].Actors[0].LastName != "Gosling" {
+		t.Errorf("unexpected films %v", resp.Films)
+	}
+}
+
+func TestListFilms_SortBy(t *testing.T) {
+	films := pb.NewFilmServiceClient(newTestClient(t))
+	ctx := withCredentials("reader", "reader")
+
+	tests := []struct {
+		sortBy string
+		code   codes.Code
+	}{
+		{sortBy: "-rating,title", code: codes.OK},
+		{sortBy: "password", code: codes.InvalidArgument},
+		{sortBy: "title,", code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			_, err := films.ListFilms(ctx, &pb.ListFilmsRequest{SortBy: tt.sortBy})
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("got %s, want %s: %v", code, tt.code, err)
+			}
+		})
+	}
+}
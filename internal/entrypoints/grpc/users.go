@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/grpc/pb"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+	service  v1.UserService
+	validate *validator.Validate
+}
+
+func NewUserServer(service v1.UserService, validate *validator.Validate) *UserServer {
+	return &UserServer{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// CreateUser is called without credentials, so is_admin is ignored and
+// the created user is never an admin.
+func (s *UserServer) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	schema := schemas.CreateUserRequest{
+		Username: req.Username,
+		Password: req.Password,
+		IsAdmin:  false,
+	}
+	if err := s.validate.Struct(schema); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	user, err := s.service.CreateUser(ctx, schema)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return newUser(user), nil
+}
+
+func (s *UserServer) GetCurrentUser(ctx context.Context, _ *emptypb.Empty) (*pb.User, error) {
+	return newUser(currentUser(ctx)), nil
+}
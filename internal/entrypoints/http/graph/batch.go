@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// filmBatch is the list of films resolved together. The cast of all of
+// them is loaded with a single query the first time any of them needs it.
+type filmBatch struct {
+	root *Resolver
+	ids  []uint
+
+	once sync.Once
+	cast map[uint][]*ActorResolver
+	err  error
+}
+
+// actorBatch is the list of actors resolved together. The filmography of
+// all of them is loaded with a single query the first time any of them
+// needs it.
+type actorBatch struct {
+	root *Resolver
+	ids  []uint
+
+	once  sync.Once
+	films map[uint][]*FilmResolver
+	err   error
+}
+
+func (r *Resolver) newFilms(films []schemas.FilmInfo) []*FilmResolver {
+	batch := &filmBatch{root: r, ids: make([]uint, 0, len(films))}
+	seen := make(map[uint]bool, len(films))
+
+	resolvers := make([]*FilmResolver, 0, len(films))
+	for _, film := range films {
+		if !seen[film.ID] {
+			seen[film.ID] = true
+			batch.ids = append(batch.ids, film.ID)
+		}
+		resolvers = append(resolvers, &FilmResolver{film: film, batch: batch})
+	}
+
+	return resolvers
+}
+
+func (r *Resolver) newActors(actors []schemas.ActorInfo) []*ActorResolver {
+	batch := &actorBatch{root: r, ids: make([]uint, 0, len(actors))}
+	seen := make(map[uint]bool, len(actors))
+
+	resolvers := make([]*ActorResolver, 0, len(actors))
+	for _, actor := range actors {
+		if !seen[actor.ID] {
+			seen[actor.ID] = true
+			batch.ids = append(batch.ids, actor.ID)
+		}
+		resolvers = append(resolvers, &ActorResolver{actor: actor, batch: batch})
+	}
+
+	return resolvers
+}
+
+// castOf returns the cast of the film. Actors of all films of the batch
+// form the next batch, so that their filmographies are loaded together.
+func (b *filmBatch) castOf(ctx context.Context, id uint) ([]*ActorResolver, error) {
+	b.once.Do(func() {
+		// the actors are counted and charged before they are loaded
+		count, err := b.root.catalog.CountCast(ctx, b.ids)
+		if err != nil {
+			b.err = resolverError(err)
+			return
+		}
+		if b.err = charge(ctx, count); b.err != nil {
+			return
+		}
+
+		cast, err := b.root.catalog.GetCast(ctx, b.ids)
+		if err != nil {
+			b.err = resolverError(err)
+			return
+		}
+
+		actors := make([]schemas.ActorInfo, 0)
+		for _, filmId := range b.ids {
+			actors = append(actors, cast[filmId]...)
+		}
+		// the cast may change between the queries
+		if b.err = charge(ctx, len(actors)-count); b.err != nil {
+			return
+		}
+
+		resolvers := b.root.newActors(actors)
+		b.cast = make(map[uint][]*ActorResolver, len(b.ids))
+		i := 0
+		for _, filmId := range b.ids {
+			n := len(cast[filmId])
+			b.cast[filmId] = resolvers[i : i+n : i+n]
+			i += n
+		}
+	})
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	cast := b.cast[id]
+	if cast == nil {
+		return []*ActorResolver{}, nil
+	}
+
+	return cast, nil
+}
+
+// filmographyOf returns the films of the actor. Films of all actors of
+// the batch form the next batch, so that their cast is loaded together.
+func (b *actorBatch) filmographyOf(ctx context.Context, id uint) ([]*FilmResolver, error) {
+	b.once.Do(func() {
+		count, err := b.root.catalog.CountFilmographies(ctx, b.ids)
+		if err != nil {
+			b.err = resolverError(err)
+			return
+		}
+		if b.err = charge(ctx, count); b.err != nil {
+			return
+		}
+
+		filmographies, err := b.root.catalog.GetFilmographies(ctx, b.ids)
+		if err != nil {
+			b.err = resolverError(err)
+			return
+		}
+
+		films := make([]schemas.FilmInfo, 0)
+		for _, actorId := range b.ids {
+			films = append(films, filmographies[actorId]...)
+		}
+		if b.err = charge(ctx, len(films)-count); b.err != nil {
+			return
+		}
+
+		resolvers := b.root.newFilms(films)
+		b.films = make(map[uint][]*FilmResolver, len(b.ids))
+		i := 0
+		for _, actorId := range b.ids {
+			n := len(filmographies[actorId])
+			b.films[actorId] = resolvers[i : i+n : i+n]
+			i += n
+		}
+	})
+
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	films := b.films[id]
+	if films == nil {
+		return []*FilmResolver{}, nil
+	}
+
+	return films, nil
+}
@@ -0,0 +1,44 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+var errTooComplex = fmt.Errorf("query resolves more than %d films and actors", MaxComplexity)
+
+type budgetKey struct{}
+
+// budget is the number of films and actors the query may still resolve.
+// Resolvers run concurrently, so it is decremented atomically.
+type budget struct {
+	left atomic.Int64
+}
+
+func withBudget(ctx context.Context, n int64) context.Context {
+	b := &budget{}
+	b.left.Store(n)
+	return context.WithValue(ctx, budgetKey{}, b)
+}
+
+// charge takes n from the budget of the query.
+func charge(ctx context.Context, n int) error {
+	b, ok := ctx.Value(budgetKey{}).(*budget)
+	if !ok {
+		return nil
+	}
+
+	if b.left.Add(-int64(n)) < 0 {
+		return errTooComplex
+	}
+
+	return nil
+}
+
+// refund returns n unused by the resolver to the budget of the query.
+func refund(ctx context.Context, n int) {
+	if b, ok := ctx.Value(budgetKey{}).(*budget); ok && n > 0 {
+		b.left.Add(int64(n))
+	}
+}
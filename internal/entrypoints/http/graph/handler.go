@@ -0,0 +1,76 @@
+// Package graph serves the GraphQL API over films and actors.
+package graph
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
+	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+)
+
+const (
+	// MaxDepth caps the nesting of selections, e.g.
+	// films { cast { filmography { cast { lastName } } } } is 5 levels deep.
+	MaxDepth = 5
+	// MaxComplexity caps the number of films and actors resolved by a query.
+	MaxComplexity = 5000
+	// MaxRequestSize caps the size of the request body.
+	MaxRequestSize = 1 << 20
+)
+
+//go:embed schema.graphql
+var schema string
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type Handler struct {
+	schema *graphql.Schema
+}
+
+func NewHandler(
+	films v1.FilmService,
+	actors v1.ActorService,
+	catalog CatalogService,
+	validate *validator.Validate,
+) *Handler {
+	resolver := &Resolver{
+		films:    films,
+		actors:   actors,
+		catalog:  catalog,
+		validate: validate,
+	}
+
+	return &Handler{
+		schema: graphql.MustParseSchema(schema, resolver, graphql.MaxDepth(MaxDepth)),
+	}
+}
+
+// ServeHTTP executes the query of the request. Errors of the query are
+// returned in the response body with status 200 as GraphQL requires.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestSize)).Decode(&req)
+	if err != nil {
+		problems.Write(w, problems.FromError(problems.MalformedBody(err), http.StatusBadRequest))
+		return
+	}
+
+	ctx := withBudget(r.Context(), MaxComplexity)
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	writeJson(w, resp, http.StatusOK)
+}
+
+func writeJson(w http.ResponseWriter, data any, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
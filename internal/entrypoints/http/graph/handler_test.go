@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	mw "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/middlewares"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/catalog"
+	"github.com/sivistrukov/vk-assigment/internal/services/validator"
+)
+
+type fakeCatalog struct {
+	filmsLimit       atomic.Uint32
+	castCalls        atomic.Int32
+	filmographyCalls atomic.Int32
+}
+
+var (
+	drive  = schemas.FilmInfo{ID: 1, Title: "Drive", ReleaseDate: "03-11-2011"}
+	barbie = schemas.FilmInfo{ID: 3, Title: "Barbie", ReleaseDate: "20-07-2023"}
+	ryan   = schemas.ActorInfo{ID: 2, FirstName: "Ryan", LastName: "Gosling", Sex: models.Male}
+	margot = schemas.ActorInfo{ID: 4, FirstName: "Margot", LastName: "Robbie", Sex: models.Female}
+)
+
+func (c *fakeCatalog) GetFilms(
+	_ context.Context, limit *uint, _ uint,
+) ([]schemas.FilmInfo, error) {
+	c.filmsLimit.Store(uint32(*limit))
+	return []schemas.FilmInfo{drive, barbie}, nil
+}
+
+func (c *fakeCatalog) GetFilmsByIds(context.Context, []uint) ([]schemas.FilmInfo, error) {
+	return []schemas.FilmInfo{drive}, nil
+}
+
+func (c *fakeCatalog) GetActors(context.Context, *uint, uint) ([]schemas.ActorInfo, error) {
+	return []schemas.ActorInfo{ryan, margot}, nil
+}
+
+func (c *fakeCatalog) GetActorsByIds(context.Context, []uint) ([]schemas.ActorInfo, error) {
+	return []schemas.ActorInfo{ryan}, nil
+}
+
+func (c *fakeCatalog) GetCast(
+	context.Context, []uint,
+) (map[uint][]schemas.ActorInfo, error) {
+	c.castCalls.Add(1)
+	return map[uint][]schemas.ActorInfo{
+		1: {ryan},
+		3: {ryan, margot},
+	}, nil
+}
+
+func (c *fakeCatalog) GetFilmographies(
+	context.Context, []uint,
+) (map[uint][]schemas.FilmInfo, error) {
+	c.filmographyCalls.Add(1)
+	return map[uint][]schemas.FilmInfo{
+		2: {drive, barbie},
+		4: {barbie},
+	}, nil
+}
+
+func (c *fakeCatalog) CountCast(context.Context, []uint) (int, error) {
+	return 3, nil
+}
+
+func (c *fakeCatalog) CountFilmographies(context.Context, []uint) (int, error) {
+	return 5, nil
+}
+
+type fakeFilms struct {
+	v1.FilmService
+	added bool
+}
+
+func (f *fakeFilms) AddFilm(
+	_ context.Context, request schemas.AddFilmRequest, _ bool,
+) (models.Film, error) {
+	f.added = true
+	return models.Film{ID: 7, Title: request.Title, ReleaseDate: request.ReleaseDate.ToTime()}, nil
+}
+
+func execute(
+	t *testing.T, h *Handler, user models.User, budget int64, query string,
+) (string, []string) {
+	t.Helper()
+
+	ctx := context.WithValue(context.Background(), mw.Key("user"), user)
+	resp := h.schema.Exec(withBudget(ctx, budget), query, "", nil)
+
+	messages := make([]string, 0, len(resp.Errors))
+	for _, err := range resp.Errors {
+		messages = append(messages, err.Message)
+	}
+
+	return string(resp.Data), messages
+}
+
+func TestHandler(t *testing.T) {
+	nested := `{ films { title cast { lastName filmography { title } } } }`
+
+	t.Run("nested resolvers are batched", func(t *testing.T) {
+		catalog := &fakeCatalog{}
+		h := NewHandler(&fakeFilms{}, nil, catalog, validator.New())
+
+		data, errs := execute(t, h, models.User{}, MaxComplexity, nested)
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+
+		want := `{"films":[` +
+			`{"title":"Drive","cast":[{"lastName":"Gosling","filmography":[{"title":"Drive"},{"title":"Barbie"}]}]},` +
+			`{"title":"Barbie","cast":[{"lastName":"Gosling","filmography":[{"title":"Drive"},{"title":"Barbie"}]},` +
+			`{"lastName":"Robbie","filmography":[{"title":"Barbie"}]}]}]}`
+		if data != want {
+			t.Errorf("data = %s, want %s", data, want)
+		}
+
+		if n := catalog.castCalls.Load(); n != 1 {
+			t.Errorf("cast loaded %d times, want 1", n)
+		}
+		if n := catalog.filmographyCalls.Load(); n != 1 {
+			t.Errorf("filmographies loaded %d times, want 1", n)
+		}
+	})
+
+	t.Run("depth limit", func(t *testing.T) {
+		h := NewHandler(&fakeFilms{}, nil, &fakeCatalog{}, validator.New())
+
+		query := `{ films { cast { filmography { cast { filmography { title } } } } } }`
+		_, errs := execute(t, h, models.User{}, MaxComplexity, query)
+		if len(errs) == 0 || !strings.Contains(errs[0], "exceeds max depth") {
+			t.Errorf("errors = %v, want depth error", errs)
+		}
+	})
+
+	t.Run("complexity limit", func(t *testing.T) {
+		h := NewHandler(&fakeFilms{}, nil, &fakeCatalog{}, validator.New())
+
+		_, errs := execute(t, h, models.User{}, 4, nested)
+		if len(errs) == 0 || errs[0] != errTooComplex.Error() {
+			t.Errorf("errors = %v, want %v", errs, errTooComplex)
+		}
+	})
+
+	t.Run("page is limited and charged before loading", func(t *testing.T) {
+		fake := &fakeCatalog{}
+		h := NewHandler(&fakeFilms{}, nil, fake, validator.New())
+
+		_, errs := execute(t, h, models.User{}, MaxComplexity, `{ films(limit: 1000) { title } }`)
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		if n := fake.filmsLimit.Load(); n != catalog.MaxLimit {
+			t.Errorf("films limit = %d, want %d", n, catalog.MaxLimit)
+		}
+
+		fake = &fakeCatalog{}
+		h = NewHandler(&fakeFilms{}, nil, fake, validator.New())
+
+		_, errs = execute(t, h, models.User{}, 10, `{ films(limit: 11) { title } }`)
+		if len(errs) == 0 || errs[0] != errTooComplex.Error() {
+			t.Errorf("errors = %v, want %v", errs, errTooComplex)
+		}
+		if fake.filmsLimit.Load() != 0 {
+			t.Error("films were loaded over the budget")
+		}
+
+		_, errs = execute(t, h, models.User{}, 11, `{ films(limit: 11) { title } }`)
+		if len(errs) > 0 {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+	})
+
+	t.Run("mutations require admin", func(t *testing.T) {
+		films := &fakeFilms{}
+		h := NewHandler(films, nil, &fakeCatalog{}, validator.New())
+
+		mutation := `mutation { addFilm(input: {
+			title: "Drive", description: "", releaseDate: "03-11-2011", rating: 7, actorsIds: ["2"]
+		}) { id title } }`
+
+		_, errs := execute(t, h, models.User{}, MaxComplexity, mutation)
+		if len(errs) == 0 || errs[0] != errForbidden.Error() {
+			t.Errorf("errors = %v, want %v", errs, errForbidden)
+		}
+		if films.added {
+			t.Error("film was added by a reader")
+		}
+
+		data, errs := execute(t, h, models.User{IsAdmin: true}, MaxComplexity, mutation)
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		if want := `{"addFilm":{"id":"7","title":"Drive"}}`; data != want {
+			t.Errorf("data = %s, want %s", data, want)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+var errInvalidRating = errors.New("rating must be between 0 and 10")
+
+type filmInput struct {
+	Title       string
+	Description string
+	ReleaseDate string
+	Rating      int32
+	ActorsIds   []graphql.ID
+}
+
+func (i filmInput) request() (schemas.AddFilmRequest, error) {
+	if i.Rating < 0 || i.Rating > 10 {
+		return schemas.AddFilmRequest{}, errInvalidRating
+	}
+
+	actorsIds, err := parseIDs(i.ActorsIds)
+	if err != nil {
+		return schemas.AddFilmRequest{}, err
+	}
+
+	return schemas.AddFilmRequest{
+		Title:       i.Title,
+		Description: i.Description,
+		ReleaseDate: schemas.Date(i.ReleaseDate),
+		Rating:      uint8(i.Rating),
+		ActorsIDs:   actorsIds,
+	}, nil
+}
+
+type filmPatch struct {
+	Title       *string
+	Description *string
+	ReleaseDate *string
+	Rating      *int32
+	ActorsIds   *[]graphql.ID
+}
+
+func (i filmPatch) request() (schemas.PartialUpdateFilmRequest, error) {
+	request := schemas.PartialUpdateFilmRequest{
+		Title:       i.Title,
+		Description: i.Description,
+	}
+
+	if i.ReleaseDate != nil {
+		date := schemas.Date(*i.ReleaseDate)
+		request.ReleaseDate = &date
+	}
+
+	if i.Rating != nil {
+		if *i.Rating < 0 || *i.Rating > 10 {
+			return schemas.PartialUpdateFilmRequest{}, errInvalidRating
+		}
+		rating := uint8(*i.Rating)
+		request.Rating = &rating
+	}
+
+	if i.ActorsIds != nil {
+		actorsIds, err := parseIDs(*i.ActorsIds)
+		if err != nil {
+			return schemas.PartialUpdateFilmRequest{}, err
+		}
+		request.ActorsIDs = &actorsIds
+	}
+
+	return request, nil
+}
+
+type actorInput struct {
+	FirstName  string
+	LastName   string
+	MiddleName *string
+	Sex        string
+	Birthday   string
+}
+
+func (i actorInput) request() schemas.AddActorRequest {
+	return schemas.AddActorRequest{
+		FirstName:  i.FirstName,
+		LastName:   i.LastName,
+		MiddleName: i.MiddleName,
+		Sex:        models.Sex(i.Sex),
+		Birthday:   schemas.Date(i.Birthday),
+	}
+}
+
+type actorPatch struct {
+	FirstName  *string
+	LastName   *string
+	MiddleName *string
+	Sex        *string
+	Birthday   *string
+}
+
+func (i actorPatch) request() schemas.PartialUpdateActorRequest {
+	request := schemas.PartialUpdateActorRequest{
+		FirstName:  i.FirstName,
+		LastName:   i.LastName,
+		MiddleName: i.MiddleName,
+	}
+
+	if i.Sex != nil {
+		sex := models.Sex(*i.Sex)
+		request.Sex = &sex
+	}
+
+	if i.Birthday != nil {
+		date := schemas.Date(*i.Birthday)
+		request.Birthday = &date
+	}
+
+	return request
+}
+
+func parseIDs(ids []graphql.ID) ([]uint, error) {
+	values := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		value, err := parseID(id)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,329 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/go-playground/validator"
+	"github.com/graph-gophers/graphql-go"
+	mw "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/middlewares"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/catalog"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+)
+
+var (
+	errForbidden = errors.New("403 forbidden")
+	errInternal  = errors.New("internal server error")
+)
+
+type CatalogService interface {
+	GetFilms(context.Context, *uint, uint) ([]schemas.FilmInfo, error)
+	GetFilmsByIds(context.Context, []uint) ([]schemas.FilmInfo, error)
+	GetActors(context.Context, *uint, uint) ([]schemas.ActorInfo, error)
+	GetActorsByIds(context.Context, []uint) ([]schemas.ActorInfo, error)
+	GetCast(context.Context, []uint) (map[uint][]schemas.ActorInfo, error)
+	GetFilmographies(context.Context, []uint) (map[uint][]schemas.FilmInfo, error)
+	CountCast(context.Context, []uint) (int, error)
+	CountFilmographies(context.Context, []uint) (int, error)
+}
+
+// Resolver is the root of the queries and mutations. Mutations are
+// mapped to the services of the REST API and are allowed to admins only.
+type Resolver struct {
+	films    v1.FilmService
+	actors   v1.ActorService
+	catalog  CatalogService
+	validate *validator.Validate
+}
+
+type pageArgs struct {
+	Limit  *int32
+	Offset *int32
+}
+
+type idArgs struct {
+	ID graphql.ID
+}
+
+func (r *Resolver) Films(ctx context.Context, args pageArgs) ([]*FilmResolver, error) {
+	limit, offset, err := args.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	// the whole page is charged before it is loaded, the rest is returned
+	if err = charge(ctx, int(limit)); err != nil {
+		return nil, err
+	}
+
+	films, err := r.catalog.GetFilms(ctx, &limit, offset)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	refund(ctx, int(limit)-len(films))
+
+	return r.newFilms(films), nil
+}
+
+func (r *Resolver) Film(ctx context.Context, args idArgs) (*FilmResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.film(ctx, id)
+}
+
+func (r *Resolver) Actors(ctx context.Context, args pageArgs) ([]*ActorResolver, error) {
+	limit, offset, err := args.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = charge(ctx, int(limit)); err != nil {
+		return nil, err
+	}
+
+	actors, err := r.catalog.GetActors(ctx, &limit, offset)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+	refund(ctx, int(limit)-len(actors))
+
+	return r.newActors(actors), nil
+}
+
+func (r *Resolver) Actor(ctx context.Context, args idArgs) (*ActorResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.actor(ctx, id)
+}
+
+func (r *Resolver) Me(ctx context.Context) *UserResolver {
+	return &UserResolver{user: currentUser(ctx)}
+}
+
+func (r *Resolver) AddFilm(ctx context.Context, args struct {
+	Input filmInput
+	Force *bool
+}) (*FilmResolver, error) {
+	if !currentUser(ctx).IsAdmin {
+		return nil, errForbidden
+	}
+
+	request, err := args.Input.request()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.validate.Struct(request); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+
+	film, err := r.films.AddFilm(ctx, request, args.Force != nil && *args.Force)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	return r.newFilms([]schemas.FilmInfo{schemas.NewFilmInfo(film)})[0], nil
+}
+
+func (r *Resolver) UpdateFilm(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input filmPatch
+}) (*FilmResolver, error) {
+	if !currentUser(ctx).IsAdmin {
+		return nil, errForbidden
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := args.Input.request()
+	if err != nil {
+		return nil, err
+	}
+	if err = r.validate.Struct(request); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+
+	err = r.films.PartialUpdateFilm(ctx, id, request)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	return r.film(ctx, id)
+}
+
+func (r *Resolver) RemoveFilm(ctx context.Context, args idArgs) (bool, error) {
+	if !currentUser(ctx).IsAdmin {
+		return false, errForbidden
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.films.RemoveFilm(ctx, id)
+	if err != nil {
+		return false, resolverError(err)
+	}
+
+	return true, nil
+}
+
+func (r *Resolver) AddActor(ctx context.Context, args struct {
+	Input actorInput
+	Force *bool
+}) (*ActorResolver, error) {
+	if !currentUser(ctx).IsAdmin {
+		return nil, errForbidden
+	}
+
+	request := args.Input.request()
+	if err := r.validate.Struct(request); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+
+	actor, err := r.actors.AddActor(ctx, request, args.Force != nil && *args.Force)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	return r.newActors([]schemas.ActorInfo{schemas.NewActorInfo(actor)})[0], nil
+}
+
+func (r *Resolver) UpdateActor(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input actorPatch
+}) (*ActorResolver, error) {
+	if !currentUser(ctx).IsAdmin {
+		return nil, errForbidden
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	request := args.Input.request()
+	if err = r.validate.Struct(request); err != nil {
+		return nil, fmt.Errorf("invalid input: %v", err)
+	}
+
+	err = r.actors.PartialUpdateActor(ctx, id, request)
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	return r.actor(ctx, id)
+}
+
+func (r *Resolver) RemoveActor(ctx context.Context, args idArgs) (bool, error) {
+	if !currentUser(ctx).IsAdmin {
+		return false, errForbidden
+	}
+
+	id, err := parseID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.actors.RemoveActor(ctx, id)
+	if err != nil {
+		return false, resolverError(err)
+	}
+
+	return true, nil
+}
+
+func (r *Resolver) film(ctx context.Context, id uint) (*FilmResolver, error) {
+	films, err := r.catalog.GetFilmsByIds(ctx, []uint{id})
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	if len(films) == 0 {
+		return nil, nil
+	}
+
+	return r.newFilms(films)[0], nil
+}
+
+func (r *Resolver) actor(ctx context.Context, id uint) (*ActorResolver, error) {
+	actors, err := r.catalog.GetActorsByIds(ctx, []uint{id})
+	if err != nil {
+		return nil, resolverError(err)
+	}
+
+	if len(actors) == 0 {
+		return nil, nil
+	}
+
+	return r.newActors(actors)[0], nil
+}
+
+// parse returns the size of the page with the default and maximum of the
+// catalog applied, so the cost of the page is known before it is loaded.
+func (a pageArgs) parse() (uint, uint, error) {
+	var limit *uint
+	if a.Limit != nil {
+		if *a.Limit < 0 {
+			return 0, 0, errors.New("limit must not be negative")
+		}
+		n := uint(*a.Limit)
+		limit = &n
+	}
+
+	var offset uint
+	if a.Offset != nil {
+		if *a.Offset < 0 {
+			return 0, 0, errors.New("offset must not be negative")
+		}
+		offset = uint(*a.Offset)
+	}
+
+	return catalog.PageSize(limit), offset, nil
+}
+
+func parseID(id graphql.ID) (uint, error) {
+	value, err := strconv.ParseUint(string(id), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id: %s", id)
+	}
+
+	return uint(value), nil
+}
+
+func currentUser(ctx context.Context) models.User {
+	user, _ := ctx.Value(mw.Key("user")).(models.User)
+	return user
+}
+
+// resolverError hides the errors which are not caused by the request.
+func resolverError(err error) error {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	var duplicateErr *duplicates.ErrDuplicate
+	switch {
+	case errors.As(err, &notFoundErr),
+		errors.As(err, &existsErr),
+		errors.As(err, &duplicateErr):
+		return err
+	default:
+		log.Printf("graphql: %v", err)
+		return errInternal
+	}
+}
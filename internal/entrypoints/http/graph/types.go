@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type FilmResolver struct {
+	film  schemas.FilmInfo
+	batch *filmBatch
+}
+
+func (r *FilmResolver) ID() graphql.ID {
+	return toID(r.film.ID)
+}
+
+func (r *FilmResolver) Title() string {
+	return r.film.Title
+}
+
+func (r *FilmResolver) Description() string {
+	return r.film.Description
+}
+
+func (r *FilmResolver) ReleaseDate() string {
+	return string(r.film.ReleaseDate)
+}
+
+func (r *FilmResolver) Rating() int32 {
+	return int32(r.film.Rating)
+}
+
+func (r *FilmResolver) Runtime() *int32 {
+	if r.film.Runtime == nil {
+		return nil
+	}
+
+	runtime := int32(*r.film.Runtime)
+	return &runtime
+}
+
+func (r *FilmResolver) Countries() []string {
+	if r.film.Countries == nil {
+		return []string{}
+	}
+
+	return r.film.Countries
+}
+
+func (r *FilmResolver) Language() *string {
+	return r.film.Language
+}
+
+func (r *FilmResolver) AgeRating() *string {
+	if r.film.AgeRating == nil {
+		return nil
+	}
+
+	rating := string(*r.film.AgeRating)
+	return &rating
+}
+
+func (r *FilmResolver) Cast(ctx context.Context) ([]*ActorResolver, error) {
+	return r.batch.castOf(ctx, r.film.ID)
+}
+
+type ActorResolver struct {
+	actor schemas.ActorInfo
+	batch *actorBatch
+}
+
+func (r *ActorResolver) ID() graphql.ID {
+	return toID(r.actor.ID)
+}
+
+func (r *ActorResolver) FirstName() string {
+	return r.actor.FirstName
+}
+
+func (r *ActorResolver) LastName() string {
+	return r.actor.LastName
+}
+
+func (r *ActorResolver) MiddleName() *string {
+	return r.actor.MiddleName
+}
+
+func (r *ActorResolver) Sex() string {
+	return string(r.actor.Sex)
+}
+
+func (r *ActorResolver) Birthday() string {
+	return string(r.actor.Birthday)
+}
+
+func (r *ActorResolver) Filmography(ctx context.Context) ([]*FilmResolver, error) {
+	return r.batch.filmographyOf(ctx, r.actor.ID)
+}
+
+type UserResolver struct {
+	user models.User
+}
+
+func (r *UserResolver) ID() graphql.ID {
+	return toID(r.user.ID)
+}
+
+func (r *UserResolver) Username() string {
+	return r.user.Username
+}
+
+func (r *UserResolver) IsAdmin() bool {
+	return r.user.IsAdmin
+}
+
+func toID(id uint) graphql.ID {
+	return graphql.ID(fmt.Sprintf("%d", id))
+}
@@ -1,21 +1,73 @@
 package http
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/go-playground/validator"
 	_ "github.com/sivistrukov/vk-assigment/docs"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/graph"
 	mw "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/middlewares"
 	v1 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v1"
+	v2 "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/v2"
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
 	httpSwag "github.com/swaggo/http-swagger/v2"
 )
 
+// readCache tracks the modification of the cached reads of the films
+// and actors.
+type readCache interface {
+	Modified(context.Context) time.Time
+}
+
+// filmService, actorService and userService are served by both versions
+// of the API.
+type filmService interface {
+	v1.FilmService
+	v2.FilmService
+}
+
+type actorService interface {
+	v1.ActorService
+	v2.ActorService
+}
+
+type userService interface {
+	v1.UserService
+	v2.UserService
+}
+
+// rateLimiter counts the requests of the clients.
+type rateLimiter interface {
+	Take(ctx context.Context, key string, write bool) (ratelimit.Result, error)
+	Check(ctx context.Context, key string, write bool) (ratelimit.Result, error)
+}
+
 func NewHandler(
 	validator *validator.Validate,
 	authService authService,
-	userService v1.UserService,
-	actorsService v1.ActorService,
-	filmsService v1.FilmService,
+	userService userService,
+	actorsService actorService,
+	filmsService filmService,
+	collectionsService v1.CollectionService,
+	mediaService v1.MediaService,
+	mediaFiles http.Handler,
+	awardsService v1.AwardService,
+	translationsService v1.TranslationService,
+	franchisesService v1.FranchiseService,
+	similarService v1.SimilarService,
+	statsService v1.StatsService,
+	importService v1.ImportService,
+	exportService v1.ExportService,
+	transactor v1.Transactor,
+	castService v1.CastService,
+	duplicateService v1.DuplicateService,
+	catalogService graph.CatalogService,
+	eventService v1.EventService,
+	webhookService v1.WebhookService,
+	cache readCache,
+	limiter rateLimiter,
 ) http.Handler {
 	mux := http.NewServeMux()
 
@@ -23,37 +75,243 @@ func NewHandler(
 	usersHandlers := v1.NewUsersHandler(userService, validator)
 	mux.Handle("POST /api/v1/users", usersHandlers.Create())
 
+	mediaHandler := v1.NewMediaHandler(mediaService)
+	translationsHandler := v1.NewTranslationsHandler(translationsService, validator)
+	franchisesHandler := v1.NewFranchisesHandler(franchisesService, validator)
+	similarHandler := v1.NewSimilarHandler(similarService)
+	batchHandler := v1.NewBatchHandler(actorsService, filmsService, transactor, validator)
+	castHandler := v1.NewCastHandler(castService, validator)
+
 	// actors
 	actorsHandler := v1.NewActorHandler(actorsService, validator)
 	readerActorsMux := http.NewServeMux()
-	readerActorsMux.Handle("/", actorsHandler.GetList())
+	readerActorsMux.Handle("GET /api/v1/actors", mw.LastModified(actorsHandler.GetList(), cache))
+	readerActorsMux.Handle("GET /api/v1/actors/{id}/costars", mw.LastModified(actorsHandler.GetCostars(), cache))
+	readerActorsMux.Handle("GET /api/v1/actors/{a}/path/{b}", mw.LastModified(actorsHandler.GetPath(), cache))
+	readerActorsMux.Handle("GET /api/v1/actors/{id}/filmography", mw.LastModified(actorsHandler.GetFilmography(), cache))
+	readerActorsMux.Handle("GET /api/v1/actors/{id}/films", castHandler.GetActorFilms())
 
 	adminActorsMux := http.NewServeMux()
-	adminActorsMux.Handle("POST /", actorsHandler.Add())
-	adminActorsMux.Handle("PUT /{id}", actorsHandler.Update())
-	adminActorsMux.Handle("PATCH /{id}", actorsHandler.PartialUpdate())
-	adminActorsMux.Handle("DELETE /{id}", actorsHandler.Remove())
+	adminActorsMux.Handle("POST /api/v1/actors", actorsHandler.Add())
+	adminActorsMux.Handle("POST /api/v1/actors:batch", batchHandler.Actors())
+	adminActorsMux.Handle("PUT /api/v1/actors/{id}", actorsHandler.Update())
+	adminActorsMux.Handle("PATCH /api/v1/actors/{id}", actorsHandler.PartialUpdate())
+	adminActorsMux.Handle("DELETE /api/v1/actors/{id}", actorsHandler.Remove())
+	adminActorsMux.Handle("POST /api/v1/actors/{id}/merge", actorsHandler.Merge())
+	adminActorsMux.Handle("POST /api/v1/actors/{id}/headshot", mediaHandler.UploadActorHeadshot())
+	adminActorsMux.Handle("DELETE /api/v1/actors/{id}/headshot", mediaHandler.RemoveActorHeadshot())
+	adminActorsMux.Handle("GET /api/v1/actors/{id}/translations", translationsHandler.GetActorTranslations())
+	adminActorsMux.Handle("PUT /api/v1/actors/{id}/translations/{locale}", translationsHandler.SetActorTranslation())
+	adminActorsMux.Handle("DELETE /api/v1/actors/{id}/translations/{locale}", translationsHandler.RemoveActorTranslation())
+	adminActorsMux.Handle("PUT /api/v1/actors/{id}/films", castHandler.SetActorFilms())
+	adminActorsMux.Handle("POST /api/v1/actors/{id}/films/{filmId}", castHandler.AddActorFilm())
+	adminActorsMux.Handle("DELETE /api/v1/actors/{id}/films/{filmId}", castHandler.RemoveActorFilm())
 
 	adminActorRouter := mw.BasicAuth(mw.AdminRoutes(adminActorsMux), authService)
 	readerActorsRouter := mw.BasicAuth(readerActorsMux, authService)
 	mux.Handle("GET /api/v1/actors", readerActorsRouter)
+	mux.Handle("GET /api/v1/actors/{id}/costars", readerActorsRouter)
+	mux.Handle("GET /api/v1/actors/{a}/path/{b}", readerActorsRouter)
+	mux.Handle("GET /api/v1/actors/{id}/filmography", readerActorsRouter)
+	mux.Handle("GET /api/v1/actors/{id}/films", readerActorsRouter)
 	mux.Handle("/api/v1/actors", adminActorRouter)
+	mux.Handle("/api/v1/actors:batch", adminActorRouter)
+	mux.Handle("/api/v1/actors/", adminActorRouter)
 
 	//films
 	filmsHandler := v1.NewFilmsHandler(filmsService, validator)
 	readerFilmsMux := http.NewServeMux()
-	readerFilmsMux.Handle("/", filmsHandler.GetList())
+	readerFilmsMux.Handle("GET /api/v1/films", mw.LastModified(filmsHandler.GetList(), cache))
+	readerFilmsMux.Handle("GET /api/v1/films/{id}/similar", similarHandler.GetSimilar())
+	readerFilmsMux.Handle("GET /api/v1/films/{id}/actors", castHandler.GetFilmActors())
 
 	adminFilmsMux := http.NewServeMux()
-	adminFilmsMux.Handle("POST /", filmsHandler.Add())
-	adminFilmsMux.Handle("PUT /{id}", filmsHandler.Update())
-	adminFilmsMux.Handle("PATCH /{id}", filmsHandler.PartialUpdate())
-	adminFilmsMux.Handle("DELETE /{id}", filmsHandler.Remove())
+	adminFilmsMux.Handle("POST /api/v1/films", filmsHandler.Add())
+	adminFilmsMux.Handle("POST /api/v1/films:batch", batchHandler.Films())
+	adminFilmsMux.Handle("PUT /api/v1/films/{id}", filmsHandler.Update())
+	adminFilmsMux.Handle("PATCH /api/v1/films/{id}", filmsHandler.PartialUpdate())
+	adminFilmsMux.Handle("DELETE /api/v1/films/{id}", filmsHandler.Remove())
+	adminFilmsMux.Handle("POST /api/v1/films/{id}/poster", mediaHandler.UploadFilmPoster())
+	adminFilmsMux.Handle("DELETE /api/v1/films/{id}/poster", mediaHandler.RemoveFilmPoster())
+	adminFilmsMux.Handle("GET /api/v1/films/{id}/translations", translationsHandler.GetFilmTranslations())
+	adminFilmsMux.Handle("PUT /api/v1/films/{id}/translations/{locale}", translationsHandler.SetFilmTranslation())
+	adminFilmsMux.Handle("DELETE /api/v1/films/{id}/translations/{locale}", translationsHandler.RemoveFilmTranslation())
+	adminFilmsMux.Handle("POST /api/v1/films/{id}/relations", franchisesHandler.AddRelation())
+	adminFilmsMux.Handle("DELETE /api/v1/films/{id}/relations/{relatedId}", franchisesHandler.RemoveRelation())
+	adminFilmsMux.Handle("PUT /api/v1/films/{id}/actors", castHandler.SetFilmActors())
+	adminFilmsMux.Handle("POST /api/v1/films/{id}/actors/{actorId}", castHandler.AddFilmActor())
+	adminFilmsMux.Handle("DELETE /api/v1/films/{id}/actors/{actorId}", castHandler.RemoveFilmActor())
 
 	readerFilmsRouter := mw.BasicAuth(readerFilmsMux, authService)
 	adminFilmsRouter := mw.BasicAuth(mw.AdminRoutes(adminFilmsMux), authService)
 	mux.Handle("GET /api/v1/films", readerFilmsRouter)
+	mux.Handle("GET /api/v1/films/{id}/similar", readerFilmsRouter)
+	mux.Handle("GET /api/v1/films/{id}/actors", readerFilmsRouter)
 	mux.Handle("/api/v1/films", adminFilmsRouter)
+	mux.Handle("/api/v1/films:batch", adminFilmsRouter)
+	mux.Handle("/api/v1/films/", adminFilmsRouter)
+
+	// collections
+	collectionsHandler := v1.NewCollectionsHandler(collectionsService, validator)
+	collectionsMux := http.NewServeMux()
+	collectionsMux.Handle("GET /api/v1/collections", collectionsHandler.GetList())
+	collectionsMux.Handle("POST /api/v1/collections", collectionsHandler.Add())
+	collectionsMux.Handle("GET /api/v1/collections/{id}", collectionsHandler.Get())
+	collectionsMux.Handle("PATCH /api/v1/collections/{id}", collectionsHandler.PartialUpdate())
+	collectionsMux.Handle("DELETE /api/v1/collections/{id}", collectionsHandler.Remove())
+	collectionsMux.Handle("PUT /api/v1/collections/{id}/items", collectionsHandler.Reorder())
+	collectionsMux.Handle("POST /api/v1/collections/{id}/items", collectionsHandler.AddFilm())
+	collectionsMux.Handle("DELETE /api/v1/collections/{id}/items/{filmId}", collectionsHandler.RemoveFilm())
+
+	collectionsRouter := mw.BasicAuth(collectionsMux, authService)
+	mux.Handle("/api/v1/collections", collectionsRouter)
+	mux.Handle("/api/v1/collections/", collectionsRouter)
+
+	// awards
+	awardsHandler := v1.NewAwardsHandler(awardsService, validator)
+	readerAwardsMux := http.NewServeMux()
+	readerAwardsMux.Handle("GET /api/v1/awards/ceremonies", awardsHandler.GetCeremonies())
+	readerAwardsMux.Handle("GET /api/v1/awards/ceremonies/{id}/years/{year}", awardsHandler.GetCeremonyYear())
+	readerAwardsMux.Handle("GET /api/v1/films/{id}/awards", awardsHandler.GetFilmAwards())
+	readerAwardsMux.Handle("GET /api/v1/actors/{id}/awards", awardsHandler.GetActorAwards())
+
+	adminAwardsMux := http.NewServeMux()
+	adminAwardsMux.Handle("POST /api/v1/awards/ceremonies", awardsHandler.AddCeremony())
+	adminAwardsMux.Handle("PUT /api/v1/awards/ceremonies/{id}", awardsHandler.UpdateCeremony())
+	adminAwardsMux.Handle("DELETE /api/v1/awards/ceremonies/{id}", awardsHandler.RemoveCeremony())
+	adminAwardsMux.Handle("POST /api/v1/awards/ceremonies/{id}/categories", awardsHandler.AddCategory())
+	adminAwardsMux.Handle("DELETE /api/v1/awards/categories/{id}", awardsHandler.RemoveCategory())
+	adminAwardsMux.Handle("POST /api/v1/awards/nominations", awardsHandler.AddNomination())
+	adminAwardsMux.Handle("PATCH /api/v1/awards/nominations/{id}", awardsHandler.PartialUpdateNomination())
+	adminAwardsMux.Handle("DELETE /api/v1/awards/nominations/{id}", awardsHandler.RemoveNomination())
+
+	readerAwardsRouter := mw.BasicAuth(readerAwardsMux, authService)
+	adminAwardsRouter := mw.BasicAuth(mw.AdminRoutes(adminAwardsMux), authService)
+	mux.Handle("GET /api/v1/awards/", readerAwardsRouter)
+	mux.Handle("GET /api/v1/films/{id}/awards", readerAwardsRouter)
+	mux.Handle("GET /api/v1/actors/{id}/awards", readerAwardsRouter)
+	mux.Handle("/api/v1/awards/", adminAwardsRouter)
+
+	// franchises
+	readerFranchisesMux := http.NewServeMux()
+	readerFranchisesMux.Handle("GET /api/v1/franchises", franchisesHandler.GetList())
+	readerFranchisesMux.Handle("GET /api/v1/franchises/{id}", franchisesHandler.Get())
+	readerFranchisesMux.Handle("GET /api/v1/films/{id}/related", franchisesHandler.GetRelated())
+
+	adminFranchisesMux := http.NewServeMux()
+	adminFranchisesMux.Handle("POST /api/v1/franchises", franchisesHandler.Add())
+	adminFranchisesMux.Handle("PATCH /api/v1/franchises/{id}", franchisesHandler.PartialUpdate())
+	adminFranchisesMux.Handle("DELETE /api/v1/franchises/{id}", franchisesHandler.Remove())
+	adminFranchisesMux.Handle("PUT /api/v1/franchises/{id}/films", franchisesHandler.SetFilms())
+
+	readerFranchisesRouter := mw.BasicAuth(readerFranchisesMux, authService)
+	adminFranchisesRouter := mw.BasicAuth(mw.AdminRoutes(adminFranchisesMux), authService)
+	mux.Handle("GET /api/v1/franchises", readerFranchisesRouter)
+	mux.Handle("GET /api/v1/franchises/", readerFranchisesRouter)
+	mux.Handle("GET /api/v1/films/{id}/related", readerFranchisesRouter)
+	mux.Handle("/api/v1/franchises", adminFranchisesRouter)
+	mux.Handle("/api/v1/franchises/", adminFranchisesRouter)
+
+	// stats
+	statsHandler := v1.NewStatsHandler(statsService)
+	adminStatsMux := http.NewServeMux()
+	adminStatsMux.Handle("GET /api/v1/stats", statsHandler.Get())
+
+	adminStatsRouter := mw.BasicAuth(mw.AdminRoutes(adminStatsMux), authService)
+	mux.Handle("/api/v1/stats", adminStatsRouter)
+
+	// duplicates
+	duplicatesHandler := v1.NewDuplicatesHandler(duplicateService)
+	adminDuplicatesMux := http.NewServeMux()
+	adminDuplicatesMux.Handle("GET /api/v1/duplicates", duplicatesHandler.Report())
+
+	adminDuplicatesRouter := mw.BasicAuth(mw.AdminRoutes(adminDuplicatesMux), authService)
+	mux.Handle("/api/v1/duplicates", adminDuplicatesRouter)
+
+	// import
+	importHandler := v1.NewImportHandler(importService)
+	adminImportMux := http.NewServeMux()
+	adminImportMux.Handle("POST /api/v1/import", mw.ReadTimeout(
+		mw.WriteTimeout(importHandler.Import(), v1.ImportTimeout), v1.ImportTimeout,
+	))
+
+	adminImportRouter := mw.BasicAuth(mw.AdminRoutes(adminImportMux), authService)
+	mux.Handle("/api/v1/import", adminImportRouter)
+
+	// export
+	exportHandler := v1.NewExportHandler(exportService)
+	adminExportMux := http.NewServeMux()
+	adminExportMux.Handle("GET /api/v1/export", mw.WriteTimeout(exportHandler.Export(), v1.ExportWriteTimeout))
+
+	adminExportRouter := mw.BasicAuth(mw.AdminRoutes(adminExportMux), authService)
+	mux.Handle("/api/v1/export", adminExportRouter)
+
+	// events
+	eventsHandler := v1.NewEventsHandler(eventService)
+	mux.Handle("GET /api/v1/events", mw.BasicAuth(eventsHandler.Stream(), authService))
+
+	// webhooks
+	webhooksHandler := v1.NewWebhooksHandler(webhookService, validator)
+	adminWebhooksMux := http.NewServeMux()
+	adminWebhooksMux.Handle("GET /api/v1/webhooks", webhooksHandler.GetList())
+	adminWebhooksMux.Handle("POST /api/v1/webhooks", webhooksHandler.Add())
+	adminWebhooksMux.Handle("PUT /api/v1/webhooks/{id}", webhooksHandler.Update())
+	adminWebhooksMux.Handle("DELETE /api/v1/webhooks/{id}", webhooksHandler.Remove())
+	adminWebhooksMux.Handle("GET /api/v1/webhooks/{id}/deliveries", webhooksHandler.GetDeliveries())
+
+	adminWebhooksRouter := mw.BasicAuth(mw.AdminRoutes(adminWebhooksMux), authService)
+	mux.Handle("/api/v1/webhooks", adminWebhooksRouter)
+	mux.Handle("/api/v1/webhooks/", adminWebhooksRouter)
+
+	// v2 reuses the services of v1 with its own schemas
+	usersV2Handler := v2.NewUsersHandler(userService, validator)
+	mux.Handle("POST /api/v2/users", usersV2Handler.Create())
+	mux.Handle("GET /api/v2/users/{id}", mw.BasicAuth(usersV2Handler.Get(), authService))
+
+	actorsV2Handler := v2.NewActorsHandler(actorsService, validator)
+	readerActorsV2Mux := http.NewServeMux()
+	readerActorsV2Mux.Handle("GET /api/v2/actors", mw.LastModified(actorsV2Handler.GetList(), cache))
+	readerActorsV2Mux.Handle("GET /api/v2/actors/{id}", mw.LastModified(actorsV2Handler.Get(), cache))
+
+	adminActorsV2Mux := http.NewServeMux()
+	adminActorsV2Mux.Handle("POST /api/v2/actors", actorsV2Handler.Add())
+	adminActorsV2Mux.Handle("PUT /api/v2/actors/{id}", actorsV2Handler.Update())
+	adminActorsV2Mux.Handle("PATCH /api/v2/actors/{id}", actorsV2Handler.PartialUpdate())
+	adminActorsV2Mux.Handle("DELETE /api/v2/actors/{id}", actorsV2Handler.Remove())
+
+	adminActorsV2Router := mw.BasicAuth(mw.AdminRoutes(adminActorsV2Mux), authService)
+	readerActorsV2Router := mw.BasicAuth(readerActorsV2Mux, authService)
+	mux.Handle("GET /api/v2/actors", readerActorsV2Router)
+	mux.Handle("GET /api/v2/actors/{id}", readerActorsV2Router)
+	mux.Handle("/api/v2/actors", adminActorsV2Router)
+	mux.Handle("/api/v2/actors/", adminActorsV2Router)
+
+	filmsV2Handler := v2.NewFilmsHandler(filmsService, validator)
+	readerFilmsV2Mux := http.NewServeMux()
+	readerFilmsV2Mux.Handle("GET /api/v2/films", mw.LastModified(filmsV2Handler.GetList(), cache))
+	readerFilmsV2Mux.Handle("GET /api/v2/films/{id}", mw.LastModified(filmsV2Handler.Get(), cache))
+
+	adminFilmsV2Mux := http.NewServeMux()
+	adminFilmsV2Mux.Handle("POST /api/v2/films", filmsV2Handler.Add())
+	adminFilmsV2Mux.Handle("PUT /api/v2/films/{id}", filmsV2Handler.Update())
+	adminFilmsV2Mux.Handle("PATCH /api/v2/films/{id}", filmsV2Handler.PartialUpdate())
+	adminFilmsV2Mux.Handle("DELETE /api/v2/films/{id}", filmsV2Handler.Remove())
+
+	adminFilmsV2Router := mw.BasicAuth(mw.AdminRoutes(adminFilmsV2Mux), authService)
+	readerFilmsV2Router := mw.BasicAuth(readerFilmsV2Mux, authService)
+	mux.Handle("GET /api/v2/films", readerFilmsV2Router)
+	mux.Handle("GET /api/v2/films/{id}", readerFilmsV2Router)
+	mux.Handle("/api/v2/films", adminFilmsV2Router)
+	mux.Handle("/api/v2/films/", adminFilmsV2Router)
+
+	// graphql, mutations check the admin role themselves
+	graphHandler := graph.NewHandler(filmsService, actorsService, catalogService, validator)
+	mux.Handle("POST /api/graphql", mw.BasicAuth(graphHandler, authService))
+
+	// media files are served by the application only for local storage
+	if mediaFiles != nil {
+		mux.Handle("GET /media/", http.StripPrefix("/media/", mediaFiles))
+	}
 
 	mux.Handle("/swagger/", httpSwag.Handler(
 		httpSwag.URL("http://localhost:8080/swagger/doc.json"),
@@ -62,7 +320,18 @@ func NewHandler(
 		httpSwag.DomID("swagger-ui"),
 	))
 
-	handler := mw.Logging(mw.PanicRecover(mux))
+	// the credentials are checked once for the rate limit and the routes,
+	// the addresses guessing passwords are rejected before the check
+	limited := mw.LimitCredentials(
+		mw.Authenticate(mw.RateLimit(mux, limiter), authService), limiter,
+	)
+	api := mw.PanicRecover(mw.Locale(limited))
+
+	// v2 wraps the errors of the shared middlewares into its envelope
+	root := http.NewServeMux()
+	root.Handle("/", api)
+	root.Handle("/api/v2/", v2.EnvelopeErrors(api))
+	handler := mw.RequestID(mw.Logging(root))
 
 	return handler
 }
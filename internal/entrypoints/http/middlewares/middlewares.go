@@ -2,35 +2,77 @@ package middlewares
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/base64"
-	"encoding/json"
+	"encoding/hex"
 	"fmt"
 	"log"
+	"math"
+	"net"
 	"net/http"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
 	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/i18n"
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
 )
 
 type authService interface {
 	Authenticate(string, string) (models.User, error)
 }
 
+type modificationTracker interface {
+	Modified(context.Context) time.Time
+}
+
+type rateLimiter interface {
+	Take(ctx context.Context, key string, write bool) (ratelimit.Result, error)
+	Check(ctx context.Context, key string, write bool) (ratelimit.Result, error)
+}
+
 type Key string
 
+// matchRequestID matches the request ids accepted from the clients.
+var matchRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID sets the X-Request-ID response header to the id sent by the
+// client or to the new random id. The id is logged and returned in the
+// problem details.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(problems.RequestIDHeader)
+		if !matchRequestID.MatchString(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(problems.RequestIDHeader, id)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
 func PanicRecover(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Println(r.Method, r.URL.Path, fmt.Sprintf("got error: %v", err))
+				// the handler aborts the response which is already sent
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
 
-				response, _ := json.Marshal(schemas.ErrorResponse{
-					Error: "internal server error",
-				})
+				log.Println(r.Method, r.URL.Path, fmt.Sprintf("got error: %v", err))
 
-				w.WriteHeader(http.StatusInternalServerError)
-				_, _ = w.Write(response)
+				problems.Write(w, problems.New(http.StatusInternalServerError, ""))
 			}
 		}()
 
@@ -38,49 +80,227 @@ func PanicRecover(next http.Handler) http.Handler {
 	})
 }
 
-func Logging(next http.Handler) http.Handler {
+// WriteTimeout replaces the server write timeout for the long responses.
+func WriteTimeout(next http.Handler, timeout time.Duration) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		log.Println(r.Method, r.URL.Path)
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Now().Add(timeout))
 
 		next.ServeHTTP(w, r)
 	})
 }
 
-func BasicAuth(next http.Handler, auth authService) http.Handler {
+// ReadTimeout replaces the server read timeout for the large uploads.
+func ReadTimeout(next http.Handler, timeout time.Duration) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			unauthorized(w)
+		rc := http.NewResponseController(w)
+		_ = rc.SetReadDeadline(time.Now().Add(timeout))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LastModified makes the clients revalidate the cached reads with the time
+// of their last modification. The handler is skipped with 304 if the reads
+// were not modified since the time sent in If-Modified-Since.
+func LastModified(next http.Handler, tracker modificationTracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		modified := tracker.Modified(r.Context())
+		w.Header().Set("Cache-Control", "private, no-cache")
+		w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err == nil && !modified.After(since) {
+			w.WriteHeader(http.StatusNotModified)
 			return
 		}
 
-		credentials := strings.SplitN(authHeader, " ", 2)
-		if len(credentials) != 2 || credentials[0] != "Basic" {
-			unauthorized(w)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func Logging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Println(r.Method, r.URL.Path, w.Header().Get(problems.RequestIDHeader))
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Locale stores the preferred locales in the request context. The "lang"
+// query parameter takes precedence over the Accept-Language header.
+func Locale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locales := i18n.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+
+		lang := i18n.Normalize(r.URL.Query().Get("lang"))
+		if i18n.IsValid(lang) {
+			locales = append([]string{lang}, locales...)
+		}
+
+		w.Header().Add("Vary", "Accept-Language")
+
+		ctx := i18n.WithLocales(r.Context(), i18n.FallbackChain(locales))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Authenticate stores the user of the valid basic credentials in the request
+// context, the requests without them are passed on anonymously. The
+// credentials are checked once per request.
+func Authenticate(next http.Handler, auth authService) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if _, checked := ctx.Value(credentialsChecked{}).(bool); checked {
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		payload, err := base64.StdEncoding.DecodeString(credentials[1])
+		ctx = context.WithValue(ctx, credentialsChecked{}, true)
+		if user, ok := authenticate(r, auth); ok {
+			ctx = context.WithValue(ctx, Key("user"), user)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RateLimit limits the requests of the user authenticated by Authenticate
+// or of the client address of the anonymous requests. The safe methods are
+// counted as reads and the others as writes. The requests with the wrong
+// credentials are counted as writes of the address in the bucket checked by
+// LimitCredentials. The requests are passed on if the limiter fails.
+func RateLimit(next http.Handler, limiter rateLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := "ip:" + clientIP(r)
+		write := r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions
+		if user, ok := r.Context().Value(Key("user")).(models.User); ok {
+			key = "user:" + user.Username
+		} else if r.Header.Get("Authorization") != "" {
+			key, write = failedCredentialsKey(r), true
+		}
+
+		result, err := limiter.Take(r.Context(), key, write)
 		if err != nil {
-			unauthorized(w)
+			log.Printf("error limiting rate of %s: %v", key, err)
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		pair := strings.SplitN(string(payload), ":", 2)
+		setRateLimit(w, result)
+		if !result.Allowed {
+			tooManyRequests(w, result)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// LimitCredentials rejects the requests with credentials from the address
+// which used up its writes with the wrong credentials, so the passwords are
+// not guessed faster than the writes are made. It runs before Authenticate,
+// the password hash is not checked for the rejected requests.
+func LimitCredentials(next http.Handler, limiter rateLimiter) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
 
-		user, err := auth.Authenticate(pair[0], pair[1])
+		key := failedCredentialsKey(r)
+		result, err := limiter.Check(r.Context(), key, true)
 		if err != nil {
-			unauthorized(w)
+			log.Printf("error limiting rate of %s: %v", key, err)
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		ctx := r.Context()
-		ctx = context.WithValue(ctx, Key("user"), user)
+		if !result.Allowed {
+			setRateLimit(w, result)
+			tooManyRequests(w, result)
+			return
+		}
 
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, r)
 	})
 }
 
+// failedCredentialsKey returns the bucket of the wrong credentials sent
+// from the client address.
+func failedCredentialsKey(r *http.Request) string {
+	return "credentials:" + clientIP(r)
+}
+
+func setRateLimit(w http.ResponseWriter, result ratelimit.Result) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	w.Header().Set("RateLimit-Reset", ceilSeconds(result.Reset))
+}
+
+func tooManyRequests(w http.ResponseWriter, result ratelimit.Result) {
+	w.Header().Set("Retry-After", ceilSeconds(result.RetryAfter))
+	problems.Write(w, problems.New(http.StatusTooManyRequests, "rate limit exceeded"))
+}
+
+// clientIP returns the address of the client connected to the server.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+func BasicAuth(next http.Handler, auth authService) http.Handler {
+	return Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(Key("user")).(models.User); !ok {
+			unauthorized(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}), auth)
+}
+
+// credentialsChecked marks the context of the request which credentials
+// were checked by Authenticate.
+type credentialsChecked struct{}
+
+func authenticate(r *http.Request, auth authService) (models.User, bool) {
+	authHeader := r.Header.Get("Authorization")
+	if authHeader == "" {
+		return models.User{}, false
+	}
+
+	credentials := strings.SplitN(authHeader, " ", 2)
+	if len(credentials) != 2 || credentials[0] != "Basic" {
+		return models.User{}, false
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(credentials[1])
+	if err != nil {
+		return models.User{}, false
+	}
+
+	username, password, ok := strings.Cut(string(payload), ":")
+	if !ok {
+		return models.User{}, false
+	}
+
+	user, err := auth.Authenticate(username, password)
+	if err != nil {
+		return models.User{}, false
+	}
+
+	return user, true
+}
+
 func AdminRoutes(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -101,17 +321,9 @@ func AdminRoutes(next http.Handler) http.Handler {
 
 func unauthorized(w http.ResponseWriter) {
 	w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
-	w.WriteHeader(http.StatusUnauthorized)
-	response, _ := json.Marshal(schemas.ErrorResponse{
-		Error: "401 unauthorized",
-	})
-	_, _ = w.Write(response)
+	problems.Write(w, problems.New(http.StatusUnauthorized, "valid basic credentials are required"))
 }
 
 func forbidden(w http.ResponseWriter) {
-	w.WriteHeader(http.StatusForbidden)
-	response, _ := json.Marshal(schemas.ErrorResponse{
-		Error: "403 forbidden",
-	})
-	_, _ = w.Write(response)
+	problems.Write(w, problems.New(http.StatusForbidden, "the route is available to administrators only"))
 }
@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
+)
+
+// countingAuth accepts the admin password and counts the checks.
+type countingAuth struct {
+	checks int
+}
+
+func (a *countingAuth) Authenticate(username string, password string) (models.User, error) {
+	a.checks++
+	if username == "admin" && password == "admin" {
+		return models.User{ID: 1, Username: "admin", IsAdmin: true}, nil
+	}
+	return models.User{}, errors.New("not authorized")
+}
+
+func TestLimitCredentials(t *testing.T) {
+	auth := &countingAuth{}
+	limiter := ratelimit.NewLimiter(
+		ratelimit.NewMemory(),
+		ratelimit.Limit{Requests: 10, Period: time.Minute},
+		ratelimit.Limit{Requests: 2, Period: time.Minute},
+	)
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	h := LimitCredentials(Authenticate(RateLimit(ok, limiter), auth), limiter)
+
+	serve := func(username, password string) int {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/films", nil)
+		r.SetBasicAuth(username, password)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := serve("admin", "guess"); code != http.StatusOK {
+			t.Errorf("wrong password %d: status = %d, want %d", i, code, http.StatusOK)
+		}
+	}
+
+	checks := auth.checks
+	if code := serve("admin", "admin"); code != http.StatusTooManyRequests {
+		t.Errorf("status = %d after the failures, want %d", code, http.StatusTooManyRequests)
+	}
+	if auth.checks != checks {
+		t.Error("credentials were checked after the failures used up the writes")
+	}
+
+	// the failures of the other addresses are counted separately
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/films", nil)
+	r.RemoteAddr = "192.0.2.2:1234"
+	r.SetBasicAuth("admin", "admin")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d for another address, want %d", w.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,258 @@
+// Package problems writes the RFC 7807 problem details of the failed
+// requests.
+package problems
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// ContentType is the media type of the problem details.
+const ContentType = "application/problem+json"
+
+// RequestIDHeader holds the id of the request, it is set on the response
+// by the RequestID middleware and copied to the problem details.
+const RequestIDHeader = "X-Request-ID"
+
+// Problem types, the types are relative URIs resolved against the API host.
+// Problems without a specific type are "about:blank" titled by the status.
+const (
+	TypeBlank            = "about:blank"
+	TypeValidation       = "/problems/validation-error"
+	TypeMalformedBody    = "/problems/malformed-body"
+	TypeInvalidParameter = "/problems/invalid-parameter"
+	TypeDuplicate        = "/problems/duplicate"
+)
+
+// Error carries the problem details through the functions which
+// return the error.
+type Error struct {
+	Problem schemas.Problem
+}
+
+func (e *Error) Error() string {
+	if e.Problem.Detail != "" {
+		return e.Problem.Detail
+	}
+
+	return e.Problem.Title
+}
+
+// New returns the problem titled by the status.
+func New(status int, detail string) schemas.Problem {
+	return schemas.Problem{
+		Type:   TypeBlank,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// FromError returns the problem carried by the error or the problem with
+// the given status described by the error text.
+func FromError(err error, status int) schemas.Problem {
+	var problemErr *Error
+	if errors.As(err, &problemErr) {
+		return problemErr.Problem
+	}
+
+	return New(status, err.Error())
+}
+
+// Duplicate returns the conflict with the existing record.
+func Duplicate(detail string, existingID uint) schemas.Problem {
+	return schemas.Problem{
+		Type:       TypeDuplicate,
+		Title:      "Duplicate record",
+		Status:     http.StatusConflict,
+		Detail:     detail,
+		ExistingID: &existingID,
+	}
+}
+
+// InvalidParameter returns the error of the query, path or header parameter.
+func InvalidParameter(name, message string) error {
+	return &Error{Problem: schemas.Problem{
+		Type:   TypeInvalidParameter,
+		Title:  "Invalid parameter",
+		Status: http.StatusBadRequest,
+		Detail: fmt.Sprintf("invalid parameter: %s", name),
+		Errors: []schemas.FieldError{{Field: name, Code: "invalid", Message: message}},
+	}}
+}
+
+// MalformedBody returns the error of the request body which can not be
+// decoded as JSON.
+func MalformedBody(err error) error {
+	problem := schemas.Problem{
+		Type:   TypeMalformedBody,
+		Title:  "Malformed request body",
+		Status: http.StatusBadRequest,
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		problem.Detail = "request body is empty"
+	case errors.As(err, &syntaxErr):
+		problem.Detail = fmt.Sprintf("invalid JSON at offset %d: %s", syntaxErr.Offset, syntaxErr.Error())
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		problem.Detail = "request body has fields of the wrong type"
+		message := fmt.Sprintf("must be %s", jsonType(typeErr.Type))
+		if strings.HasPrefix(typeErr.Value, "number") && jsonType(typeErr.Type) == "a number" {
+			message = "is out of range"
+		}
+		problem.Errors = []schemas.FieldError{{Field: typeErr.Field, Code: "type", Message: message}}
+	default:
+		problem.Detail = err.Error()
+	}
+
+	return &Error{Problem: problem}
+}
+
+// Validation returns the error of the request which failed validation.
+// Other errors are returned as is.
+func Validation(err error) error {
+	errs := FieldErrors(err)
+	if errs == nil {
+		return err
+	}
+
+	return &Error{Problem: schemas.Problem{
+		Type:   TypeValidation,
+		Title:  "Request validation failed",
+		Status: http.StatusBadRequest,
+		Detail: "request has invalid fields",
+		Errors: errs,
+	}}
+}
+
+// FieldErrors converts the validation errors to the field errors. Fields
+// are named by their JSON path as the validator is set up to use the JSON
+// names. Returns nil if the error is not a validation error.
+func FieldErrors(err error) []schemas.FieldError {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil
+	}
+
+	result := make([]schemas.FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		result = append(result, schemas.FieldError{
+			Field:   fieldPath(fe.Namespace()),
+			Code:    strings.TrimSuffix(fe.Tag(), "Validation"),
+			Message: message(fe),
+		})
+	}
+
+	return result
+}
+
+// Write writes the problem with its status.
+func Write(w http.ResponseWriter, problem schemas.Problem) {
+	if problem.Type == "" {
+		problem.Type = TypeBlank
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	problem.RequestID = w.Header().Get(RequestIDHeader)
+
+	data, _ := json.Marshal(problem)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(problem.Status)
+	_, _ = w.Write(data)
+}
+
+// fieldPath trims the name of the validated struct from the namespace,
+// e.g. "AddFilmRequest.countries[1]".
+func fieldPath(namespace string) string {
+	_, path, found := strings.Cut(namespace, ".")
+	if !found {
+		return namespace
+	}
+
+	return path
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_with":
+		return fmt.Sprintf("is required with %s", fe.Param())
+	case "required_without":
+		return fmt.Sprintf("is required without %s", fe.Param())
+	case "min":
+		return fmt.Sprintf("must be at least %s%s", fe.Param(), unit(fe.Kind()))
+	case "max":
+		return fmt.Sprintf("must be at most %s%s", fe.Param(), unit(fe.Kind()))
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "url":
+		return "must be a URL"
+	case "startswith":
+		return fmt.Sprintf("must start with %q", fe.Param())
+	case "dateValidation":
+		return "must be a date formatted as dd-mm-yyyy"
+	case "isoDateValidation":
+		return "must be a date formatted as yyyy-mm-dd"
+	case "sexValidation":
+		return "must be one of: male, female"
+	case "visibilityValidation":
+		return "must be one of: public, private"
+	case "countryValidation":
+		return "must be an ISO 3166-1 alpha-2 country code"
+	case "languageValidation":
+		return "must be an ISO 639-1 language code"
+	case "currencyValidation":
+		return "must be an ISO 4217 currency code"
+	case "moneyValidation":
+		return "must be a non-negative amount with at most two fraction digits"
+	case "ageRatingValidation":
+		return "must be a known age rating"
+	case "relationTypeValidation":
+		return "must be a known relation type"
+	default:
+		return "is invalid"
+	}
+}
+
+// unit describes what min and max limit for the kind of the field.
+func unit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " items"
+	default:
+		return ""
+	}
+}
+
+// jsonType names the JSON type of the Go type.
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,102 @@
+package problems
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/services/validator"
+)
+
+func TestValidation(t *testing.T) {
+	budget := "100"
+	request := schemas.AddFilmRequest{
+		ReleaseDate: "2011-11-03",
+		Countries:   []string{"US", "USA"},
+		Budget:      &budget,
+		ActorsIDs:   []uint{},
+	}
+
+	err := Validation(validator.New().Struct(request))
+	problem := FromError(err, http.StatusInternalServerError)
+	if problem.Type != TypeValidation || problem.Status != http.StatusBadRequest {
+		t.Fatalf("unexpected problem: %+v", problem)
+	}
+
+	want := []schemas.FieldError{
+		{Field: "title", Code: "required", Message: "is required"},
+		{Field: "releaseDate", Code: "date", Message: "must be a date formatted as dd-mm-yyyy"},
+		{Field: "countries[1]", Code: "country", Message: "must be an ISO 3166-1 alpha-2 country code"},
+		{Field: "currency", Code: "required_with", Message: "is required with Budget BoxOffice"},
+	}
+	if !reflect.DeepEqual(problem.Errors, want) {
+		t.Errorf("errors = %+v, want %+v", problem.Errors, want)
+	}
+}
+
+func TestMalformedBody(t *testing.T) {
+	decode := func(body string) schemas.Problem {
+		var request schemas.AddFilmRequest
+		err := json.NewDecoder(strings.NewReader(body)).Decode(&request)
+		return FromError(MalformedBody(err), http.StatusInternalServerError)
+	}
+
+	t.Run("syntax", func(t *testing.T) {
+		problem := decode(`{"title": }`)
+		if problem.Type != TypeMalformedBody || !strings.HasPrefix(problem.Detail, "invalid JSON at offset 11") {
+			t.Errorf("unexpected problem: %+v", problem)
+		}
+	})
+
+	t.Run("type", func(t *testing.T) {
+		problem := decode(`{"title": 1}`)
+		want := []schemas.FieldError{{Field: "title", Code: "type", Message: "must be a string"}}
+		if !reflect.DeepEqual(problem.Errors, want) {
+			t.Errorf("errors = %+v, want %+v", problem.Errors, want)
+		}
+	})
+
+	t.Run("range", func(t *testing.T) {
+		problem := decode(`{"rating": 300}`)
+		want := []schemas.FieldError{{Field: "rating", Code: "type", Message: "is out of range"}}
+		if !reflect.DeepEqual(problem.Errors, want) {
+			t.Errorf("errors = %+v, want %+v", problem.Errors, want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		if problem := decode(""); problem.Detail != "request body is empty" {
+			t.Errorf("unexpected problem: %+v", problem)
+		}
+	})
+}
+
+func TestFromError(t *testing.T) {
+	problem := FromError(errors.New("invalid threshold"), http.StatusBadRequest)
+	if problem.Type != TypeBlank || problem.Title != "Bad Request" || problem.Detail != "invalid threshold" {
+		t.Errorf("unexpected problem: %+v", problem)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+	w.Header().Set(RequestIDHeader, "abc")
+	Write(w, New(http.StatusNotFound, "film not found"))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, ContentType)
+	}
+
+	want := `{"type":"about:blank","title":"Not Found","status":404,"detail":"film not found","requestId":"abc"}`
+	if got := w.Body.String(); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package schemas
+
+import (
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type AddCeremonyRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=150" example:"Academy Awards"`
+}
+
+type UpdateCeremonyRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=150" example:"Academy Awards"`
+}
+
+type AddCategoryRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=150" example:"Best Actor"`
+}
+
+type AddNominationRequest struct {
+	CategoryID uint  `json:"categoryId" validate:"required"`
+	Year       uint  `json:"year" validate:"required,min=1900,max=2100" example:"2012"`
+	FilmID     *uint `json:"filmId" validate:"required_without=ActorID"`
+	ActorID    *uint `json:"actorId" validate:"required_without=FilmID"`
+	Won        bool  `json:"won"`
+}
+
+type PartialUpdateNominationRequest struct {
+	Year *uint `json:"year" validate:"omitempty,min=1900,max=2100" example:"2012"`
+	Won  *bool `json:"won" validate:"omitempty"`
+}
+
+type CeremonyInfo struct {
+	ID         uint           `json:"id"`
+	Name       string         `json:"name"`
+	Categories []CategoryInfo `json:"categories"`
+}
+
+func NewCeremonyInfo(ceremony models.AwardCeremony) CeremonyInfo {
+	return CeremonyInfo{
+		ID:         ceremony.ID,
+		Name:       ceremony.Name,
+		Categories: make([]CategoryInfo, 0),
+	}
+}
+
+type CategoryInfo struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func NewCategoryInfo(category models.AwardCategory) CategoryInfo {
+	return CategoryInfo{
+		ID:   category.ID,
+		Name: category.Name,
+	}
+}
+
+type NominationInfo struct {
+	ID         uint    `json:"id"`
+	CeremonyID uint    `json:"ceremonyId"`
+	Ceremony   string  `json:"ceremony"`
+	CategoryID uint    `json:"categoryId"`
+	Category   string  `json:"category"`
+	Year       uint    `json:"year"`
+	Won        bool    `json:"won"`
+	FilmID     *uint   `json:"filmId"`
+	FilmTitle  *string `json:"filmTitle"`
+	ActorID    *uint   `json:"actorId"`
+	ActorName  *string `json:"actorName"`
+}
+
+// NominationsFilter narrows down the list of nominations,
+// nil fields are not applied.
+type NominationsFilter struct {
+	ID         *uint
+	CeremonyID *uint
+	Year       *uint
+	FilmID     *uint
+	ActorID    *uint
+}
@@ -0,0 +1,39 @@
+package schemas
+
+import "encoding/json"
+
+type BatchOp string
+
+const (
+	BatchCreate BatchOp = "create"
+	BatchUpdate BatchOp = "update"
+	BatchPatch  BatchOp = "patch"
+	BatchDelete BatchOp = "delete"
+)
+
+// BatchOperation holds the body of the single endpoint request in Data,
+// ID is required for every operation except create.
+type BatchOperation struct {
+	Op   BatchOp         `json:"op" validate:"required,oneof=create update patch delete" enums:"create,update,patch,delete"`
+	ID   *uint           `json:"id"`
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+type BatchRequest struct {
+	Operations []BatchOperation `json:"operations" validate:"required,min=1,max=100,dive"`
+}
+
+// BatchResult is the outcome of the operation with the same index.
+type BatchResult struct {
+	Status int     `json:"status"`
+	ID     *uint   `json:"id"`
+	Error  *string `json:"error,omitempty"`
+	// Errors lists the invalid fields of the operation data.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+type BatchResponse struct {
+	Atomic    bool          `json:"atomic"`
+	Committed bool          `json:"committed"`
+	Results   []BatchResult `json:"results"`
+}
@@ -0,0 +1,15 @@
+package schemas
+
+type SetFilmActorsRequest struct {
+	ActorsIDs []uint `json:"actorsIds" validate:"required"`
+}
+
+type SetActorFilmsRequest struct {
+	FilmsIDs []uint `json:"filmsIds" validate:"required"`
+}
+
+// CastLink is the actor starring in the film.
+type CastLink struct {
+	ActorID uint `json:"actorId"`
+	FilmID  uint `json:"filmId"`
+}
@@ -0,0 +1,54 @@
+package schemas
+
+import (
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type AddCollectionRequest struct {
+	Title       string            `json:"title" validate:"required,min=1,max=150"`
+	Description string            `json:"description" validate:"max=1000"`
+	Visibility  models.Visibility `json:"visibility" validate:"required,visibilityValidation" example:"public"`
+	FilmsIDs    []uint            `json:"filmsIds"`
+}
+
+type PartialUpdateCollectionRequest struct {
+	Title       *string            `json:"title" validate:"omitempty,min=1,max=150"`
+	Description *string            `json:"description" validate:"omitempty,max=1000"`
+	Visibility  *models.Visibility `json:"visibility" validate:"omitempty,visibilityValidation" example:"public"`
+}
+
+type AddCollectionItemRequest struct {
+	FilmID   uint  `json:"filmId" validate:"required"`
+	Position *uint `json:"position" validate:"omitempty,min=1" example:"1"`
+}
+
+type ReorderCollectionRequest struct {
+	FilmsIDs []uint `json:"filmsIds" validate:"required"`
+}
+
+type CollectionInfo struct {
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Visibility  models.Visibility `json:"visibility"`
+	OwnerID     uint              `json:"ownerId"`
+}
+
+func NewCollectionInfo(collection models.Collection) CollectionInfo {
+	return CollectionInfo{
+		ID:          collection.ID,
+		Title:       collection.Title,
+		Description: collection.Description,
+		Visibility:  collection.Visibility,
+		OwnerID:     collection.OwnerID,
+	}
+}
+
+type CollectionWithFilmsResponse struct {
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Visibility  models.Visibility `json:"visibility"`
+	OwnerID     uint              `json:"ownerId"`
+	Films       []FilmInfo        `json:"films"`
+}
@@ -0,0 +1,31 @@
+package schemas
+
+type CostarInfo struct {
+	ID          uint    `json:"id"`
+	FirstName   string  `json:"firstName"`
+	LastName    string  `json:"lastName"`
+	MiddleName  *string `json:"middleName"`
+	SharedFilms uint    `json:"sharedFilms"`
+}
+
+type ActorRef struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type FilmRef struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+}
+
+// ActorPathStep connects two actors which starred in the same film.
+type ActorPathStep struct {
+	FromActor ActorRef `json:"fromActor"`
+	Film      FilmRef  `json:"film"`
+	ToActor   ActorRef `json:"toActor"`
+}
+
+type ActorPathResponse struct {
+	Degrees uint            `json:"degrees"`
+	Steps   []ActorPathStep `json:"steps"`
+}
@@ -0,0 +1,20 @@
+package schemas
+
+// DuplicateCandidate is a film or an actor, Date is the release date
+// of the film or the birthday of the actor.
+type DuplicateCandidate struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Date Date   `json:"date" example:"02-01-2006"`
+}
+
+type DuplicatePair struct {
+	Score  float64            `json:"score"`
+	First  DuplicateCandidate `json:"first"`
+	Second DuplicateCandidate `json:"second"`
+}
+
+type DuplicatesReport struct {
+	Films  []DuplicatePair `json:"films"`
+	Actors []DuplicatePair `json:"actors"`
+}
@@ -0,0 +1,25 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+// Event is the data of the server-sent event, the event name is the type
+// and the event id is the ID.
+type Event struct {
+	ID        int64            `json:"id"`
+	Type      models.EventType `json:"type" example:"film.created"`
+	EntityID  uint             `json:"entityId"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+func NewEvent(event models.Event) Event {
+	return Event{
+		ID:        event.ID,
+		Type:      event.Type,
+		EntityID:  event.EntityID,
+		CreatedAt: event.CreatedAt,
+	}
+}
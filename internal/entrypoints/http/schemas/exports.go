@@ -0,0 +1,178 @@
+package schemas
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type ExportEntity string
+
+const (
+	ExportFilms  ExportEntity = "films"
+	ExportActors ExportEntity = "actors"
+	ExportLinks  ExportEntity = "links"
+)
+
+// ExportEntities lists all entities in the export order.
+var ExportEntities = []ExportEntity{ExportFilms, ExportActors, ExportLinks}
+
+// ExportDeletions holds the deletions of the exported entities, it follows
+// them in the export of the rows updated after the time.
+const ExportDeletions ExportEntity = "deletions"
+
+type ExportFormat string
+
+const (
+	ExportNDJSON ExportFormat = "ndjson"
+	ExportCSV    ExportFormat = "csv"
+	ExportJSON   ExportFormat = "json"
+)
+
+// ExportRequest selects rows updated after Since and the rows deleted after
+// it, all rows if it is nil.
+type ExportRequest struct {
+	Format   ExportFormat
+	Entities []ExportEntity
+	Since    *time.Time
+}
+
+// ExportRecord is the exported row which can be written to CSV files.
+type ExportRecord interface {
+	CSVHeader() []string
+	CSVRecord() []string
+}
+
+type ExportFilm struct {
+	ID          uint   `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ReleaseDate Date   `json:"releaseDate" example:"02-01-2006"`
+	Rating      uint8  `json:"rating"`
+	FilmMetadata
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (f ExportFilm) CSVHeader() []string {
+	return []string{
+		"id", "title", "description", "releaseDate", "rating", "runtime",
+		"countries", "language", "ageRating", "budget", "boxOffice",
+		"currency", "updatedAt",
+	}
+}
+
+func (f ExportFilm) CSVRecord() []string {
+	var ageRating *string
+	if f.AgeRating != nil {
+		value := string(*f.AgeRating)
+		ageRating = &value
+	}
+
+	return []string{
+		formatUint(f.ID),
+		f.Title,
+		f.Description,
+		string(f.ReleaseDate),
+		formatUint(uint(f.Rating)),
+		formatOptionalUint(f.Runtime),
+		strings.Join(f.Countries, ";"),
+		formatOptional(f.Language),
+		formatOptional(ageRating),
+		formatOptional(f.Budget),
+		formatOptional(f.BoxOffice),
+		formatOptional(f.Currency),
+		f.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type ExportActor struct {
+	ID         uint       `json:"id"`
+	FirstName  string     `json:"firstName"`
+	LastName   string     `json:"lastName"`
+	MiddleName *string    `json:"middleName"`
+	Sex        models.Sex `json:"sex"`
+	Birthday   Date       `json:"birthday" example:"02-01-2006"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+}
+
+func (a ExportActor) CSVHeader() []string {
+	return []string{
+		"id", "firstName", "lastName", "middleName", "sex", "birthday",
+		"updatedAt",
+	}
+}
+
+func (a ExportActor) CSVRecord() []string {
+	return []string{
+		formatUint(a.ID),
+		a.FirstName,
+		a.LastName,
+		formatOptional(a.MiddleName),
+		string(a.Sex),
+		string(a.Birthday),
+		a.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ExportLink is the actor starring in the film.
+type ExportLink struct {
+	ActorID   uint      `json:"actorId"`
+	FilmID    uint      `json:"filmId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (l ExportLink) CSVHeader() []string {
+	return []string{"actorId", "filmId", "updatedAt"}
+}
+
+func (l ExportLink) CSVRecord() []string {
+	return []string{
+		formatUint(l.ActorID),
+		formatUint(l.FilmID),
+		l.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// ExportDeletion is the row of the entity deleted after the time, films and
+// actors are identified by ID and links by ActorID and FilmID.
+type ExportDeletion struct {
+	Entity    ExportEntity `json:"entity"`
+	ID        *uint        `json:"id,omitempty"`
+	ActorID   *uint        `json:"actorId,omitempty"`
+	FilmID    *uint        `json:"filmId,omitempty"`
+	DeletedAt time.Time    `json:"deletedAt"`
+}
+
+func (d ExportDeletion) CSVHeader() []string {
+	return []string{"entity", "id", "actorId", "filmId", "deletedAt"}
+}
+
+func (d ExportDeletion) CSVRecord() []string {
+	return []string{
+		string(d.Entity),
+		formatOptionalUint(d.ID),
+		formatOptionalUint(d.ActorID),
+		formatOptionalUint(d.FilmID),
+		d.DeletedAt.Format(time.RFC3339),
+	}
+}
+
+func formatUint(value uint) string {
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+func formatOptionalUint(value *uint) string {
+	if value == nil {
+		return ""
+	}
+	return formatUint(*value)
+}
+
+func formatOptional(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
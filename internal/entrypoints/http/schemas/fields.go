@@ -0,0 +1,84 @@
+package schemas
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FilmFields are the JSON names of the film fields which can be selected.
+var FilmFields = []string{
+	"id", "title", "description", "releaseDate", "rating", "runtime", "countries",
+	"language", "ageRating", "budget", "boxOffice", "currency", "poster",
+}
+
+// ActorFields are the JSON names of the actor fields which can be selected.
+var ActorFields = []string{
+	"id", "firstName", "lastName", "middleName", "sex", "birthday", "headshot",
+}
+
+// FilmSortFields are the JSON names of the film fields the films can be
+// sorted by.
+var FilmSortFields = []string{
+	"id", "title", "releaseDate", "rating", "runtime", "budget", "boxOffice",
+}
+
+var ErrUnknownSortField = errors.New("unknown sort field")
+
+// SortField is the field of the sort order.
+type SortField struct {
+	Name       string
+	Descending bool
+}
+
+// ParseSort parses the comma separated sort order, e.g. "-rating,title",
+// the fields prefixed with "-" are sorted in descending order. Returns
+// ErrUnknownSortField if the field is empty or not one of the fields.
+func ParseSort(sortBy string, fields []string) ([]SortField, error) {
+	if sortBy == "" {
+		return nil, nil
+	}
+
+	order := make([]SortField, 0)
+	for _, name := range strings.Split(sortBy, ",") {
+		var field SortField
+		field.Name, field.Descending = strings.CutPrefix(strings.TrimSpace(name), "-")
+		if !slices.Contains(fields, field.Name) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownSortField, name)
+		}
+		order = append(order, field)
+	}
+
+	return order, nil
+}
+
+// ListOptions selects the fields of the listed records and whether their
+// related records are included. Empty Fields select every field of the
+// records, empty IncludedFields select every field of the related ones.
+// The id is always selected.
+type ListOptions struct {
+	Fields         []string
+	Include        bool
+	IncludedFields []string
+}
+
+// DefaultListOptions select every field and include the related records
+// as the lists did before the options were introduced.
+var DefaultListOptions = ListOptions{Include: true}
+
+// IsDefault reports whether the options select the full records.
+func (o ListOptions) IsDefault() bool {
+	return o.Include && len(o.Fields) == 0 && len(o.IncludedFields) == 0
+}
+
+// HasField reports whether the field of the listed records is selected.
+func (o ListOptions) HasField(name string) bool {
+	return name == "id" || len(o.Fields) == 0 || slices.Contains(o.Fields, name)
+}
+
+// HasIncludedField reports whether the field of the related records is
+// selected.
+func (o ListOptions) HasIncludedField(name string) bool {
+	return name == "id" || len(o.IncludedFields) == 0 || slices.Contains(o.IncludedFields, name)
+}
@@ -0,0 +1,26 @@
+package schemas
+
+type FilmographyEntry struct {
+	Film         FilmInfo `json:"film"`
+	AgeAtRelease int      `json:"ageAtRelease"`
+}
+
+type FilmographyYear struct {
+	Year  int                `json:"year"`
+	Films []FilmographyEntry `json:"films"`
+}
+
+// FilmographySummary dates and average rating are null for actors without films.
+type FilmographySummary struct {
+	Films            uint     `json:"films"`
+	FirstAppearance  *Date    `json:"firstAppearance" example:"02-01-2006"`
+	LatestAppearance *Date    `json:"latestAppearance" example:"02-01-2006"`
+	CareerSpan       uint     `json:"careerSpan"`
+	AverageRating    *float64 `json:"averageRating"`
+}
+
+type FilmographyResponse struct {
+	Actor   ActorInfo          `json:"actor"`
+	Years   []FilmographyYear  `json:"years"`
+	Summary FilmographySummary `json:"summary"`
+}
@@ -0,0 +1,68 @@
+package schemas
+
+import (
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type AddFranchiseRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=150"`
+	Description string `json:"description" validate:"max=1000"`
+	FilmsIDs    []uint `json:"filmsIds"`
+}
+
+type PartialUpdateFranchiseRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=150"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type SetFranchiseFilmsRequest struct {
+	FilmsIDs []uint `json:"filmsIds" validate:"required"`
+}
+
+type FranchiseInfo struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+func NewFranchiseInfo(franchise models.Franchise) FranchiseInfo {
+	return FranchiseInfo{
+		ID:          franchise.ID,
+		Name:        franchise.Name,
+		Description: franchise.Description,
+	}
+}
+
+type FranchiseWithFilmsResponse struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Films       []FilmInfo `json:"films"`
+}
+
+type AddFilmRelationRequest struct {
+	RelatedFilmID uint                `json:"relatedFilmId" validate:"required"`
+	Type          models.RelationType `json:"type" validate:"required,relationTypeValidation" example:"sequel_of"`
+}
+
+type FilmRelationInfo struct {
+	FilmID        uint                `json:"filmId"`
+	RelatedFilmID uint                `json:"relatedFilmId"`
+	Type          models.RelationType `json:"type" example:"sequel_of"`
+}
+
+func NewFilmRelationInfo(relation models.FilmRelation) FilmRelationInfo {
+	return FilmRelationInfo{
+		FilmID:        relation.FilmID,
+		RelatedFilmID: relation.RelatedFilmID,
+		Type:          relation.Type,
+	}
+}
+
+// RelatedFilmInfo describes how the related film is connected to the
+// requested one, e.g. the relation "sequel_of" means the related film
+// is a sequel of the requested film.
+type RelatedFilmInfo struct {
+	Relation models.RelationType `json:"relation" example:"sequel_of"`
+	Film     FilmInfo            `json:"film"`
+}
@@ -0,0 +1,47 @@
+package schemas
+
+import (
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type ImageInfo struct {
+	URL        string      `json:"url"`
+	Width      uint        `json:"width"`
+	Height     uint        `json:"height"`
+	Thumbnails []Thumbnail `json:"thumbnails"`
+}
+
+type Thumbnail struct {
+	URL    string `json:"url"`
+	Width  uint   `json:"width"`
+	Height uint   `json:"height"`
+}
+
+// NewImageInfo groups the original image with its thumbnails.
+// Returns nil if there is no original image.
+func NewImageInfo(images []models.Image) *ImageInfo {
+	var info *ImageInfo
+	thumbnails := make([]Thumbnail, 0, len(images))
+	for _, image := range images {
+		if image.Original {
+			info = &ImageInfo{
+				URL:    image.URL,
+				Width:  image.Width,
+				Height: image.Height,
+			}
+			continue
+		}
+
+		thumbnails = append(thumbnails, Thumbnail{
+			URL:    image.URL,
+			Width:  image.Width,
+			Height: image.Height,
+		})
+	}
+
+	if info != nil {
+		info.Thumbnails = thumbnails
+	}
+
+	return info
+}
@@ -0,0 +1,86 @@
+package schemas
+
+import "github.com/sivistrukov/vk-assigment/internal/models"
+
+type ImportKind string
+
+const (
+	ImportActors ImportKind = "actors"
+	ImportFilms  ImportKind = "films"
+	ImportCast   ImportKind = "cast"
+)
+
+type ImportFormat string
+
+const (
+	ImportCSV    ImportFormat = "csv"
+	ImportNDJSON ImportFormat = "ndjson"
+)
+
+type ImportMode string
+
+const (
+	// ImportAtomic imports all rows or none of them.
+	ImportAtomic ImportMode = "atomic"
+	// ImportChunked commits every chunk of rows without failed rows.
+	ImportChunked ImportMode = "chunked"
+)
+
+type ImportRequest struct {
+	Kind      ImportKind
+	Format    ImportFormat
+	Mode      ImportMode
+	ChunkSize uint
+	DryRun    bool
+}
+
+type ImportActorRow struct {
+	FirstName  string     `json:"firstName" validate:"required"`
+	LastName   string     `json:"lastName" validate:"required"`
+	MiddleName *string    `json:"middleName"`
+	Sex        models.Sex `json:"sex" validate:"required,sexValidation"`
+	Birthday   Date       `json:"birthday" validate:"required,dateValidation" example:"02-01-2006"`
+}
+
+type ImportFilmRow struct {
+	Title       string            `json:"title" validate:"required,min=1,max=150"`
+	Description string            `json:"description" validate:"max=1000"`
+	ReleaseDate Date              `json:"releaseDate" validate:"required,dateValidation" example:"02-01-2006"`
+	Rating      uint8             `json:"rating" validate:"min=0,max=10"`
+	Runtime     *uint             `json:"runtime" validate:"omitempty,min=1,max=1000"`
+	Countries   []string          `json:"countries" validate:"omitempty,dive,countryValidation" example:"US,GB"`
+	Language    *string           `json:"language" validate:"omitempty,languageValidation" example:"en"`
+	AgeRating   *models.AgeRating `json:"ageRating" validate:"omitempty,ageRatingValidation" example:"PG-13"`
+	Budget      *string           `json:"budget" validate:"omitempty,moneyValidation" example:"185000000.00"`
+	BoxOffice   *string           `json:"boxOffice" validate:"omitempty,moneyValidation" example:"276600000.00"`
+	Currency    *string           `json:"currency" validate:"required_with=Budget BoxOffice,omitempty,currencyValidation" example:"USD"`
+}
+
+// ImportCastRow links the actor to the film, both are referenced by
+// their natural keys.
+type ImportCastRow struct {
+	FilmTitle       string `json:"filmTitle" validate:"required"`
+	FilmReleaseDate Date   `json:"filmReleaseDate" validate:"required,dateValidation" example:"02-01-2006"`
+	FirstName       string `json:"firstName" validate:"required"`
+	LastName        string `json:"lastName" validate:"required"`
+	Birthday        Date   `json:"birthday" validate:"required,dateValidation" example:"02-01-2006"`
+}
+
+// ImportRowError lists problems of the row at the line of the file in the
+// shape of the problem details field errors. The errors of the whole row,
+// e.g. duplicates, have no field.
+type ImportRowError struct {
+	Line   uint         `json:"line"`
+	Errors []FieldError `json:"errors"`
+}
+
+// ImportReport counts rows of the file. Nothing is imported in dry-run mode.
+type ImportReport struct {
+	Kind     ImportKind       `json:"kind"`
+	Mode     ImportMode       `json:"mode"`
+	DryRun   bool             `json:"dryRun"`
+	Rows     uint             `json:"rows"`
+	Imported uint             `json:"imported"`
+	Failed   uint             `json:"failed"`
+	Errors   []ImportRowError `json:"errors"`
+}
@@ -0,0 +1,16 @@
+package schemas
+
+// MergeActorsRequest names the duplicate actor merged into the target.
+// Fields lists the fields whose values are taken from the source actor,
+// the rest keep the values of the target.
+type MergeActorsRequest struct {
+	SourceID uint     `json:"sourceId" validate:"required"`
+	Fields   []string `json:"fields" validate:"omitempty,dive,oneof=firstName lastName middleName sex birthday" example:"birthday"`
+}
+
+type MergeActorsResponse struct {
+	ID             uint  `json:"id"`
+	SourceID       uint  `json:"sourceId"`
+	MovedFilms     int64 `json:"movedFilms"`
+	DuplicateFilms int64 `json:"duplicateFilms"`
+}
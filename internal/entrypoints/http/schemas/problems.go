@@ -0,0 +1,23 @@
+package schemas
+
+// Problem is the RFC 7807 problem details of the failed request.
+type Problem struct {
+	Type      string `json:"type" example:"/problems/validation-error"`
+	Title     string `json:"title" example:"Request validation failed"`
+	Status    int    `json:"status" example:"400"`
+	Detail    string `json:"detail,omitempty"`
+	RequestID string `json:"requestId,omitempty"`
+	// Errors lists the invalid fields of the request body or the invalid
+	// query, path and header parameters.
+	Errors []FieldError `json:"errors,omitempty"`
+	// ExistingID is the id of the record duplicated by the created one.
+	ExistingID *uint `json:"existingId,omitempty"`
+}
+
+type FieldError struct {
+	// Field is the JSON path of the body field or the parameter name,
+	// empty for the errors of the whole imported row.
+	Field   string `json:"field,omitempty" example:"countries[1]"`
+	Code    string `json:"code" example:"country"`
+	Message string `json:"message" example:"must be an ISO 3166-1 alpha-2 country code"`
+}
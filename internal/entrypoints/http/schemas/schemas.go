@@ -4,10 +4,6 @@ import (
 	"github.com/sivistrukov/vk-assigment/internal/models"
 )
 
-type ErrorResponse struct {
-	Error string `json:"error"`
-}
-
 type CreateUserRequest struct {
 	Username string `json:"username" validate:"required" example:"user"`
 	Password string `json:"password" validate:"required" example:"<PASSWORD>"`
@@ -39,27 +35,48 @@ type PartialUpdateActorRequest struct {
 }
 
 type AddFilmRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=150"`
-	Description string `json:"description" validate:"max=1000"`
-	ReleaseDate Date   `json:"releaseDate" validate:"required,dateValidation" example:"02-01-2006"`
-	Rating      uint8  `json:"rating" validate:"min=0,max=10"`
-	ActorsIDs   []uint `json:"actorsIds" validate:"required"`
+	Title       string            `json:"title" validate:"required,min=1,max=150"`
+	Description string            `json:"description" validate:"max=1000"`
+	ReleaseDate Date              `json:"releaseDate" validate:"required,dateValidation" example:"02-01-2006"`
+	Rating      uint8             `json:"rating" validate:"min=0,max=10"`
+	Runtime     *uint             `json:"runtime" validate:"omitempty,min=1,max=1000"`
+	Countries   []string          `json:"countries" validate:"omitempty,dive,countryValidation" example:"US,GB"`
+	Language    *string           `json:"language" validate:"omitempty,languageValidation" example:"en"`
+	AgeRating   *models.AgeRating `json:"ageRating" validate:"omitempty,ageRatingValidation" example:"PG-13"`
+	Budget      *string           `json:"budget" validate:"omitempty,moneyValidation" example:"185000000.00"`
+	BoxOffice   *string           `json:"boxOffice" validate:"omitempty,moneyValidation" example:"276600000.00"`
+	Currency    *string           `json:"currency" validate:"required_with=Budget BoxOffice,omitempty,currencyValidation" example:"USD"`
+	ActorsIDs   []uint            `json:"actorsIds" validate:"required"`
 }
 
 type UpdateFilmRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=150"`
-	Description string `json:"description" validate:"required,max=1000"`
-	ReleaseDate Date   `json:"releaseDate" validate:"required,dateValidation" example:"02-01-2006"`
-	Rating      uint8  `json:"rating" validate:"min=0,max=10"`
-	ActorsIds   []uint `json:"actorsIds" validate:"required"`
+	Title       string            `json:"title" validate:"required,min=1,max=150"`
+	Description string            `json:"description" validate:"required,max=1000"`
+	ReleaseDate Date              `json:"releaseDate" validate:"required,dateValidation" example:"02-01-2006"`
+	Rating      uint8             `json:"rating" validate:"min=0,max=10"`
+	Runtime     *uint             `json:"runtime" validate:"omitempty,min=1,max=1000"`
+	Countries   []string          `json:"countries" validate:"omitempty,dive,countryValidation" example:"US,GB"`
+	Language    *string           `json:"language" validate:"omitempty,languageValidation" example:"en"`
+	AgeRating   *models.AgeRating `json:"ageRating" validate:"omitempty,ageRatingValidation" example:"PG-13"`
+	Budget      *string           `json:"budget" validate:"omitempty,moneyValidation" example:"185000000.00"`
+	BoxOffice   *string           `json:"boxOffice" validate:"omitempty,moneyValidation" example:"276600000.00"`
+	Currency    *string           `json:"currency" validate:"required_with=Budget BoxOffice,omitempty,currencyValidation" example:"USD"`
+	ActorsIds   []uint            `json:"actorsIds" validate:"required"`
 }
 
 type PartialUpdateFilmRequest struct {
-	Title       *string `json:"title" validate:"omitempty,min=1,max=150"`
-	Description *string `json:"description" validate:"omitempty,max=1000"`
-	ReleaseDate *Date   `json:"releaseDate" validate:"omitempty,dateValidation" example:"02-01-2006"`
-	Rating      *uint8  `json:"rating" validate:"omitempty,min=0,max=10"`
-	ActorsIDs   *[]uint `json:"actorsIds" validate:"omitempty"`
+	Title       *string           `json:"title" validate:"omitempty,min=1,max=150"`
+	Description *string           `json:"description" validate:"omitempty,max=1000"`
+	ReleaseDate *Date             `json:"releaseDate" validate:"omitempty,dateValidation" example:"02-01-2006"`
+	Rating      *uint8            `json:"rating" validate:"omitempty,min=0,max=10"`
+	Runtime     *uint             `json:"runtime" validate:"omitempty,min=1,max=1000"`
+	Countries   *[]string         `json:"countries" validate:"omitempty,dive,countryValidation" example:"US,GB"`
+	Language    *string           `json:"language" validate:"omitempty,languageValidation" example:"en"`
+	AgeRating   *models.AgeRating `json:"ageRating" validate:"omitempty,ageRatingValidation" example:"PG-13"`
+	Budget      *string           `json:"budget" validate:"omitempty,moneyValidation" example:"185000000.00"`
+	BoxOffice   *string           `json:"boxOffice" validate:"omitempty,moneyValidation" example:"276600000.00"`
+	Currency    *string           `json:"currency" validate:"omitempty,currencyValidation" example:"USD"`
+	ActorsIDs   *[]uint           `json:"actorsIds" validate:"omitempty"`
 }
 
 type ActorWithFilmsResponse struct {
@@ -69,34 +86,79 @@ type ActorWithFilmsResponse struct {
 	MiddleName *string    `json:"middleName"`
 	Sex        models.Sex `json:"sex"`
 	Birthday   Date       `json:"birthday" example:"02-01-2006"`
+	Headshot   *ImageInfo `json:"headshot"`
 	Films      []FilmInfo `json:"films"`
 }
 
+// FilmMetadata holds the optional catalogue details of the film.
+type FilmMetadata struct {
+	Runtime   *uint             `json:"runtime"`
+	Countries []string          `json:"countries" example:"US,GB"`
+	Language  *string           `json:"language" example:"en"`
+	AgeRating *models.AgeRating `json:"ageRating" example:"PG-13"`
+	Budget    *string           `json:"budget" example:"185000000.00"`
+	BoxOffice *string           `json:"boxOffice" example:"276600000.00"`
+	Currency  *string           `json:"currency" example:"USD"`
+}
+
+func NewFilmMetadata(film models.Film) FilmMetadata {
+	countries := film.Countries
+	if countries == nil {
+		countries = make([]string, 0)
+	}
+
+	return FilmMetadata{
+		Runtime:   film.Runtime,
+		Countries: countries,
+		Language:  film.Language,
+		AgeRating: film.AgeRating,
+		Budget:    film.Budget,
+		BoxOffice: film.BoxOffice,
+		Currency:  film.Currency,
+	}
+}
+
 type FilmInfo struct {
 	ID          uint   `json:"id"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	ReleaseDate Date   `json:"releaseDate" example:"02-01-2006"`
 	Rating      uint8  `json:"rating"`
+	FilmMetadata
+	Poster *ImageInfo `json:"poster"`
 }
 
 func NewFilmInfo(film models.Film) FilmInfo {
 	return FilmInfo{
-		ID:          film.ID,
-		Title:       film.Title,
-		Description: film.Description,
-		ReleaseDate: NewDate(film.ReleaseDate),
-		Rating:      film.Rating,
+		ID:           film.ID,
+		Title:        film.Title,
+		Description:  film.Description,
+		ReleaseDate:  NewDate(film.ReleaseDate),
+		Rating:       film.Rating,
+		FilmMetadata: NewFilmMetadata(film),
 	}
 }
 
 type FilmWithActorsResponse struct {
-	ID          uint        `json:"id"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	ReleaseDate Date        `json:"releaseDate" example:"02-01-2006"`
-	Rating      uint8       `json:"rating"`
-	Actors      []ActorInfo `json:"actors"`
+	ID          uint   `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ReleaseDate Date   `json:"releaseDate" example:"02-01-2006"`
+	Rating      uint8  `json:"rating"`
+	FilmMetadata
+	Poster *ImageInfo  `json:"poster"`
+	Actors []ActorInfo `json:"actors"`
+}
+
+// FilmsFilter narrows down the list of films, nil fields are not applied.
+type FilmsFilter struct {
+	HasAwards   *bool
+	AwardWinner *bool
+	Country     *string `query:"country" validate:"omitempty,countryValidation"`
+	Language    *string `query:"language" validate:"omitempty,languageValidation"`
+	AgeRating   *string `query:"ageRating" validate:"omitempty,ageRatingValidation"`
+	MinRuntime  *uint
+	MaxRuntime  *uint
 }
 
 type ActorInfo struct {
@@ -106,6 +168,7 @@ type ActorInfo struct {
 	MiddleName *string    `json:"middleName"`
 	Sex        models.Sex `json:"sex"`
 	Birthday   Date       `json:"birthday" example:"02-01-2006"`
+	Headshot   *ImageInfo `json:"headshot"`
 }
 
 func NewActorInfo(actor models.Actor) ActorInfo {
@@ -0,0 +1,24 @@
+package schemas
+
+import "time"
+
+// SimilarityTarget describes the film other films are compared with.
+type SimilarityTarget struct {
+	ReleaseDate time.Time
+	CastSize    uint
+}
+
+// ScoreBreakdown holds weighted parts of the similarity score.
+type ScoreBreakdown struct {
+	Cast   float64 `json:"cast"`
+	Year   float64 `json:"year"`
+	Rating float64 `json:"rating"`
+}
+
+type SimilarFilmInfo struct {
+	Film         FilmInfo       `json:"film"`
+	SharedActors uint           `json:"sharedActors"`
+	CastSize     uint           `json:"-"`
+	Score        float64        `json:"score"`
+	Breakdown    ScoreBreakdown `json:"breakdown"`
+}
@@ -0,0 +1,34 @@
+package schemas
+
+import "time"
+
+type YearCount struct {
+	Year  int  `json:"year"`
+	Films uint `json:"films"`
+}
+
+type RatingCount struct {
+	Rating uint8 `json:"rating"`
+	Films  uint  `json:"films"`
+}
+
+type ActorFilmCount struct {
+	Actor ActorRef `json:"actor"`
+	Films uint     `json:"films"`
+}
+
+type SexSplit struct {
+	Male   uint `json:"male"`
+	Female uint `json:"female"`
+}
+
+// StatsResponse holds catalogue statistics as of the RefreshedAt time.
+type StatsResponse struct {
+	FilmsByYear         []YearCount      `json:"filmsByYear"`
+	RatingHistogram     []RatingCount    `json:"ratingHistogram"`
+	ProlificActors      []ActorFilmCount `json:"prolificActors"`
+	AverageCastSize     float64          `json:"averageCastSize"`
+	SexSplit            SexSplit         `json:"sexSplit"`
+	AverageAgeAtRelease *float64         `json:"averageAgeAtRelease"`
+	RefreshedAt         time.Time        `json:"refreshedAt"`
+}
@@ -0,0 +1,46 @@
+package schemas
+
+import (
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type SetFilmTranslationRequest struct {
+	Title       string  `json:"title" validate:"required,min=1,max=150"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+type SetActorTranslationRequest struct {
+	FirstName  string  `json:"firstName" validate:"required"`
+	LastName   string  `json:"lastName" validate:"required"`
+	MiddleName *string `json:"middleName,omitempty"`
+}
+
+type FilmTranslationInfo struct {
+	Locale      string  `json:"locale" example:"ru"`
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+}
+
+func NewFilmTranslationInfo(translation models.FilmTranslation) FilmTranslationInfo {
+	return FilmTranslationInfo{
+		Locale:      translation.Locale,
+		Title:       translation.Title,
+		Description: translation.Description,
+	}
+}
+
+type ActorTranslationInfo struct {
+	Locale     string  `json:"locale" example:"ru"`
+	FirstName  string  `json:"firstName"`
+	LastName   string  `json:"lastName"`
+	MiddleName *string `json:"middleName"`
+}
+
+func NewActorTranslationInfo(translation models.ActorTranslation) ActorTranslationInfo {
+	return ActorTranslationInfo{
+		Locale:     translation.Locale,
+		FirstName:  translation.FirstName,
+		LastName:   translation.LastName,
+		MiddleName: translation.MiddleName,
+	}
+}
@@ -0,0 +1,75 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type WebhookRequest struct {
+	URL        string             `json:"url" validate:"required,url,startswith=http" example:"https://partner.example.com/hooks"`
+	EventTypes []models.EventType `json:"eventTypes" validate:"required,min=1,dive,oneof=film.created film.updated film.deleted" example:"film.created,film.deleted"`
+	Secret     string             `json:"secret" validate:"required,min=16,max=256"`
+}
+
+// WebhookResponse is the webhook without the secret.
+type WebhookResponse struct {
+	ID         uint               `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []models.EventType `json:"eventTypes" example:"film.created,film.deleted"`
+	CreatedAt  time.Time          `json:"createdAt"`
+}
+
+func NewWebhookResponse(webhook models.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:         webhook.ID,
+		URL:        webhook.URL,
+		EventTypes: webhook.EventTypes,
+		CreatedAt:  webhook.CreatedAt,
+	}
+}
+
+// WebhookDelivery is the log entry of the delivery of the change to the
+// webhook. NextAttemptAt is set for the pending deliveries only.
+type WebhookDelivery struct {
+	ID             int64                 `json:"id"`
+	EventID        int64                 `json:"eventId"`
+	Type           models.EventType      `json:"type" example:"film.updated"`
+	FilmID         uint                  `json:"filmId"`
+	OccurredAt     time.Time             `json:"occurredAt"`
+	Status         models.DeliveryStatus `json:"status" example:"delivered"`
+	Attempts       uint                  `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"nextAttemptAt"`
+	LastAttemptAt  *time.Time            `json:"lastAttemptAt"`
+	ResponseStatus *int                  `json:"responseStatus"`
+	Error          *string               `json:"error"`
+}
+
+func NewWebhookDelivery(delivery models.WebhookDelivery) WebhookDelivery {
+	response := WebhookDelivery{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		Type:           delivery.Type,
+		FilmID:         delivery.FilmID,
+		OccurredAt:     delivery.OccurredAt,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+	}
+
+	if delivery.Status == models.DeliveryPending {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return response
+}
+
+// WebhookPayload is the body of the webhook call.
+type WebhookPayload struct {
+	ID         int64            `json:"id"`
+	Type       models.EventType `json:"type" example:"film.created"`
+	FilmID     uint             `json:"filmId"`
+	OccurredAt time.Time        `json:"occurredAt"`
+}
@@ -10,14 +10,20 @@ import (
 	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
 	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
 	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/actors"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
 )
 
 type ActorService interface {
-	AddActor(context.Context, schemas.AddActorRequest) (models.Actor, error)
+	AddActor(context.Context, schemas.AddActorRequest, bool) (models.Actor, error)
 	UpdateActor(context.Context, uint, schemas.UpdateActorRequest) error
 	PartialUpdateActor(context.Context, uint, schemas.PartialUpdateActorRequest) error
 	RemoveActor(context.Context, uint) error
-	GetActorsWithFilms(context.Context) ([]schemas.ActorWithFilmsResponse, error)
+	GetActorsWithFilms(context.Context, schemas.ListOptions) ([]schemas.ActorWithFilmsResponse, error)
+	GetCostars(context.Context, uint, *uint) ([]schemas.CostarInfo, error)
+	GetActorPath(context.Context, uint, uint) (schemas.ActorPathResponse, error)
+	GetFilmography(context.Context, uint) (schemas.FilmographyResponse, error)
+	MergeActors(context.Context, uint, schemas.MergeActorsRequest) (schemas.MergeActorsResponse, error)
 }
 
 type ActorHandler struct {
@@ -41,23 +47,36 @@ func NewActorHandler(service ActorService, validate *validator.Validate) *ActorH
 //	@Accept			json
 //	@Produce		json
 //	@Param			actor	body		schemas.AddActorRequest	true	"New actor"
+//	@Param			force	query		bool					false	"create the actor even if it duplicates an existing one"
 //	@Success		201		{object}	schemas.ActorInfo
-//	@Failure		401		{object}	schemas.ErrorResponse
-//	@Failure		403		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		409		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
 //	@Router			/v1/actors [post]
 func (h *ActorHandler) Add() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		force, err := parseBoolQuery(r, "force")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
 		var schema schemas.AddActorRequest
-		err := validateRequestBody(r, h.validate, &schema)
+		err = validateRequestBody(r, h.validate, &schema)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: err.Error()}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			badRequest(w, err)
 			return
 		}
 
-		actor, err := h.service.AddActor(r.Context(), schema)
+		actor, err := h.service.AddActor(r.Context(), schema, force != nil && *force)
 		if err != nil {
+			var duplicateErr *duplicates.ErrDuplicate
+			if errors.As(err, &duplicateErr) {
+				duplicateError(w, duplicateErr)
+				return
+			}
 			internalError(w)
 			return
 		}
@@ -82,26 +101,24 @@ func (h *ActorHandler) Add() http.Handler {
 //	@Param			actor	body		schemas.UpdateActorRequest	true	"Update actor"
 //	@Param			id		path		int							true	"Actor id"
 //	@Success		204		{object}	nil
-//	@Failure		401		{object}	schemas.ErrorResponse
-//	@Failure		403		{object}	schemas.ErrorResponse
-//	@Failure		404		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
 //	@Router			/v1/actors/{id} [put]
 func (h *ActorHandler) Update() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		idParam := r.PathValue("id")
 		id, err := strconv.ParseUint(idParam, 10, 64)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: "invalid path parameter: id"}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			invalidParameter(w, "id", "must be a non-negative integer")
 			return
 		}
 
 		var schema schemas.UpdateActorRequest
 		err = validateRequestBody(r, h.validate, &schema)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: err.Error()}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			badRequest(w, err)
 			return
 		}
 
@@ -109,8 +126,7 @@ func (h *ActorHandler) Update() http.Handler {
 		if err != nil {
 			var notFoundErr *postgresql.ErrRecordNotFound
 			if errors.As(err, &notFoundErr) {
-				resp := schemas.ErrorResponse{Error: "actor not found"}
-				_ = writeJson(w, resp, http.StatusNotFound)
+				writeProblem(w, http.StatusNotFound, "actor not found")
 				return
 			}
 			internalError(w)
@@ -136,26 +152,24 @@ func (h *ActorHandler) Update() http.Handler {
 //	@Param			actor	body		schemas.PartialUpdateActorRequest	true	"Update actor"
 //	@Param			id		path		int									true	"Actor id"
 //	@Success		204		{object}	nil
-//	@Failure		401		{object}	schemas.ErrorResponse
-//	@Failure		403		{object}	schemas.ErrorResponse
-//	@Failure		404		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
 //	@Router			/v1/actors/{id} [patch]
 func (h *ActorHandler) PartialUpdate() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		idParam := r.PathValue("id")
 		id, err := strconv.ParseUint(idParam, 10, 64)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: "invalid path parameter: id"}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			invalidParameter(w, "id", "must be a non-negative integer")
 			return
 		}
 
 		var schema schemas.PartialUpdateActorRequest
 		err = validateRequestBody(r, h.validate, &schema)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: err.Error()}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			badRequest(w, err)
 			return
 		}
 
@@ -163,8 +177,7 @@ func (h *ActorHandler) PartialUpdate() http.Handler {
 		if err != nil {
 			var notFoundErr *postgresql.ErrRecordNotFound
 			if errors.As(err, &notFoundErr) {
-				resp := schemas.ErrorResponse{Error: "actor not found"}
-				_ = writeJson(w, resp, http.StatusNotFound)
+				writeProblem(w, http.StatusNotFound, "actor not found")
 				return
 			}
 			internalError(w)
@@ -189,18 +202,17 @@ func (h *ActorHandler) PartialUpdate() http.Handler {
 //	@Produce		json
 //	@Param			id	path		int	true	"Actor id"
 //	@Success		204	{object}	nil
-//	@Failure		401	{object}	schemas.ErrorResponse
-//	@Failure		403	{object}	schemas.ErrorResponse
-//	@Failure		404	{object}	schemas.ErrorResponse
-//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
 //	@Router			/v1/actors/{id} [delete]
 func (h *ActorHandler) Remove() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		idParam := r.PathValue("id")
 		id, err := strconv.ParseUint(idParam, 10, 64)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: "invalid path parameter: id"}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			invalidParameter(w, "id", "must be a non-negative integer")
 			return
 
 		}
@@ -208,8 +220,7 @@ func (h *ActorHandler) Remove() http.Handler {
 		if err != nil {
 			var notFoundErr *postgresql.ErrRecordNotFound
 			if errors.As(err, &notFoundErr) {
-				resp := schemas.ErrorResponse{Error: "actor not found"}
-				_ = writeJson(w, resp, http.StatusNotFound)
+				writeProblem(w, http.StatusNotFound, "actor not found")
 				return
 			}
 			internalError(w)
@@ -232,19 +243,244 @@ func (h *ActorHandler) Remove() http.Handler {
 //	@Tags			actors
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{array}		schemas.ActorWithFilmsResponse
-//	@Failure		401	{object}	schemas.ErrorResponse
-//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Param			Accept-Language		header		string	false	"preferred languages of names"
+//	@Param			lang				query		string	false	"language of names, overrides Accept-Language"
+//	@Param			fields				query		string	false	"comma separated fields of actors and films, e.g. id,lastName,films.title"
+//	@Param			include				query		string	false	"related records to include: films"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{array}		schemas.ActorWithFilmsResponse
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		400					{object}	schemas.Problem
+//	@Failure		401					{object}	schemas.Problem
+//	@Failure		500					{object}	schemas.Problem
 //	@Router			/v1/actors [get]
 func (h *ActorHandler) GetList() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		actors, err := h.service.GetActorsWithFilms(r.Context())
+		options, err := parseListOptions(r, schemas.ActorFields, "films", schemas.FilmFields)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		actors, err := h.service.GetActorsWithFilms(r.Context(), options)
+		if err != nil {
+			internalError(w)
+			return
+		}
+
+		if options.IsDefault() {
+			err = writeJson(w, actors, http.StatusOK)
+		} else {
+			err = writeFields(w, actors, options, "films")
+		}
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// GetCostars godoc
+//
+//	@Summary		List co-stars
+//	@Description	Get actors who starred with the actor ranked by the number of shared films
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		int		true	"Actor id"
+//	@Param			limit				query		int		false	"maximal number of co-stars, 20 by default, at most 100"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{array}		schemas.CostarInfo
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		400					{object}	schemas.Problem
+//	@Failure		401					{object}	schemas.Problem
+//	@Failure		404					{object}	schemas.Problem
+//	@Failure		500					{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/costars [get]
+func (h *ActorHandler) GetCostars() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		limit, err := parseUintQuery(r, "limit")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		costars, err := h.service.GetCostars(r.Context(), uint(id), limit)
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			if errors.As(err, &notFoundErr) {
+				writeProblem(w, http.StatusNotFound, err.Error())
+				return
+			}
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, costars, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// GetPath godoc
+//
+//	@Summary		Connect actors
+//	@Description	Get the shortest chain of films connecting two actors, at most 6 films long
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			a					path		int		true	"First actor id"
+//	@Param			b					path		int		true	"Second actor id"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{object}	schemas.ActorPathResponse
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		400					{object}	schemas.Problem
+//	@Failure		401					{object}	schemas.Problem
+//	@Failure		404					{object}	schemas.Problem
+//	@Failure		500					{object}	schemas.Problem
+//	@Router			/v1/actors/{a}/path/{b} [get]
+func (h *ActorHandler) GetPath() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fromParam := r.PathValue("a")
+		fromId, err := strconv.ParseUint(fromParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "a", "must be a non-negative integer")
+			return
+		}
+
+		toParam := r.PathValue("b")
+		toId, err := strconv.ParseUint(toParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "b", "must be a non-negative integer")
+			return
+		}
+
+		path, err := h.service.GetActorPath(r.Context(), uint(fromId), uint(toId))
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			switch {
+			case errors.As(err, &notFoundErr):
+				writeProblem(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, postgresql.ErrPathNotFound):
+				writeProblem(w, http.StatusNotFound, "actors are not connected")
+			default:
+				internalError(w)
+			}
+			return
+		}
+
+		err = writeJson(w, path, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// GetFilmography godoc
+//
+//	@Summary		Get filmography
+//	@Description	Get films of the actor ordered by release date and grouped by year with the age at release and career summary
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id					path		int		true	"Actor id"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{object}	schemas.FilmographyResponse
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		400					{object}	schemas.Problem
+//	@Failure		401					{object}	schemas.Problem
+//	@Failure		404					{object}	schemas.Problem
+//	@Failure		500					{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/filmography [get]
+func (h *ActorHandler) GetFilmography() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		filmography, err := h.service.GetFilmography(r.Context(), uint(id))
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			if errors.As(err, &notFoundErr) {
+				writeProblem(w, http.StatusNotFound, err.Error())
+				return
+			}
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, filmography, http.StatusOK)
 		if err != nil {
 			internalError(w)
 			return
 		}
+	})
+}
+
+// Merge godoc
+//
+//	@Summary		Merge actors
+//	@Description	Merge the duplicate actor into the actor. Films are moved to the actor,
+//	@Description	listed fields are taken from the duplicate, its id keeps resolving to the actor
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			merge	body		schemas.MergeActorsRequest	true	"Duplicate actor"
+//	@Param			id		path		int							true	"Actor id"
+//	@Success		200		{object}	schemas.MergeActorsResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/merge [post]
+func (h *ActorHandler) Merge() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		var schema schemas.MergeActorsRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		result, err := h.service.MergeActors(r.Context(), id, schema)
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			switch {
+			case errors.As(err, &notFoundErr):
+				writeProblem(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, actors.ErrSelfMerge):
+				badRequest(w, err)
+			default:
+				internalError(w)
+			}
+			return
+		}
 
-		err = writeJson(w, actors, http.StatusOK)
+		err = writeJson(w, result, http.StatusOK)
 		if err != nil {
 			internalError(w)
 			return
@@ -0,0 +1,485 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type AwardService interface {
+	AddCeremony(context.Context, schemas.AddCeremonyRequest) (models.AwardCeremony, error)
+	UpdateCeremony(context.Context, uint, schemas.UpdateCeremonyRequest) error
+	RemoveCeremony(context.Context, uint) error
+	GetCeremonies(context.Context) ([]schemas.CeremonyInfo, error)
+	AddCategory(context.Context, uint, schemas.AddCategoryRequest) (models.AwardCategory, error)
+	RemoveCategory(context.Context, uint) error
+	AddNomination(context.Context, schemas.AddNominationRequest) (schemas.NominationInfo, error)
+	PartialUpdateNomination(context.Context, uint, schemas.PartialUpdateNominationRequest) error
+	RemoveNomination(context.Context, uint) error
+	GetNominations(context.Context, schemas.NominationsFilter) ([]schemas.NominationInfo, error)
+}
+
+type AwardsHandler struct {
+	service  AwardService
+	validate *validator.Validate
+}
+
+func NewAwardsHandler(service AwardService, validate *validator.Validate) *AwardsHandler {
+	return &AwardsHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// GetCeremonies godoc
+//
+//	@Summary		List award ceremonies
+//	@Description	Get list of award ceremonies with their categories
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		schemas.CeremonyInfo
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/awards/ceremonies [get]
+func (h *AwardsHandler) GetCeremonies() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ceremonies, err := h.service.GetCeremonies(r.Context())
+		if err != nil {
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, ceremonies, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// AddCeremony godoc
+//
+//	@Summary		Add award ceremony
+//	@Description	Add award ceremony to database
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			ceremony	body		schemas.AddCeremonyRequest	true	"New ceremony"
+//	@Success		201			{object}	schemas.CeremonyInfo
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		409			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/awards/ceremonies [post]
+func (h *AwardsHandler) AddCeremony() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var schema schemas.AddCeremonyRequest
+		err := validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		ceremony, err := h.service.AddCeremony(r.Context(), schema)
+		if err != nil {
+			awardError(w, err)
+			return
+		}
+
+		resp := schemas.NewCeremonyInfo(ceremony)
+		err = writeJson(w, resp, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// UpdateCeremony godoc
+//
+//	@Summary		Update award ceremony
+//	@Description	Update award ceremony
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			ceremony	body		schemas.UpdateCeremonyRequest	true	"Update ceremony"
+//	@Param			id			path		int								true	"Ceremony id"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		409			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/awards/ceremonies/{id} [put]
+func (h *AwardsHandler) UpdateCeremony() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		var schema schemas.UpdateCeremonyRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.UpdateCeremony(r.Context(), id, schema)
+		if err != nil {
+			awardError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveCeremony godoc
+//
+//	@Summary		Remove award ceremony
+//	@Description	Remove award ceremony with its categories and nominations
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Ceremony id"
+//	@Success		204	{object}	nil
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/awards/ceremonies/{id} [delete]
+func (h *AwardsHandler) RemoveCeremony() http.Handler {
+	return h.remove(h.service.RemoveCeremony)
+}
+
+// GetCeremonyYear godoc
+//
+//	@Summary		List ceremony nominations
+//	@Description	Get nominations of the ceremony in the given year
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int	true	"Ceremony id"
+//	@Param			year	path		int	true	"Ceremony year"
+//	@Success		200		{array}		schemas.NominationInfo
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/awards/ceremonies/{id}/years/{year} [get]
+func (h *AwardsHandler) GetCeremonyYear() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		year, err := parseUintPath(r, "year")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		ceremonyId, ceremonyYear := id, year
+		h.writeNominations(w, r, schemas.NominationsFilter{
+			CeremonyID: &ceremonyId,
+			Year:       &ceremonyYear,
+		})
+	})
+}
+
+// AddCategory godoc
+//
+//	@Summary		Add award category
+//	@Description	Add category to the award ceremony
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			category	body		schemas.AddCategoryRequest	true	"New category"
+//	@Param			id			path		int							true	"Ceremony id"
+//	@Success		201			{object}	schemas.CategoryInfo
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		409			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/awards/ceremonies/{id}/categories [post]
+func (h *AwardsHandler) AddCategory() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		var schema schemas.AddCategoryRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		category, err := h.service.AddCategory(r.Context(), id, schema)
+		if err != nil {
+			awardError(w, err)
+			return
+		}
+
+		resp := schemas.NewCategoryInfo(category)
+		err = writeJson(w, resp, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveCategory godoc
+//
+//	@Summary		Remove award category
+//	@Description	Remove award category with its nominations
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Category id"
+//	@Success		204	{object}	nil
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/awards/categories/{id} [delete]
+func (h *AwardsHandler) RemoveCategory() http.Handler {
+	return h.remove(h.service.RemoveCategory)
+}
+
+// AddNomination godoc
+//
+//	@Summary		Add nomination
+//	@Description	Add nomination of a film and/or an actor
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			nomination	body		schemas.AddNominationRequest	true	"New nomination"
+//	@Success		201			{object}	schemas.NominationInfo
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/awards/nominations [post]
+func (h *AwardsHandler) AddNomination() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var schema schemas.AddNominationRequest
+		err := validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		nomination, err := h.service.AddNomination(r.Context(), schema)
+		if err != nil {
+			awardError(w, err)
+			return
+		}
+
+		err = writeJson(w, nomination, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// PartialUpdateNomination godoc
+//
+//	@Summary		Partial update nomination
+//	@Description	Partial update nomination
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			nomination	body		schemas.PartialUpdateNominationRequest	true	"Update nomination"
+//	@Param			id			path		int										true	"Nomination id"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/awards/nominations/{id} [patch]
+func (h *AwardsHandler) PartialUpdateNomination() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		var schema schemas.PartialUpdateNominationRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.PartialUpdateNomination(r.Context(), id, schema)
+		if err != nil {
+			awardError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveNomination godoc
+//
+//	@Summary		Remove nomination
+//	@Description	Remove nomination
+//	@Security		BasicAuth
+//	@Tags			awards
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Nomination id"
+//	@Success		204	{object}	nil
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/awards/nominations/{id} [delete]
+func (h *AwardsHandler) RemoveNomination() http.Handler {
+	return h.remove(h.service.RemoveNomination)
+}
+
+// GetFilmAwards godoc
+//
+//	@Summary		List film awards
+//	@Description	Get nominations and wins of the film
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Film id"
+//	@Success		200	{array}		schemas.NominationInfo
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/films/{id}/awards [get]
+func (h *AwardsHandler) GetFilmAwards() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		h.writeNominations(w, r, schemas.NominationsFilter{FilmID: &id})
+	})
+}
+
+// GetActorAwards godoc
+//
+//	@Summary		List actor awards
+//	@Description	Get nominations and wins of the actor
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Actor id"
+//	@Success		200	{array}		schemas.NominationInfo
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/awards [get]
+func (h *AwardsHandler) GetActorAwards() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		h.writeNominations(w, r, schemas.NominationsFilter{ActorID: &id})
+	})
+}
+
+func (h *AwardsHandler) writeNominations(
+	w http.ResponseWriter, r *http.Request, filter schemas.NominationsFilter,
+) {
+	nominations, err := h.service.GetNominations(r.Context(), filter)
+	if err != nil {
+		internalError(w)
+		return
+	}
+
+	err = writeJson(w, nominations, http.StatusOK)
+	if err != nil {
+		internalError(w)
+		return
+	}
+}
+
+func (h *AwardsHandler) remove(
+	remove func(context.Context, uint) error,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = remove(r.Context(), id)
+		if err != nil {
+			awardError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// awardError writes response matching the awards service error.
+func awardError(w http.ResponseWriter, err error) {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	switch {
+	case errors.As(err, &notFoundErr):
+		writeProblem(w, http.StatusNotFound, err.Error())
+	case errors.As(err, &existsErr):
+		writeProblem(w, http.StatusConflict, err.Error())
+	default:
+		internalError(w)
+	}
+}
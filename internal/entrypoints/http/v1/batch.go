@@ -0,0 +1,283 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+)
+
+// errBatchFailed rolls back the atomic batch.
+var errBatchFailed = errors.New("batch operation failed")
+
+type Transactor interface {
+	WithinTransaction(context.Context, func(context.Context) error) error
+}
+
+type BatchHandler struct {
+	actors     ActorService
+	films      FilmService
+	transactor Transactor
+	validate   *validator.Validate
+}
+
+func NewBatchHandler(
+	actors ActorService,
+	films FilmService,
+	transactor Transactor,
+	validate *validator.Validate,
+) *BatchHandler {
+	return &BatchHandler{
+		actors:     actors,
+		films:      films,
+		transactor: transactor,
+		validate:   validate,
+	}
+}
+
+// Actors godoc
+//
+//	@Summary		Batch actors
+//	@Description	Run create, update, patch and delete operations on actors. Data of the operation is the body
+//	@Description	of the single actor request. Atomic batch is rolled back on the first failed operation.
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			batch	body		schemas.BatchRequest	true	"Operations"
+//	@Param			atomic	query		bool					false	"run operations in a single transaction, true by default"
+//	@Success		200		{object}	schemas.BatchResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/actors:batch [post]
+func (h *BatchHandler) Actors() http.Handler {
+	return h.handle(h.applyActor)
+}
+
+// Films godoc
+//
+//	@Summary		Batch films
+//	@Description	Run create, update, patch and delete operations on films. Data of the operation is the body
+//	@Description	of the single film request. Atomic batch is rolled back on the first failed operation.
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			json
+//	@Produce		json
+//	@Param			batch	body		schemas.BatchRequest	true	"Operations"
+//	@Param			atomic	query		bool					false	"run operations in a single transaction, true by default"
+//	@Success		200		{object}	schemas.BatchResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/films:batch [post]
+func (h *BatchHandler) Films() http.Handler {
+	return h.handle(h.applyFilm)
+}
+
+func (h *BatchHandler) handle(
+	apply func(context.Context, schemas.BatchOperation) schemas.BatchResult,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic, err := parseBoolQuery(r, "atomic")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		var schema schemas.BatchRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		resp := schemas.BatchResponse{
+			Atomic:    atomic == nil || *atomic,
+			Committed: true,
+			Results:   make([]schemas.BatchResult, len(schema.Operations)),
+		}
+
+		if !resp.Atomic {
+			for i, operation := range schema.Operations {
+				resp.Results[i] = apply(r.Context(), operation)
+			}
+		} else {
+			err = h.transactor.WithinTransaction(r.Context(), func(ctx context.Context) error {
+				for i, operation := range schema.Operations {
+					resp.Results[i] = apply(ctx, operation)
+					if resp.Results[i].Status >= http.StatusBadRequest {
+						rollBackResults(resp.Results, schema.Operations, i)
+						return errBatchFailed
+					}
+				}
+				return nil
+			})
+			if err != nil && !errors.Is(err, errBatchFailed) {
+				internalError(w)
+				return
+			}
+			resp.Committed = err == nil
+		}
+
+		err = writeJson(w, resp, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// rollBackResults marks every operation but the failed one as not applied.
+func rollBackResults(
+	results []schemas.BatchResult, operations []schemas.BatchOperation, failed int,
+) {
+	message := fmt.Sprintf("not applied, operation %d failed", failed)
+	for i := range results {
+		if i == failed {
+			continue
+		}
+
+		results[i] = batchFailure(
+			http.StatusFailedDependency, operations[i].ID, message,
+		)
+	}
+}
+
+func (h *BatchHandler) applyActor(
+	ctx context.Context, operation schemas.BatchOperation,
+) schemas.BatchResult {
+	switch operation.Op {
+	case schemas.BatchCreate:
+		var schema schemas.AddActorRequest
+		if result, ok := h.decode(operation, &schema); !ok {
+			return result
+		}
+
+		actor, err := h.actors.AddActor(ctx, schema, false)
+		if err != nil {
+			return batchError(err)
+		}
+		return schemas.BatchResult{Status: http.StatusCreated, ID: &actor.ID}
+	case schemas.BatchUpdate:
+		var schema schemas.UpdateActorRequest
+		if result, ok := h.decode(operation, &schema); !ok {
+			return result
+		}
+		return batchResult(operation, h.actors.UpdateActor(ctx, *operation.ID, schema))
+	case schemas.BatchPatch:
+		var schema schemas.PartialUpdateActorRequest
+		if result, ok := h.decode(operation, &schema); !ok {
+			return result
+		}
+		return batchResult(operation, h.actors.PartialUpdateActor(ctx, *operation.ID, schema))
+	default:
+		if result, ok := h.decode(operation, nil); !ok {
+			return result
+		}
+		return batchResult(operation, h.actors.RemoveActor(ctx, *operation.ID))
+	}
+}
+
+func (h *BatchHandler) applyFilm(
+	ctx context.Context, operation schemas.BatchOperation,
+) schemas.BatchResult {
+	switch operation.Op {
+	case schemas.BatchCreate:
+		var schema schemas.AddFilmRequest
+		if result, ok := h.decode(operation, &schema); !ok {
+			return result
+		}
+
+		film, err := h.films.AddFilm(ctx, schema, false)
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			if errors.As(err, &notFoundErr) {
+				return batchFailure(http.StatusBadRequest, nil, "invalid actor id")
+			}
+			return batchError(err)
+		}
+		return schemas.BatchResult{Status: http.StatusCreated, ID: &film.ID}
+	case schemas.BatchUpdate:
+		var schema schemas.UpdateFilmRequest
+		if result, ok := h.decode(operation, &schema); !ok {
+			return result
+		}
+		return batchResult(operation, h.films.UpdateFilm(ctx, *operation.ID, schema))
+	case schemas.BatchPatch:
+		var schema schemas.PartialUpdateFilmRequest
+		if result, ok := h.decode(operation, &schema); !ok {
+			return result
+		}
+		return batchResult(operation, h.films.PartialUpdateFilm(ctx, *operation.ID, schema))
+	default:
+		if result, ok := h.decode(operation, nil); !ok {
+			return result
+		}
+		return batchResult(operation, h.films.RemoveFilm(ctx, *operation.ID))
+	}
+}
+
+// decode checks the id of the operation and validates its data against
+// the schema. A nil schema means the operation has no data.
+func (h *BatchHandler) decode(
+	operation schemas.BatchOperation, schema any,
+) (schemas.BatchResult, bool) {
+	if operation.Op != schemas.BatchCreate && operation.ID == nil {
+		return batchFailure(http.StatusBadRequest, nil, "id is required"), false
+	}
+	if schema == nil {
+		return schemas.BatchResult{}, true
+	}
+
+	if err := json.Unmarshal(operation.Data, schema); err != nil {
+		return batchFailure(http.StatusBadRequest, operation.ID, "invalid data"), false
+	}
+	if err := h.validate.Struct(schema); err != nil {
+		result := batchFailure(http.StatusBadRequest, operation.ID, "invalid data")
+		result.Errors = problems.FieldErrors(err)
+		return result, false
+	}
+
+	return schemas.BatchResult{}, true
+}
+
+// batchResult is the result of the operation on the existing record.
+func batchResult(operation schemas.BatchOperation, err error) schemas.BatchResult {
+	if err != nil {
+		result := batchError(err)
+		result.ID = operation.ID
+		return result
+	}
+
+	return schemas.BatchResult{Status: http.StatusNoContent, ID: operation.ID}
+}
+
+func batchError(err error) schemas.BatchResult {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	var duplicateErr *duplicates.ErrDuplicate
+	switch {
+	case errors.As(err, &notFoundErr):
+		return batchFailure(http.StatusNotFound, nil, err.Error())
+	case errors.As(err, &duplicateErr):
+		return batchFailure(http.StatusConflict, &duplicateErr.ID, err.Error())
+	case errors.As(err, &existsErr):
+		return batchFailure(http.StatusConflict, nil, err.Error())
+	default:
+		return batchFailure(http.StatusInternalServerError, nil, "internal server error")
+	}
+}
+
+func batchFailure(status int, id *uint, message string) schemas.BatchResult {
+	return schemas.BatchResult{Status: status, ID: id, Error: &message}
+}
@@ -0,0 +1,393 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+)
+
+type CastService interface {
+	GetFilmActors(context.Context, uint) ([]schemas.ActorInfo, error)
+	GetActorFilms(context.Context, uint) ([]schemas.FilmInfo, error)
+	AddLink(context.Context, uint, uint) (schemas.CastLink, error)
+	RemoveLink(context.Context, uint, uint) error
+	SetFilmActors(context.Context, uint, schemas.SetFilmActorsRequest) error
+	SetActorFilms(context.Context, uint, schemas.SetActorFilmsRequest) error
+}
+
+type CastHandler struct {
+	service  CastService
+	validate *validator.Validate
+}
+
+func NewCastHandler(service CastService, validate *validator.Validate) *CastHandler {
+	return &CastHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// GetFilmActors godoc
+//
+//	@Summary		List actors of film
+//	@Description	Get actors of the film
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Film id"
+//	@Success		200	{array}		schemas.ActorInfo
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/films/{id}/actors [get]
+func (h *CastHandler) GetFilmActors() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		actors, err := h.service.GetFilmActors(r.Context(), id)
+		if err != nil {
+			castError(w, err)
+			return
+		}
+
+		err = writeJson(w, actors, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// SetFilmActors godoc
+//
+//	@Summary		Replace actors of film
+//	@Description	Replace actors of the film with the given list
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			json
+//	@Produce		json
+//	@Param			actors	body		schemas.SetFilmActorsRequest	true	"Actors ids"
+//	@Param			id		path		int	true	"Film id"
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/films/{id}/actors [put]
+func (h *CastHandler) SetFilmActors() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		var schema schemas.SetFilmActorsRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.SetFilmActors(r.Context(), id, schema)
+		if err != nil {
+			castError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// AddFilmActor godoc
+//
+//	@Summary		Add actor to film
+//	@Description	Link the actor to the film
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int	true	"Film id"
+//	@Param			actorId	path		int	true	"Actor id"
+//	@Success		201			{object}	schemas.CastLink
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		409			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/films/{id}/actors/{actorId} [post]
+func (h *CastHandler) AddFilmActor() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		actorId, err := parseUintPath(r, "actorId")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		link, err := h.service.AddLink(r.Context(), actorId, id)
+		if err != nil {
+			castError(w, err)
+			return
+		}
+
+		err = writeJson(w, link, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveFilmActor godoc
+//
+//	@Summary		Remove actor from film
+//	@Description	Unlink the actor from the film
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int	true	"Film id"
+//	@Param			actorId	path		int	true	"Actor id"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/films/{id}/actors/{actorId} [delete]
+func (h *CastHandler) RemoveFilmActor() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		actorId, err := parseUintPath(r, "actorId")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.RemoveLink(r.Context(), actorId, id)
+		if err != nil {
+			castError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// GetActorFilms godoc
+//
+//	@Summary		List films of actor
+//	@Description	Get films of the actor
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Actor id"
+//	@Success		200	{array}		schemas.FilmInfo
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/films [get]
+func (h *CastHandler) GetActorFilms() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		films, err := h.service.GetActorFilms(r.Context(), id)
+		if err != nil {
+			castError(w, err)
+			return
+		}
+
+		err = writeJson(w, films, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// SetActorFilms godoc
+//
+//	@Summary		Replace films of actor
+//	@Description	Replace films of the actor with the given list
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			films	body		schemas.SetActorFilmsRequest	true	"Films ids"
+//	@Param			id		path		int	true	"Actor id"
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/films [put]
+func (h *CastHandler) SetActorFilms() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		var schema schemas.SetActorFilmsRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.SetActorFilms(r.Context(), id, schema)
+		if err != nil {
+			castError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// AddActorFilm godoc
+//
+//	@Summary		Add film to actor
+//	@Description	Link the film to the actor
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int	true	"Actor id"
+//	@Param			filmId	path		int	true	"Film id"
+//	@Success		201			{object}	schemas.CastLink
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		409			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/films/{filmId} [post]
+func (h *CastHandler) AddActorFilm() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		filmId, err := parseUintPath(r, "filmId")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		link, err := h.service.AddLink(r.Context(), id, filmId)
+		if err != nil {
+			castError(w, err)
+			return
+		}
+
+		err = writeJson(w, link, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveActorFilm godoc
+//
+//	@Summary		Remove film from actor
+//	@Description	Unlink the film from the actor
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int	true	"Actor id"
+//	@Param			filmId	path		int	true	"Film id"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/films/{filmId} [delete]
+func (h *CastHandler) RemoveActorFilm() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		filmId, err := parseUintPath(r, "filmId")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.RemoveLink(r.Context(), id, filmId)
+		if err != nil {
+			castError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+func castError(w http.ResponseWriter, err error) {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	switch {
+	case errors.As(err, &notFoundErr):
+		writeProblem(w, http.StatusNotFound, err.Error())
+	case errors.As(err, &existsErr):
+		writeProblem(w, http.StatusConflict, err.Error())
+	default:
+		internalError(w)
+	}
+}
@@ -0,0 +1,405 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/collections"
+)
+
+type CollectionService interface {
+	AddCollection(context.Context, models.User, schemas.AddCollectionRequest) (models.Collection, error)
+	PartialUpdateCollection(context.Context, models.User, uint, schemas.PartialUpdateCollectionRequest) error
+	RemoveCollection(context.Context, models.User, uint) error
+	GetCollections(context.Context, models.User) ([]schemas.CollectionInfo, error)
+	GetCollectionWithFilms(context.Context, models.User, uint) (schemas.CollectionWithFilmsResponse, error)
+	AddFilm(context.Context, models.User, uint, schemas.AddCollectionItemRequest) error
+	RemoveFilm(context.Context, models.User, uint, uint) error
+	ReorderFilms(context.Context, models.User, uint, schemas.ReorderCollectionRequest) error
+}
+
+type CollectionsHandler struct {
+	service  CollectionService
+	validate *validator.Validate
+}
+
+func NewCollectionsHandler(
+	service CollectionService, validate *validator.Validate,
+) *CollectionsHandler {
+	return &CollectionsHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// Add godoc
+//
+//	@Summary		Add collection
+//	@Description	Create collection owned by the current user
+//	@Security		BasicAuth
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	body		schemas.AddCollectionRequest	true	"New collection"
+//	@Success		201			{object}	schemas.CollectionInfo
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/collections [post]
+func (h *CollectionsHandler) Add() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var schema schemas.AddCollectionRequest
+		err := validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		collection, err := h.service.AddCollection(
+			r.Context(), currentUser(r), schema,
+		)
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			var existsErr *postgresql.ErrRecordAlreadyExists
+			if errors.As(err, &notFoundErr) {
+				writeProblem(w, http.StatusBadRequest, "invalid film id")
+				return
+			}
+			if errors.As(err, &existsErr) {
+				writeProblem(w, http.StatusBadRequest, "duplicated film id")
+				return
+			}
+			internalError(w)
+			return
+		}
+
+		resp := schemas.NewCollectionInfo(collection)
+		err = writeJson(w, resp, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// PartialUpdate godoc
+//
+//	@Summary		Partial update collection
+//	@Description	Partial update collection. Only owner or admin can do it
+//	@Security		BasicAuth
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Param			collection	body		schemas.PartialUpdateCollectionRequest	true	"Update collection"
+//	@Param			id			path		int										true	"Collection id"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/collections/{id} [patch]
+func (h *CollectionsHandler) PartialUpdate() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		var schema schemas.PartialUpdateCollectionRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.PartialUpdateCollection(
+			r.Context(), currentUser(r), uint(id), schema,
+		)
+		if err != nil {
+			collectionError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Remove godoc
+//
+//	@Summary		Remove collection
+//	@Description	Remove collection. Only owner or admin can do it
+//	@Security		BasicAuth
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Collection id"
+//	@Success		204	{object}	nil
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/collections/{id} [delete]
+func (h *CollectionsHandler) Remove() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		err = h.service.RemoveCollection(r.Context(), currentUser(r), uint(id))
+		if err != nil {
+			collectionError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// GetList godoc
+//
+//	@Summary		List collections
+//	@Description	Get list of public collections and private collections of the current user
+//	@Security		BasicAuth
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		schemas.CollectionInfo
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/collections [get]
+func (h *CollectionsHandler) GetList() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		collections, err := h.service.GetCollections(r.Context(), currentUser(r))
+		if err != nil {
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, collections, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Get godoc
+//
+//	@Summary		Get collection
+//	@Description	Get collection with films in their order
+//	@Security		BasicAuth
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Collection id"
+//	@Success		200	{object}	schemas.CollectionWithFilmsResponse
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/collections/{id} [get]
+func (h *CollectionsHandler) Get() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		collection, err := h.service.GetCollectionWithFilms(
+			r.Context(), currentUser(r), uint(id),
+		)
+		if err != nil {
+			collectionError(w, err)
+			return
+		}
+
+		err = writeJson(w, collection, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// AddFilm godoc
+//
+//	@Summary		Add film to collection
+//	@Description	Insert film into collection at the given position or append it to the end
+//	@Security		BasicAuth
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Param			item	body		schemas.AddCollectionItemRequest	true	"Collection item"
+//	@Param			id		path		int									true	"Collection id"
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		409		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/collections/{id}/items [post]
+func (h *CollectionsHandler) AddFilm() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		var schema schemas.AddCollectionItemRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.AddFilm(r.Context(), currentUser(r), uint(id), schema)
+		if err != nil {
+			collectionError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveFilm godoc
+//
+//	@Summary		Remove film from collection
+//	@Description	Remove film from collection
+//	@Security		BasicAuth
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int	true	"Collection id"
+//	@Param			filmId	path		int	true	"Film id"
+//	@Success		204		{object}	nil
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/collections/{id}/items/{filmId} [delete]
+func (h *CollectionsHandler) RemoveFilm() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		filmIdParam := r.PathValue("filmId")
+		filmId, err := strconv.ParseUint(filmIdParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "filmId", "must be a non-negative integer")
+			return
+		}
+
+		err = h.service.RemoveFilm(
+			r.Context(), currentUser(r), uint(id), uint(filmId),
+		)
+		if err != nil {
+			collectionError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Reorder godoc
+//
+//	@Summary		Reorder collection
+//	@Description	Atomically set the order of collection films. The list must contain every film of the collection exactly once
+//	@Security		BasicAuth
+//	@Tags			collections
+//	@Accept			json
+//	@Produce		json
+//	@Param			items	body		schemas.ReorderCollectionRequest	true	"Films order"
+//	@Param			id		path		int									true	"Collection id"
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/collections/{id}/items [put]
+func (h *CollectionsHandler) Reorder() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		var schema schemas.ReorderCollectionRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.ReorderFilms(r.Context(), currentUser(r), uint(id), schema)
+		if err != nil {
+			collectionError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// collectionError writes response matching the collections service error.
+func collectionError(w http.ResponseWriter, err error) {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	switch {
+	case errors.As(err, &notFoundErr):
+		writeProblem(w, http.StatusNotFound, err.Error())
+	case errors.As(err, &existsErr):
+		writeProblem(w, http.StatusConflict, "film already in collection")
+	case errors.Is(err, collections.ErrForbidden):
+		writeProblem(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, postgresql.ErrItemsMismatch):
+		writeProblem(w, http.StatusBadRequest, "films must match collection content")
+	default:
+		internalError(w)
+	}
+}
@@ -0,0 +1,78 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+)
+
+type DuplicateService interface {
+	Report(context.Context, *float64, *uint) (schemas.DuplicatesReport, error)
+}
+
+type DuplicatesHandler struct {
+	service DuplicateService
+}
+
+func NewDuplicatesHandler(service DuplicateService) *DuplicatesHandler {
+	return &DuplicatesHandler{
+		service: service,
+	}
+}
+
+// Report godoc
+//
+//	@Summary		Report duplicates
+//	@Description	Get pairs of probable duplicate films and actors ranked by the similarity
+//	@Description	of titles and names. Films are paired if released no more than a year apart
+//	@Security		BasicAuth
+//	@Tags			duplicates
+//	@Accept			json
+//	@Produce		json
+//	@Param			threshold	query		number	false	"minimal similarity from 0.3 to 1, 0.5 by default"
+//	@Param			limit		query		int		false	"maximal number of pairs of each kind, 50 by default, at most 200"
+//	@Success		200			{object}	schemas.DuplicatesReport
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/duplicates [get]
+func (h *DuplicatesHandler) Report() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		threshold, err := parseFloatQuery(r, "threshold")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		limit, err := parseUintQuery(r, "limit")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		report, err := h.service.Report(r.Context(), threshold, limit)
+		if err != nil {
+			if errors.Is(err, duplicates.ErrInvalidThreshold) {
+				badRequest(w, err)
+				return
+			}
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, report, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+func duplicateError(w http.ResponseWriter, err *duplicates.ErrDuplicate) {
+	problems.Write(w, problems.Duplicate(err.Error(), err.ID))
+}
@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+// EventsHeartbeat is the interval of the comments which keep idle
+// streams open behind proxies.
+const EventsHeartbeat = 15 * time.Second
+
+type EventService interface {
+	Subscribe() (<-chan models.Event, func())
+	GetSince(context.Context, int64) ([]models.Event, error)
+}
+
+type EventsHandler struct {
+	service EventService
+}
+
+func NewEventsHandler(service EventService) *EventsHandler {
+	return &EventsHandler{
+		service: service,
+	}
+}
+
+// Stream godoc
+//
+//	@Summary		Stream changes
+//	@Description	Server-sent events of created, updated and deleted films and actors.
+//	@Description	The event name is the type of the event, e.g. film.created, and the data is schemas.Event.
+//	@Description	The stream is resumed after the Last-Event-ID, the last 1000 events are kept.
+//	@Security		BasicAuth
+//	@Tags			events
+//	@Produce		text/event-stream
+//	@Param			Last-Event-ID	header		int	false	"id of the last received event"
+//	@Success		200				{object}	schemas.Event
+//	@Failure		400				{object}	schemas.Problem
+//	@Failure		401				{object}	schemas.Problem
+//	@Router			/v1/events [get]
+func (h *EventsHandler) Stream() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var lastID int64
+		if header := r.Header.Get("Last-Event-ID"); header != "" {
+			id, err := strconv.ParseInt(header, 10, 64)
+			if err != nil || id < 0 {
+				invalidParameter(w, "Last-Event-ID", "must be a non-negative integer")
+				return
+			}
+			lastID = id
+		}
+
+		// the server timeouts would close the stream, the read deadline
+		// cancels the request context as well
+		rc := http.NewResponseController(w)
+		_ = rc.SetReadDeadline(time.Time{})
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		// subscribe first so no event is lost between the replay and the stream
+		events, unsubscribe := h.service.Subscribe()
+		defer unsubscribe()
+
+		var missed []models.Event
+		if lastID > 0 {
+			var err error
+			missed, err = h.service.GetSince(r.Context(), lastID)
+			if err != nil {
+				internalError(w)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		for _, event := range missed {
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			lastID = event.ID
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(EventsHeartbeat)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			case event, ok := <-events:
+				// the subscriber fell behind, the client resumes the stream
+				if !ok {
+					return
+				}
+				if event.ID <= lastID {
+					continue
+				}
+				if err := writeEvent(w, event); err != nil {
+					return
+				}
+				lastID = event.ID
+			}
+
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	})
+}
+
+func writeEvent(w http.ResponseWriter, event models.Event) error {
+	data, err := json.Marshal(schemas.NewEvent(event))
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
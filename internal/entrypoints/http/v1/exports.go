@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/services/exports"
+)
+
+// ExportWriteTimeout replaces the server write timeout for the export.
+const ExportWriteTimeout = time.Hour
+
+var exportContentTypes = map[schemas.ExportFormat]string{
+	schemas.ExportNDJSON: "application/x-ndjson",
+	schemas.ExportCSV:    "text/csv",
+	schemas.ExportJSON:   "application/json",
+}
+
+type ExportService interface {
+	Export(context.Context, schemas.ExportRequest, io.Writer) error
+}
+
+type ExportHandler struct {
+	service ExportService
+}
+
+func NewExportHandler(service ExportService) *ExportHandler {
+	return &ExportHandler{
+		service: service,
+	}
+}
+
+// Export godoc
+//
+//	@Summary		Export catalogue
+//	@Description	Stream films, actors and links between them. NDJSON lines are tagged with the entity,
+//	@Description	JSON holds an array per entity and CSV requires a single entity.
+//	@Description	The export of the rows updated since the time is followed by the deletions of the entities
+//	@Description	since it, deleted films and actors are identified by id, links by actorId and filmId.
+//	@Description	The response is compressed when the client accepts gzip encoding.
+//	@Security		BasicAuth
+//	@Tags			export
+//	@Produce		application/x-ndjson,text/csv,json
+//	@Param			format	query		string	false	"export format, ndjson by default"	Enums(ndjson, csv, json)
+//	@Param			entity	query		string	false	"export only the entity"			Enums(films, actors, links)
+//	@Param			since	query		string	false	"export only rows updated and deleted after the time in RFC 3339 format, not supported by csv"
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Router			/v1/export [get]
+func (h *ExportHandler) Export() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := schemas.ExportRequest{
+			Format:   schemas.ExportNDJSON,
+			Entities: schemas.ExportEntities,
+		}
+		if format := stringQuery(r, "format"); format != nil {
+			request.Format = schemas.ExportFormat(*format)
+		}
+		if entity := stringQuery(r, "entity"); entity != nil {
+			request.Entities = []schemas.ExportEntity{schemas.ExportEntity(*entity)}
+		}
+		if since := stringQuery(r, "since"); since != nil {
+			value, err := time.Parse(time.RFC3339, *since)
+			if err != nil {
+				invalidParameter(w, "since", "must be a RFC 3339 timestamp")
+				return
+			}
+			request.Since = &value
+		}
+
+		if err := exports.Validate(request); err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", exportContentTypes[request.Format])
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		var out io.Writer = w
+		var gz *gzip.Writer
+		if acceptsGzip(r) {
+			w.Header().Set("Content-Encoding", "gzip")
+			gz = gzip.NewWriter(w)
+			out = gz
+		}
+		w.WriteHeader(http.StatusOK)
+
+		err := h.service.Export(r.Context(), request, out)
+		if err == nil && gz != nil {
+			err = gz.Close()
+		}
+		if err != nil {
+			// the status is already sent, the connection is dropped so that
+			// the client does not take the partial export for a whole one
+			log.Printf("export failed: %v", err)
+			panic(http.ErrAbortHandler)
+		}
+	})
+}
+
+// acceptsGzip reports whether the client accepts gzip encoding.
+func acceptsGzip(r *http.Request) bool {
+	for _, encoding := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(encoding), ";")
+		if strings.TrimSpace(name) != "gzip" {
+			continue
+		}
+
+		param, weighted := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !weighted {
+			return true
+		}
+
+		quality, err := strconv.ParseFloat(param, 64)
+		return err == nil && quality > 0
+	}
+
+	return false
+}
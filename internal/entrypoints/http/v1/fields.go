@@ -0,0 +1,136 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// parseListOptions parses the "fields" and "include" query parameters of
+// the list of records which have the given fields and are related to the
+// relation records. The fields of the related records are prefixed with
+// the relation, e.g. "actors.lastName". Returns the default options if
+// neither parameter is set.
+func parseListOptions(
+	r *http.Request, fields []string, relation string, relatedFields []string,
+) (schemas.ListOptions, error) {
+	query := r.URL.Query()
+	if !query.Has("fields") && !query.Has("include") {
+		return schemas.DefaultListOptions, nil
+	}
+
+	var options schemas.ListOptions
+	for _, include := range splitList(query.Get("include")) {
+		if include != relation {
+			return options, problems.InvalidParameter("include", fmt.Sprintf("must be %s", relation))
+		}
+		options.Include = true
+	}
+
+	for _, field := range splitList(query.Get("fields")) {
+		related, found := strings.CutPrefix(field, relation+".")
+		switch {
+		case found && slices.Contains(relatedFields, related):
+			if !options.Include {
+				message := fmt.Sprintf("%s requires include=%s", field, relation)
+				return options, problems.InvalidParameter("fields", message)
+			}
+			options.IncludedFields = append(options.IncludedFields, related)
+		case !found && slices.Contains(fields, field):
+			options.Fields = append(options.Fields, field)
+		default:
+			return options, problems.InvalidParameter("fields", fmt.Sprintf("unknown field: %s", field))
+		}
+	}
+
+	return options, nil
+}
+
+// selectFields returns the records with only the fields selected by the
+// options. The related records are stored under the relation key.
+func selectFields[T any](
+	records []T, options schemas.ListOptions, relation string,
+) ([]map[string]json.RawMessage, error) {
+	result := make([]map[string]json.RawMessage, 0, len(records))
+	for _, record := range records {
+		fields, err := toFields(record)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, value := range fields {
+			switch {
+			case name == relation && !options.Include:
+				delete(fields, name)
+			case name == relation && len(options.IncludedFields) > 0:
+				fields[name], err = selectRelatedFields(value, options)
+				if err != nil {
+					return nil, err
+				}
+			case name != relation && !options.HasField(name):
+				delete(fields, name)
+			}
+		}
+
+		result = append(result, fields)
+	}
+
+	return result, nil
+}
+
+// writeFields writes the records with the fields selected by the options.
+func writeFields[T any](
+	w http.ResponseWriter, records []T, options schemas.ListOptions, relation string,
+) error {
+	result, err := selectFields(records, options, relation)
+	if err != nil {
+		return err
+	}
+
+	return writeJson(w, result, http.StatusOK)
+}
+
+func selectRelatedFields(value json.RawMessage, options schemas.ListOptions) (json.RawMessage, error) {
+	var related []map[string]json.RawMessage
+	if err := json.Unmarshal(value, &related); err != nil {
+		return nil, err
+	}
+
+	for _, fields := range related {
+		for name := range fields {
+			if !options.HasIncludedField(name) {
+				delete(fields, name)
+			}
+		}
+	}
+
+	return json.Marshal(related)
+}
+
+func toFields(record any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	return fields, err
+}
+
+// splitList splits the comma separated list skipping empty items.
+func splitList(list string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
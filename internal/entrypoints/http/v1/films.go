@@ -7,17 +7,19 @@ import (
 	"strconv"
 
 	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
 	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
 	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
 	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
 )
 
 type FilmService interface {
-	AddFilm(context.Context, schemas.AddFilmRequest) (models.Film, error)
+	AddFilm(context.Context, schemas.AddFilmRequest, bool) (models.Film, error)
 	UpdateFilm(context.Context, uint, schemas.UpdateFilmRequest) error
 	PartialUpdateFilm(context.Context, uint, schemas.PartialUpdateFilmRequest) error
 	RemoveFilm(context.Context, uint) error
-	GetFilmsWithActors(context.Context, string, string) ([]schemas.FilmWithActorsResponse, error)
+	GetFilmsWithActors(context.Context, string, string, schemas.FilmsFilter, schemas.ListOptions) ([]schemas.FilmWithActorsResponse, error)
 }
 
 type FilmsHandler struct {
@@ -41,30 +43,41 @@ func NewFilmsHandler(service FilmService, validate *validator.Validate) *FilmsHa
 //	@Accept			json
 //	@Produce		json
 //	@Param			actor	body		schemas.AddFilmRequest	true	"New film"
+//	@Param			force	query		bool					false	"create the film even if it duplicates an existing one"
 //	@Success		201		{object}	schemas.ActorInfo
-//	@Failure		401		{object}	schemas.ErrorResponse
-//	@Failure		403		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		409		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
 //	@Router			/v1/films [post]
 func (h *FilmsHandler) Add() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		force, err := parseBoolQuery(r, "force")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
 		var schema schemas.AddFilmRequest
-		err := validateRequestBody(r, h.validate, &schema)
+		err = validateRequestBody(r, h.validate, &schema)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: err.Error()}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			badRequest(w, err)
 			return
 		}
 
-		film, err := h.service.AddFilm(r.Context(), schema)
+		film, err := h.service.AddFilm(r.Context(), schema, force != nil && *force)
 		if err != nil {
 			var notFoundErr *postgresql.ErrRecordNotFound
-			if errors.As(err, &notFoundErr) {
-				resp := schemas.ErrorResponse{Error: "invalid actor id"}
-				_ = writeJson(w, resp, http.StatusBadRequest)
-				return
+			var duplicateErr *duplicates.ErrDuplicate
+			switch {
+			case errors.As(err, &notFoundErr):
+				writeProblem(w, http.StatusBadRequest, "invalid actor id")
+			case errors.As(err, &duplicateErr):
+				duplicateError(w, duplicateErr)
+			default:
+				internalError(w)
 			}
-			internalError(w)
 			return
 		}
 
@@ -88,26 +101,24 @@ func (h *FilmsHandler) Add() http.Handler {
 //	@Param			actor	body		schemas.UpdateFilmRequest	true	"Update film"
 //	@Param			id		path		int							true	"Film id"
 //	@Success		204		{object}	nil
-//	@Failure		401		{object}	schemas.ErrorResponse
-//	@Failure		403		{object}	schemas.ErrorResponse
-//	@Failure		404		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
 //	@Router			/v1/films/{id} [put]
 func (h *FilmsHandler) Update() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		idParam := r.PathValue("id")
 		id, err := strconv.ParseUint(idParam, 10, 64)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: "invalid path parameter: id"}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			invalidParameter(w, "id", "must be a non-negative integer")
 			return
 		}
 
 		var schema schemas.UpdateFilmRequest
 		err = validateRequestBody(r, h.validate, &schema)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: err.Error()}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			badRequest(w, err)
 			return
 		}
 
@@ -115,8 +126,7 @@ func (h *FilmsHandler) Update() http.Handler {
 		if err != nil {
 			var notFoundErr *postgresql.ErrRecordNotFound
 			if errors.As(err, &notFoundErr) {
-				resp := schemas.ErrorResponse{Error: "actor not found"}
-				_ = writeJson(w, resp, http.StatusNotFound)
+				writeProblem(w, http.StatusNotFound, "actor not found")
 				return
 			}
 			internalError(w)
@@ -142,26 +152,24 @@ func (h *FilmsHandler) Update() http.Handler {
 //	@Param			actor	body		schemas.PartialUpdateFilmRequest	true	"Update film"
 //	@Param			id		path		int									true	"Film id"
 //	@Success		204		{object}	nil
-//	@Failure		401		{object}	schemas.ErrorResponse
-//	@Failure		403		{object}	schemas.ErrorResponse
-//	@Failure		404		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
 //	@Router			/v1/films/{id} [patch]
 func (h *FilmsHandler) PartialUpdate() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		idParam := r.PathValue("id")
 		id, err := strconv.ParseUint(idParam, 10, 64)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: "invalid path parameter: id"}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			invalidParameter(w, "id", "must be a non-negative integer")
 			return
 		}
 
 		var schema schemas.PartialUpdateFilmRequest
 		err = validateRequestBody(r, h.validate, &schema)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: err.Error()}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			badRequest(w, err)
 			return
 		}
 
@@ -169,8 +177,7 @@ func (h *FilmsHandler) PartialUpdate() http.Handler {
 		if err != nil {
 			var notFoundErr *postgresql.ErrRecordNotFound
 			if errors.As(err, &notFoundErr) {
-				resp := schemas.ErrorResponse{Error: "actor not found"}
-				_ = writeJson(w, resp, http.StatusNotFound)
+				writeProblem(w, http.StatusNotFound, "actor not found")
 				return
 			}
 			internalError(w)
@@ -195,18 +202,17 @@ func (h *FilmsHandler) PartialUpdate() http.Handler {
 //	@Produce		json
 //	@Param			id	path		int	true	"Film id"
 //	@Success		204	{object}	nil
-//	@Failure		401	{object}	schemas.ErrorResponse
-//	@Failure		403	{object}	schemas.ErrorResponse
-//	@Failure		404	{object}	schemas.ErrorResponse
-//	@Failure		500	{object}	schemas.ErrorResponse
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
 //	@Router			/v1/films/{id} [delete]
 func (h *FilmsHandler) Remove() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		idParam := r.PathValue("id")
 		id, err := strconv.ParseUint(idParam, 10, 64)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: "invalid path parameter: id"}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			invalidParameter(w, "id", "must be a non-negative integer")
 			return
 
 		}
@@ -214,8 +220,7 @@ func (h *FilmsHandler) Remove() http.Handler {
 		if err != nil {
 			var notFoundErr *postgresql.ErrRecordNotFound
 			if errors.As(err, &notFoundErr) {
-				resp := schemas.ErrorResponse{Error: "film not found"}
-				_ = writeJson(w, resp, http.StatusNotFound)
+				writeProblem(w, http.StatusNotFound, "film not found")
 				return
 			}
 			internalError(w)
@@ -238,25 +243,94 @@ func (h *FilmsHandler) Remove() http.Handler {
 //	@Tags			films
 //	@Accept			json
 //	@Produce		json
-//	@Param			search	query		string	false	"search by films title and actors names"
-//	@Param			sortBy	query		string	false	"sorting by field. Format: orderBy=field1,-field2"
-//	@Success		200		{array}		schemas.FilmWithActorsResponse
-//	@Failure		401		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Param			search				query		string	false	"search by films title and actors names"
+//	@Param			sortBy				query		string	false	"sorting by field: id, title, releaseDate, rating, runtime, budget, boxOffice. Format: sortBy=field1,-field2"
+//	@Param			hasAwards			query		bool	false	"films with (true) or without (false) award nominations"
+//	@Param			awardWinner			query		bool	false	"films which won (true) or never won (false) an award"
+//	@Param			country				query		string	false	"films produced in the country (ISO 3166-1 alpha-2)"
+//	@Param			language			query		string	false	"films with the original language (ISO 639-1)"
+//	@Param			ageRating			query		string	false	"films with the age rating"
+//	@Param			minRuntime			query		int		false	"minimal runtime in minutes"
+//	@Param			maxRuntime			query		int		false	"maximal runtime in minutes"
+//	@Param			lang				query		string	false	"language of titles, overrides Accept-Language"
+//	@Param			Accept-Language		header		string	false	"preferred languages of titles"
+//	@Param			fields				query		string	false	"comma separated fields of films and actors, e.g. id,title,actors.lastName"
+//	@Param			include				query		string	false	"related records to include: actors"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{array}		schemas.FilmWithActorsResponse
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		400					{object}	schemas.Problem
+//	@Failure		401					{object}	schemas.Problem
+//	@Failure		500					{object}	schemas.Problem
 //	@Router			/v1/films [get]
 func (h *FilmsHandler) GetList() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var filter schemas.FilmsFilter
+		var err error
+
+		filter.HasAwards, err = parseBoolQuery(r, "hasAwards")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		filter.AwardWinner, err = parseBoolQuery(r, "awardWinner")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		filter.MinRuntime, err = parseUintQuery(r, "minRuntime")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		filter.MaxRuntime, err = parseUintQuery(r, "maxRuntime")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		filter.Country = stringQuery(r, "country")
+		filter.Language = stringQuery(r, "language")
+		filter.AgeRating = stringQuery(r, "ageRating")
+		err = h.validate.Struct(filter)
+		if err != nil {
+			badRequest(w, problems.Validation(err))
+			return
+		}
+
+		sortBy := r.URL.Query().Get("sortBy")
+		_, err = schemas.ParseSort(sortBy, schemas.FilmSortFields)
+		if err != nil {
+			invalidParameter(w, "sortBy", err.Error())
+			return
+		}
+
+		options, err := parseListOptions(r, schemas.FilmFields, "actors", schemas.ActorFields)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
 		films, err := h.service.GetFilmsWithActors(
 			r.Context(),
 			r.URL.Query().Get("search"),
-			r.URL.Query().Get("sortBy"),
+			sortBy,
+			filter,
+			options,
 		)
 		if err != nil {
 			internalError(w)
 			return
 		}
 
-		err = writeJson(w, films, http.StatusOK)
+		if options.IsDefault() {
+			err = writeJson(w, films, http.StatusOK)
+		} else {
+			err = writeFields(w, films, options, "actors")
+		}
 		if err != nil {
 			internalError(w)
 			return
@@ -0,0 +1,430 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type FranchiseService interface {
+	AddFranchise(context.Context, schemas.AddFranchiseRequest) (models.Franchise, error)
+	PartialUpdateFranchise(context.Context, uint, schemas.PartialUpdateFranchiseRequest) error
+	RemoveFranchise(context.Context, uint) error
+	GetFranchises(context.Context) ([]schemas.FranchiseInfo, error)
+	GetFranchiseWithFilms(context.Context, uint) (schemas.FranchiseWithFilmsResponse, error)
+	SetFranchiseFilms(context.Context, uint, schemas.SetFranchiseFilmsRequest) error
+	AddRelation(context.Context, uint, schemas.AddFilmRelationRequest) (models.FilmRelation, error)
+	RemoveRelation(context.Context, uint, uint) error
+	GetRelatedFilms(context.Context, uint) ([]schemas.RelatedFilmInfo, error)
+}
+
+type FranchisesHandler struct {
+	service  FranchiseService
+	validate *validator.Validate
+}
+
+func NewFranchisesHandler(
+	service FranchiseService, validate *validator.Validate,
+) *FranchisesHandler {
+	return &FranchisesHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// GetList godoc
+//
+//	@Summary		List franchises
+//	@Description	Get list of franchises
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{array}		schemas.FranchiseInfo
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/franchises [get]
+func (h *FranchisesHandler) GetList() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		franchises, err := h.service.GetFranchises(r.Context())
+		if err != nil {
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, franchises, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Get godoc
+//
+//	@Summary		Get franchise
+//	@Description	Get franchise with its films in the franchise order
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Franchise id"
+//	@Success		200	{object}	schemas.FranchiseWithFilmsResponse
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/franchises/{id} [get]
+func (h *FranchisesHandler) Get() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		franchise, err := h.service.GetFranchiseWithFilms(r.Context(), uint(id))
+		if err != nil {
+			franchiseError(w, err)
+			return
+		}
+
+		err = writeJson(w, franchise, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Add godoc
+//
+//	@Summary		Add franchise
+//	@Description	Add franchise with the ordered films
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Param			franchise	body		schemas.AddFranchiseRequest	true	"New franchise"
+//	@Success		201			{object}	schemas.FranchiseInfo
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		409			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/franchises [post]
+func (h *FranchisesHandler) Add() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var schema schemas.AddFranchiseRequest
+		err := validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		franchise, err := h.service.AddFranchise(r.Context(), schema)
+		if err != nil {
+			franchiseError(w, err)
+			return
+		}
+
+		resp := schemas.NewFranchiseInfo(franchise)
+		err = writeJson(w, resp, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// PartialUpdate godoc
+//
+//	@Summary		Partial update franchise
+//	@Description	Partial update franchise name and description
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Param			franchise	body		schemas.PartialUpdateFranchiseRequest	true	"Update franchise"
+//	@Param			id			path		int										true	"Franchise id"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		409			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/franchises/{id} [patch]
+func (h *FranchisesHandler) PartialUpdate() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		var schema schemas.PartialUpdateFranchiseRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.PartialUpdateFranchise(r.Context(), uint(id), schema)
+		if err != nil {
+			franchiseError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Remove godoc
+//
+//	@Summary		Remove franchise
+//	@Description	Remove franchise, films are kept
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Franchise id"
+//	@Success		204	{object}	nil
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/franchises/{id} [delete]
+func (h *FranchisesHandler) Remove() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		err = h.service.RemoveFranchise(r.Context(), uint(id))
+		if err != nil {
+			franchiseError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// SetFilms godoc
+//
+//	@Summary		Set franchise films
+//	@Description	Replace films of the franchise, the order of ids is the franchise order
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Param			films	body		schemas.SetFranchiseFilmsRequest	true	"Ordered films"
+//	@Param			id		path		int									true	"Franchise id"
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		409		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/franchises/{id}/films [put]
+func (h *FranchisesHandler) SetFilms() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		var schema schemas.SetFranchiseFilmsRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.SetFranchiseFilms(r.Context(), uint(id), schema)
+		if err != nil {
+			franchiseError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// GetRelated godoc
+//
+//	@Summary		List related films
+//	@Description	Get sequels, prequels, remakes and spin-offs related to the film
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Film id"
+//	@Success		200	{array}		schemas.RelatedFilmInfo
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/films/{id}/related [get]
+func (h *FranchisesHandler) GetRelated() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		related, err := h.service.GetRelatedFilms(r.Context(), uint(id))
+		if err != nil {
+			franchiseError(w, err)
+			return
+		}
+
+		err = writeJson(w, related, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// AddRelation godoc
+//
+//	@Summary		Add film relation
+//	@Description	Add relation meaning the film is a sequel, prequel, remake or spin-off of the related film
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Param			relation	body		schemas.AddFilmRelationRequest	true	"New relation"
+//	@Param			id			path		int								true	"Film id"
+//	@Success		201			{object}	schemas.FilmRelationInfo
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		409			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/films/{id}/relations [post]
+func (h *FranchisesHandler) AddRelation() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		var schema schemas.AddFilmRelationRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		relation, err := h.service.AddRelation(r.Context(), uint(id), schema)
+		if err != nil {
+			franchiseError(w, err)
+			return
+		}
+
+		resp := schemas.NewFilmRelationInfo(relation)
+		err = writeJson(w, resp, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveRelation godoc
+//
+//	@Summary		Remove film relation
+//	@Description	Remove relation between films regardless of its direction
+//	@Security		BasicAuth
+//	@Tags			franchises
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int	true	"Film id"
+//	@Param			relatedId	path		int	true	"Related film id"
+//	@Success		204			{object}	nil
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/films/{id}/relations/{relatedId} [delete]
+func (h *FranchisesHandler) RemoveRelation() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		relatedIdParam := r.PathValue("relatedId")
+		relatedId, err := strconv.ParseUint(relatedIdParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "relatedId", "must be a non-negative integer")
+			return
+		}
+
+		err = h.service.RemoveRelation(r.Context(), uint(id), uint(relatedId))
+		if err != nil {
+			franchiseError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+func franchiseError(w http.ResponseWriter, err error) {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	switch {
+	case errors.As(err, &notFoundErr):
+		writeProblem(w, http.StatusNotFound, err.Error())
+	case errors.As(err, &existsErr):
+		writeProblem(w, http.StatusConflict, err.Error())
+	case errors.Is(err, postgresql.ErrRelationCycle):
+		writeProblem(w, http.StatusConflict, err.Error())
+	default:
+		internalError(w)
+	}
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/services/imports"
+)
+
+// MaxImportSize caps the size of the imported file.
+const MaxImportSize = 32 << 20
+
+// ImportTimeout replaces the server read and write timeouts for the import,
+// the file is read and stored while the request is served.
+const ImportTimeout = 10 * time.Minute
+
+type ImportService interface {
+	Import(context.Context, schemas.ImportRequest, io.Reader) (schemas.ImportReport, error)
+}
+
+type ImportHandler struct {
+	service ImportService
+}
+
+func NewImportHandler(service ImportService) *ImportHandler {
+	return &ImportHandler{
+		service: service,
+	}
+}
+
+// Import godoc
+//
+//	@Summary		Import catalogue
+//	@Description	Import actors, films or cast links from CSV with the header row or NDJSON.
+//	@Description	Columns and keys are named as the fields of the row, CSV lists are separated by ";".
+//	@Description	Cast links reference films by title and release date and actors by name and birthday.
+//	@Security		BasicAuth
+//	@Tags			import
+//	@Accept			text/csv,application/x-ndjson
+//	@Produce		json
+//	@Param			kind		query		string	true	"rows kind"	Enums(actors, films, cast)
+//	@Param			mode		query		string	false	"atomic imports all rows or none, chunked commits chunks without failed rows"	Enums(atomic, chunked)
+//	@Param			chunkSize	query		int		false	"rows in the chunk, 100 by default, at most 1000"
+//	@Param			dryRun		query		bool	false	"validate rows without importing them"
+//	@Success		200			{object}	schemas.ImportReport
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		413			{object}	schemas.Problem
+//	@Failure		415			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/import [post]
+func (h *ImportHandler) Import() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format, err := importFormat(r)
+		if err != nil {
+			writeProblem(w, http.StatusUnsupportedMediaType, err.Error())
+			return
+		}
+
+		chunkSize, err := parseUintQuery(r, "chunkSize")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		dryRun, err := parseBoolQuery(r, "dryRun")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		request := schemas.ImportRequest{
+			Kind:   schemas.ImportKind(r.URL.Query().Get("kind")),
+			Format: format,
+			Mode:   schemas.ImportMode(r.URL.Query().Get("mode")),
+			DryRun: dryRun != nil && *dryRun,
+		}
+		if chunkSize != nil {
+			request.ChunkSize = *chunkSize
+		}
+
+		body := http.MaxBytesReader(w, r.Body, MaxImportSize)
+		report, err := h.service.Import(r.Context(), request, body)
+		if err != nil {
+			importError(w, err)
+			return
+		}
+
+		err = writeJson(w, report, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// importFormat returns the format of the file by the content type.
+func importFormat(r *http.Request) (schemas.ImportFormat, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", imports.ErrUnknownFormat
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return schemas.ImportCSV, nil
+	case "application/x-ndjson", "application/jsonl":
+		return schemas.ImportNDJSON, nil
+	default:
+		return "", imports.ErrUnknownFormat
+	}
+}
+
+func importError(w http.ResponseWriter, err error) {
+	var tooLargeErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLargeErr):
+		writeProblem(w, http.StatusRequestEntityTooLarge, "import file is too large")
+	case errors.Is(err, imports.ErrUnknownKind),
+		errors.Is(err, imports.ErrUnknownMode),
+		errors.Is(err, imports.ErrInvalidFile):
+		badRequest(w, err)
+	default:
+		internalError(w)
+	}
+}
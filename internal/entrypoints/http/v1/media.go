@@ -0,0 +1,201 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/media"
+)
+
+// multipartOverhead is allowed in addition to the image size limit
+// for multipart headers and boundaries.
+const multipartOverhead = 1 << 20
+
+type MediaService interface {
+	UploadFilmPoster(context.Context, uint, io.Reader) ([]models.Image, error)
+	UploadActorHeadshot(context.Context, uint, io.Reader) ([]models.Image, error)
+	RemoveFilmPoster(context.Context, uint) error
+	RemoveActorHeadshot(context.Context, uint) error
+}
+
+type MediaHandler struct {
+	service MediaService
+}
+
+func NewMediaHandler(service MediaService) *MediaHandler {
+	return &MediaHandler{
+		service: service,
+	}
+}
+
+// UploadFilmPoster godoc
+//
+//	@Summary		Upload film poster
+//	@Description	Upload jpeg, png or gif film poster. Thumbnails are generated in several widths
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			id		path		int		true	"Film id"
+//	@Param			file	formData	file	true	"Poster image"
+//	@Success		201		{object}	schemas.ImageInfo
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		413		{object}	schemas.Problem
+//	@Failure		415		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/films/{id}/poster [post]
+func (h *MediaHandler) UploadFilmPoster() http.Handler {
+	return h.upload(h.service.UploadFilmPoster, "film not found")
+}
+
+// RemoveFilmPoster godoc
+//
+//	@Summary		Remove film poster
+//	@Description	Remove film poster with its thumbnails
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Film id"
+//	@Success		204	{object}	nil
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/films/{id}/poster [delete]
+func (h *MediaHandler) RemoveFilmPoster() http.Handler {
+	return h.remove(h.service.RemoveFilmPoster, "poster not found")
+}
+
+// UploadActorHeadshot godoc
+//
+//	@Summary		Upload actor headshot
+//	@Description	Upload jpeg, png or gif actor headshot. Thumbnails are generated in several widths
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			id		path		int		true	"Actor id"
+//	@Param			file	formData	file	true	"Headshot image"
+//	@Success		201		{object}	schemas.ImageInfo
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		413		{object}	schemas.Problem
+//	@Failure		415		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/headshot [post]
+func (h *MediaHandler) UploadActorHeadshot() http.Handler {
+	return h.upload(h.service.UploadActorHeadshot, "actor not found")
+}
+
+// RemoveActorHeadshot godoc
+//
+//	@Summary		Remove actor headshot
+//	@Description	Remove actor headshot with its thumbnails
+//	@Security		BasicAuth
+//	@Tags			actors
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Actor id"
+//	@Success		204	{object}	nil
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/headshot [delete]
+func (h *MediaHandler) RemoveActorHeadshot() http.Handler {
+	return h.remove(h.service.RemoveActorHeadshot, "headshot not found")
+}
+
+func (h *MediaHandler) upload(
+	upload func(context.Context, uint, io.Reader) ([]models.Image, error),
+	notFoundMsg string,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		r.Body = http.MaxBytesReader(
+			w, r.Body, media.MaxImageSize+multipartOverhead,
+		)
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				writeProblem(w, http.StatusRequestEntityTooLarge, media.ErrTooLarge.Error())
+				return
+			}
+			invalidParameter(w, "file", "is required")
+			return
+		}
+		defer file.Close()
+
+		images, err := upload(r.Context(), uint(id), file)
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			switch {
+			case errors.As(err, &notFoundErr):
+				writeProblem(w, http.StatusNotFound, notFoundMsg)
+			case errors.Is(err, media.ErrTooLarge):
+				writeProblem(w, http.StatusRequestEntityTooLarge, err.Error())
+			case errors.Is(err, media.ErrUnsupportedType):
+				writeProblem(w, http.StatusUnsupportedMediaType, err.Error())
+			default:
+				internalError(w)
+			}
+			return
+		}
+
+		resp := schemas.NewImageInfo(images)
+		err = writeJson(w, resp, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+func (h *MediaHandler) remove(
+	remove func(context.Context, uint) error, notFoundMsg string,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		err = remove(r.Context(), uint(id))
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			if errors.As(err, &notFoundErr) {
+				writeProblem(w, http.StatusNotFound, notFoundMsg)
+				return
+			}
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
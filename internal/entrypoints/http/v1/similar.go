@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/services/similar"
+)
+
+type SimilarService interface {
+	GetSimilarFilms(context.Context, uint, *similar.Weights, *uint) ([]schemas.SimilarFilmInfo, error)
+}
+
+type SimilarHandler struct {
+	service SimilarService
+}
+
+func NewSimilarHandler(service SimilarService) *SimilarHandler {
+	return &SimilarHandler{
+		service: service,
+	}
+}
+
+// GetSimilar godoc
+//
+//	@Summary		List similar films
+//	@Description	Get films similar to the film scored by shared cast, release year and rating
+//	@Security		BasicAuth
+//	@Tags			films
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int		true	"Film id"
+//	@Param			limit			query		int		false	"maximal number of films, 10 by default, at most 50"
+//	@Param			castWeight		query		number	false	"weight of shared cast, 0.6 by default"
+//	@Param			yearWeight		query		number	false	"weight of release year proximity, 0.25 by default"
+//	@Param			ratingWeight	query		number	false	"weight of rating, 0.15 by default"
+//	@Success		200				{array}		schemas.SimilarFilmInfo
+//	@Failure		400				{object}	schemas.Problem
+//	@Failure		401				{object}	schemas.Problem
+//	@Failure		404				{object}	schemas.Problem
+//	@Failure		500				{object}	schemas.Problem
+//	@Router			/v1/films/{id}/similar [get]
+func (h *SimilarHandler) GetSimilar() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		limit, err := parseUintQuery(r, "limit")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		weights, err := parseWeights(r)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		films, err := h.service.GetSimilarFilms(r.Context(), uint(id), weights, limit)
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			switch {
+			case errors.As(err, &notFoundErr):
+				writeProblem(w, http.StatusNotFound, err.Error())
+			case errors.Is(err, similar.ErrInvalidWeights):
+				badRequest(w, err)
+			default:
+				internalError(w)
+			}
+			return
+		}
+
+		err = writeJson(w, films, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// parseWeights returns the default weights overridden by the weights
+// set in the query. Returns nil if no weight is set.
+func parseWeights(r *http.Request) (*similar.Weights, error) {
+	weights := similar.DefaultWeights
+	params := map[string]*float64{
+		"castWeight":   &weights.Cast,
+		"yearWeight":   &weights.Year,
+		"ratingWeight": &weights.Rating,
+	}
+
+	set := false
+	for name, weight := range params {
+		value, err := parseFloatQuery(r, name)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			*weight = *value
+			set = true
+		}
+	}
+
+	if !set {
+		return nil, nil
+	}
+
+	return &weights, nil
+}
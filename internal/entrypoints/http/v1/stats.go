@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+type StatsService interface {
+	GetStats(context.Context, *uint) (schemas.StatsResponse, error)
+}
+
+type StatsHandler struct {
+	service StatsService
+}
+
+func NewStatsHandler(service StatsService) *StatsHandler {
+	return &StatsHandler{
+		service: service,
+	}
+}
+
+// Get godoc
+//
+//	@Summary		Get statistics
+//	@Description	Get catalogue statistics, refreshed periodically
+//	@Security		BasicAuth
+//	@Tags			stats
+//	@Accept			json
+//	@Produce		json
+//	@Param			limit	query		int	false	"maximal number of the most prolific actors, 10 by default, at most 100"
+//	@Success		200		{object}	schemas.StatsResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/stats [get]
+func (h *StatsHandler) Get() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, err := parseUintQuery(r, "limit")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		stats, err := h.service.GetStats(r.Context(), limit)
+		if err != nil {
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, stats, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
@@ -0,0 +1,294 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/translations"
+)
+
+type TranslationService interface {
+	SetFilmTranslation(context.Context, uint, string, schemas.SetFilmTranslationRequest) (models.FilmTranslation, error)
+	RemoveFilmTranslation(context.Context, uint, string) error
+	GetFilmTranslations(context.Context, uint) ([]schemas.FilmTranslationInfo, error)
+	SetActorTranslation(context.Context, uint, string, schemas.SetActorTranslationRequest) (models.ActorTranslation, error)
+	RemoveActorTranslation(context.Context, uint, string) error
+	GetActorTranslations(context.Context, uint) ([]schemas.ActorTranslationInfo, error)
+}
+
+type TranslationsHandler struct {
+	service  TranslationService
+	validate *validator.Validate
+}
+
+func NewTranslationsHandler(
+	service TranslationService, validate *validator.Validate,
+) *TranslationsHandler {
+	return &TranslationsHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// GetFilmTranslations godoc
+//
+//	@Summary		List film translations
+//	@Description	Get all translations of the film
+//	@Security		BasicAuth
+//	@Tags			translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Film id"
+//	@Success		200	{array}		schemas.FilmTranslationInfo
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/films/{id}/translations [get]
+func (h *TranslationsHandler) GetFilmTranslations() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		translations, err := h.service.GetFilmTranslations(r.Context(), uint(id))
+		if err != nil {
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, translations, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// SetFilmTranslation godoc
+//
+//	@Summary		Set film translation
+//	@Description	Create or replace translation of the film title and description
+//	@Security		BasicAuth
+//	@Tags			translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			translation	body		schemas.SetFilmTranslationRequest	true	"Film translation"
+//	@Param			id			path		int									true	"Film id"
+//	@Param			locale		path		string								true	"Locale"	example(en-us)
+//	@Success		200			{object}	schemas.FilmTranslationInfo
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/films/{id}/translations/{locale} [put]
+func (h *TranslationsHandler) SetFilmTranslation() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		var schema schemas.SetFilmTranslationRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		translation, err := h.service.SetFilmTranslation(
+			r.Context(), uint(id), r.PathValue("locale"), schema,
+		)
+		if err != nil {
+			translationError(w, err)
+			return
+		}
+
+		resp := schemas.NewFilmTranslationInfo(translation)
+		err = writeJson(w, resp, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveFilmTranslation godoc
+//
+//	@Summary		Remove film translation
+//	@Description	Remove translation of the film for the locale
+//	@Security		BasicAuth
+//	@Tags			translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"Film id"
+//	@Param			locale	path		string	true	"Locale"	example(en-us)
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/films/{id}/translations/{locale} [delete]
+func (h *TranslationsHandler) RemoveFilmTranslation() http.Handler {
+	return h.remove(func(ctx context.Context, id uint, locale string) error {
+		return h.service.RemoveFilmTranslation(ctx, id, locale)
+	})
+}
+
+// GetActorTranslations godoc
+//
+//	@Summary		List actor translations
+//	@Description	Get all translations of the actor
+//	@Security		BasicAuth
+//	@Tags			translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"Actor id"
+//	@Success		200	{array}		schemas.ActorTranslationInfo
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/translations [get]
+func (h *TranslationsHandler) GetActorTranslations() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		translations, err := h.service.GetActorTranslations(r.Context(), uint(id))
+		if err != nil {
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, translations, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// SetActorTranslation godoc
+//
+//	@Summary		Set actor translation
+//	@Description	Create or replace translation of the actor name
+//	@Security		BasicAuth
+//	@Tags			translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			translation	body		schemas.SetActorTranslationRequest	true	"Actor translation"
+//	@Param			id			path		int									true	"Actor id"
+//	@Param			locale		path		string								true	"Locale"	example(en-us)
+//	@Success		200			{object}	schemas.ActorTranslationInfo
+//	@Failure		400			{object}	schemas.Problem
+//	@Failure		401			{object}	schemas.Problem
+//	@Failure		403			{object}	schemas.Problem
+//	@Failure		404			{object}	schemas.Problem
+//	@Failure		500			{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/translations/{locale} [put]
+func (h *TranslationsHandler) SetActorTranslation() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		var schema schemas.SetActorTranslationRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		translation, err := h.service.SetActorTranslation(
+			r.Context(), uint(id), r.PathValue("locale"), schema,
+		)
+		if err != nil {
+			translationError(w, err)
+			return
+		}
+
+		resp := schemas.NewActorTranslationInfo(translation)
+		err = writeJson(w, resp, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// RemoveActorTranslation godoc
+//
+//	@Summary		Remove actor translation
+//	@Description	Remove translation of the actor for the locale
+//	@Security		BasicAuth
+//	@Tags			translations
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int		true	"Actor id"
+//	@Param			locale	path		string	true	"Locale"	example(en-us)
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/actors/{id}/translations/{locale} [delete]
+func (h *TranslationsHandler) RemoveActorTranslation() http.Handler {
+	return h.remove(func(ctx context.Context, id uint, locale string) error {
+		return h.service.RemoveActorTranslation(ctx, id, locale)
+	})
+}
+
+func (h *TranslationsHandler) remove(
+	remove func(context.Context, uint, string) error,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idParam := r.PathValue("id")
+		id, err := strconv.ParseUint(idParam, 10, 64)
+		if err != nil {
+			invalidParameter(w, "id", "must be a non-negative integer")
+			return
+		}
+
+		err = remove(r.Context(), uint(id), r.PathValue("locale"))
+		if err != nil {
+			translationError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func translationError(w http.ResponseWriter, err error) {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	switch {
+	case errors.Is(err, translations.ErrInvalidLocale):
+		invalidParameter(w, "locale", "must be a supported locale")
+	case errors.As(err, &notFoundErr):
+		writeProblem(w, http.StatusNotFound, err.Error())
+	default:
+		internalError(w)
+	}
+}
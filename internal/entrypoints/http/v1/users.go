@@ -34,16 +34,15 @@ func NewUsersHandler(service UserService, validate *validator.Validate) *UsersHa
 //	@Produce		json
 //	@Param			user	body		schemas.CreateUserRequest	true "New user"
 //	@Success		201		{object}	models.User
-//	@Failure		400		{object}	schemas.ErrorResponse
-//	@Failure		500		{object}	schemas.ErrorResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
 //	@Router			/v1/users [post]
 func (h *UsersHandler) Create() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var schema schemas.CreateUserRequest
 		err := validateRequestBody(r, h.validate, &schema)
 		if err != nil {
-			resp := schemas.ErrorResponse{Error: err.Error()}
-			_ = writeJson(w, resp, http.StatusBadRequest)
+			badRequest(w, err)
 			return
 		}
 
@@ -2,26 +2,29 @@ package v1
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/go-playground/validator"
-	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	mw "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/middlewares"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
+	"github.com/sivistrukov/vk-assigment/internal/models"
 )
 
 // validateRequestBody checks if the request body is valid according to
-// the given schema. If the request body is not valid, it returns an error.
+// the given schema. If the request body is not valid, it returns
+// the error carrying the problem details.
 func validateRequestBody(r *http.Request, validate *validator.Validate, schema interface{}) error {
 	err := parseRequestBody(r, schema)
 	if err != nil {
-		return errors.New("invalid request body")
+		return problems.MalformedBody(err)
 	}
 
 	err = validate.Struct(schema)
 	if err != nil {
-		return fmt.Errorf("invalid request body: %v", err)
+		return problems.Validation(err)
 	}
 
 	return nil
@@ -43,13 +46,75 @@ func parseRequestBody(r *http.Request, schema any) error {
 	return nil
 }
 
-// internalError is alias for writeJson.
+// parseBoolQuery parses the optional boolean query parameter.
+// Returns nil if the parameter is not set.
+func parseBoolQuery(r *http.Request, name string) (*bool, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		return nil, problems.InvalidParameter(name, "must be a boolean")
+	}
+
+	return &value, nil
+}
+
+// parseUintQuery parses the optional unsigned integer query parameter.
+// Returns nil if the parameter is not set.
+func parseUintQuery(r *http.Request, name string) (*uint, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return nil, problems.InvalidParameter(name, "must be a non-negative integer")
+	}
+
+	result := uint(value)
+	return &result, nil
+}
+
+// stringQuery returns the optional string query parameter.
+// Returns nil if the parameter is not set.
+func stringQuery(r *http.Request, name string) *string {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil
+	}
+
+	return &param
+}
+
+// currentUser returns the user authenticated by the BasicAuth middleware.
+func currentUser(r *http.Request) models.User {
+	user, _ := r.Context().Value(mw.Key("user")).(models.User)
+	return user
+}
+
+// internalError writes the problem hiding the cause of the error.
 func internalError(w http.ResponseWriter) {
-	_ = writeJson(
-		w,
-		schemas.ErrorResponse{Error: "internal server error"},
-		http.StatusInternalServerError,
-	)
+	problems.Write(w, problems.New(http.StatusInternalServerError, ""))
+}
+
+// writeProblem writes the problem with the status described by detail.
+func writeProblem(w http.ResponseWriter, status int, detail string) {
+	problems.Write(w, problems.New(status, detail))
+}
+
+// badRequest writes the problem carried by the error, other errors are
+// written as the bad request described by the error text.
+func badRequest(w http.ResponseWriter, err error) {
+	problems.Write(w, problems.FromError(err, http.StatusBadRequest))
+}
+
+// invalidParameter writes the problem of the query, path or header parameter.
+func invalidParameter(w http.ResponseWriter, name, message string) {
+	badRequest(w, problems.InvalidParameter(name, message))
 }
 
 // writeJson writes the given data as JSON to the response writer
@@ -70,3 +135,29 @@ func writeJson(w http.ResponseWriter, data interface{}, status int) error {
 	}
 	return nil
 }
+
+// parseFloatQuery returns the optional float query parameter.
+// Returns nil if the parameter is not set.
+func parseFloatQuery(r *http.Request, name string) (*float64, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return nil, problems.InvalidParameter(name, "must be a number")
+	}
+
+	return &value, nil
+}
+
+// parseUintPath parses the unsigned integer path parameter.
+func parseUintPath(r *http.Request, name string) (uint, error) {
+	value, err := strconv.ParseUint(r.PathValue(name), 10, 64)
+	if err != nil {
+		return 0, problems.InvalidParameter(name, "must be a non-negative integer")
+	}
+
+	return uint(value), nil
+}
@@ -0,0 +1,237 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+)
+
+type WebhookService interface {
+	CreateWebhook(context.Context, schemas.WebhookRequest) (schemas.WebhookResponse, error)
+	UpdateWebhook(context.Context, uint, schemas.WebhookRequest) (schemas.WebhookResponse, error)
+	RemoveWebhook(context.Context, uint) error
+	GetWebhooks(context.Context) ([]schemas.WebhookResponse, error)
+	GetDeliveries(context.Context, uint, *uint) ([]schemas.WebhookDelivery, error)
+}
+
+type WebhooksHandler struct {
+	service  WebhookService
+	validate *validator.Validate
+}
+
+func NewWebhooksHandler(
+	service WebhookService, validate *validator.Validate,
+) *WebhooksHandler {
+	return &WebhooksHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// GetList godoc
+//
+//	@Summary		List webhooks
+//	@Description	Get list of webhook subscriptions, secrets are not returned
+//	@Security		BasicAuth
+//	@Tags			webhooks
+//	@Produce		json
+//	@Success		200	{array}		schemas.WebhookResponse
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/webhooks [get]
+func (h *WebhooksHandler) GetList() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhooks, err := h.service.GetWebhooks(r.Context())
+		if err != nil {
+			internalError(w)
+			return
+		}
+
+		err = writeJson(w, webhooks, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Add godoc
+//
+//	@Summary		Add webhook
+//	@Description	Subscribe the url to the film changes. The calls are signed with the secret,
+//	@Description	see the X-Webhook-Signature header.
+//	@Security		BasicAuth
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			webhook	body		schemas.WebhookRequest	true	"New webhook"
+//	@Success		201		{object}	schemas.WebhookResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/webhooks [post]
+func (h *WebhooksHandler) Add() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var schema schemas.WebhookRequest
+		err := validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		webhook, err := h.service.CreateWebhook(r.Context(), schema)
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		err = writeJson(w, webhook, http.StatusCreated)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Update godoc
+//
+//	@Summary		Update webhook
+//	@Description	Replace the url, event types and secret of the webhook
+//	@Security		BasicAuth
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Webhook id"
+//	@Param			webhook	body		schemas.WebhookRequest	true	"Webhook"
+//	@Success		200		{object}	schemas.WebhookResponse
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/webhooks/{id} [put]
+func (h *WebhooksHandler) Update() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		var schema schemas.WebhookRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		webhook, err := h.service.UpdateWebhook(r.Context(), id, schema)
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		err = writeJson(w, webhook, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// Remove godoc
+//
+//	@Summary		Remove webhook
+//	@Description	Remove webhook with its delivery log
+//	@Security		BasicAuth
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id	path	int	true	"Webhook id"
+//	@Success		204
+//	@Failure		400	{object}	schemas.Problem
+//	@Failure		401	{object}	schemas.Problem
+//	@Failure		403	{object}	schemas.Problem
+//	@Failure		404	{object}	schemas.Problem
+//	@Failure		500	{object}	schemas.Problem
+//	@Router			/v1/webhooks/{id} [delete]
+func (h *WebhooksHandler) Remove() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		err = h.service.RemoveWebhook(r.Context(), id)
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		err = writeJson(w, nil, http.StatusNoContent)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+// GetDeliveries godoc
+//
+//	@Summary		List webhook deliveries
+//	@Description	Get the latest deliveries of the webhook with the results of their last attempts
+//	@Security		BasicAuth
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id		path		int	true	"Webhook id"
+//	@Param			limit	query		int	false	"number of deliveries, 50 by default, at most 200"
+//	@Success		200		{array}		schemas.WebhookDelivery
+//	@Failure		400		{object}	schemas.Problem
+//	@Failure		401		{object}	schemas.Problem
+//	@Failure		403		{object}	schemas.Problem
+//	@Failure		404		{object}	schemas.Problem
+//	@Failure		500		{object}	schemas.Problem
+//	@Router			/v1/webhooks/{id}/deliveries [get]
+func (h *WebhooksHandler) GetDeliveries() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		limit, err := parseUintQuery(r, "limit")
+		if err != nil {
+			badRequest(w, err)
+			return
+		}
+
+		deliveries, err := h.service.GetDeliveries(r.Context(), id, limit)
+		if err != nil {
+			webhookError(w, err)
+			return
+		}
+
+		err = writeJson(w, deliveries, http.StatusOK)
+		if err != nil {
+			internalError(w)
+			return
+		}
+	})
+}
+
+func webhookError(w http.ResponseWriter, err error) {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	if errors.As(err, &notFoundErr) {
+		writeProblem(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	internalError(w)
+}
@@ -0,0 +1,273 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+)
+
+type ActorService interface {
+	AddActor(context.Context, schemas.AddActorRequest, bool) (models.Actor, error)
+	UpdateActor(context.Context, uint, schemas.UpdateActorRequest) error
+	PartialUpdateActor(context.Context, uint, schemas.PartialUpdateActorRequest) error
+	RemoveActor(context.Context, uint) error
+	GetActorsWithFilms(context.Context, schemas.ListOptions) ([]schemas.ActorWithFilmsResponse, error)
+	GetActorWithFilms(context.Context, uint) (schemas.ActorWithFilmsResponse, error)
+}
+
+type ActorsHandler struct {
+	service  ActorService
+	validate *validator.Validate
+}
+
+func NewActorsHandler(service ActorService, validate *validator.Validate) *ActorsHandler {
+	return &ActorsHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// Add godoc
+//
+//	@Summary		Add actor
+//	@Description	Add actor to database
+//	@Security		BasicAuth
+//	@Tags			actors v2
+//	@Accept			json
+//	@Produce		json
+//	@Param			actor	body		v2.AddActorRequest	true	"New actor"
+//	@Param			force	query		bool				false	"create the actor even if it duplicates an existing one"
+//	@Success		201		{object}	v2.Envelope{data=v2.Actor}
+//	@Header			201		{string}	Location	"URI of the created actor"
+//	@Failure		400		{object}	v2.Envelope
+//	@Failure		401		{object}	v2.Envelope
+//	@Failure		403		{object}	v2.Envelope
+//	@Failure		409		{object}	v2.Envelope
+//	@Failure		500		{object}	v2.Envelope
+//	@Router			/v2/actors [post]
+func (h *ActorsHandler) Add() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		force, err := parseBoolQuery(r, "force")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var schema AddActorRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		actor, err := h.service.AddActor(r.Context(), schema.toSchema(), force != nil && *force)
+		if err != nil {
+			var duplicateErr *duplicates.ErrDuplicate
+			if errors.As(err, &duplicateErr) {
+				duplicateError(w, r, duplicateErr)
+				return
+			}
+			internalError(w, r)
+			return
+		}
+
+		location := fmt.Sprintf("/api/v2/actors/%d", actor.ID)
+		writeCreated(w, newActor(actor), location)
+	})
+}
+
+// Update godoc
+//
+//	@Summary		Update actor
+//	@Description	Update actor
+//	@Security		BasicAuth
+//	@Tags			actors v2
+//	@Accept			json
+//	@Produce		json
+//	@Param			actor	body	v2.UpdateActorRequest	true	"Update actor"
+//	@Param			id		path	int						true	"Actor id"
+//	@Success		204
+//	@Failure		400	{object}	v2.Envelope
+//	@Failure		401	{object}	v2.Envelope
+//	@Failure		403	{object}	v2.Envelope
+//	@Failure		404	{object}	v2.Envelope
+//	@Failure		500	{object}	v2.Envelope
+//	@Router			/v2/actors/{id} [put]
+func (h *ActorsHandler) Update() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var schema UpdateActorRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = h.service.UpdateActor(r.Context(), id, schema.toSchema())
+		if err != nil {
+			actorError(w, r, err)
+			return
+		}
+
+		noContent(w)
+	})
+}
+
+// PartialUpdate godoc
+//
+//	@Summary		Partial update actor
+//	@Description	Partial update actor
+//	@Security		BasicAuth
+//	@Tags			actors v2
+//	@Accept			json
+//	@Produce		json
+//	@Param			actor	body	v2.PartialUpdateActorRequest	true	"Update actor"
+//	@Param			id		path	int								true	"Actor id"
+//	@Success		204
+//	@Failure		400	{object}	v2.Envelope
+//	@Failure		401	{object}	v2.Envelope
+//	@Failure		403	{object}	v2.Envelope
+//	@Failure		404	{object}	v2.Envelope
+//	@Failure		500	{object}	v2.Envelope
+//	@Router			/v2/actors/{id} [patch]
+func (h *ActorsHandler) PartialUpdate() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var schema PartialUpdateActorRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = h.service.PartialUpdateActor(r.Context(), id, schema.toSchema())
+		if err != nil {
+			actorError(w, r, err)
+			return
+		}
+
+		noContent(w)
+	})
+}
+
+// Remove godoc
+//
+//	@Summary		Remove actor
+//	@Description	Remove actor from database
+//	@Security		BasicAuth
+//	@Tags			actors v2
+//	@Produce		json
+//	@Param			id	path	int	true	"Actor id"
+//	@Success		204
+//	@Failure		400	{object}	v2.Envelope
+//	@Failure		401	{object}	v2.Envelope
+//	@Failure		403	{object}	v2.Envelope
+//	@Failure		404	{object}	v2.Envelope
+//	@Failure		500	{object}	v2.Envelope
+//	@Router			/v2/actors/{id} [delete]
+func (h *ActorsHandler) Remove() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = h.service.RemoveActor(r.Context(), id)
+		if err != nil {
+			actorError(w, r, err)
+			return
+		}
+
+		noContent(w)
+	})
+}
+
+// GetList godoc
+//
+//	@Summary		List actors
+//	@Description	Get list of actors with their films
+//	@Security		BasicAuth
+//	@Tags			actors v2
+//	@Produce		json
+//	@Param			Accept-Language		header		string	false	"preferred languages of names"
+//	@Param			lang				query		string	false	"language of names, overrides Accept-Language"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{object}	v2.Envelope{data=[]v2.ActorWithFilms}
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		401					{object}	v2.Envelope
+//	@Failure		500					{object}	v2.Envelope
+//	@Router			/v2/actors [get]
+func (h *ActorsHandler) GetList() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actors, err := h.service.GetActorsWithFilms(r.Context(), schemas.DefaultListOptions)
+		if err != nil {
+			internalError(w, r)
+			return
+		}
+
+		writeList(w, r, mapList(actors, newActorWithFilms))
+	})
+}
+
+// Get godoc
+//
+//	@Summary		Get actor
+//	@Description	Get actor with their films, the created actor is located here
+//	@Security		BasicAuth
+//	@Tags			actors v2
+//	@Produce		json
+//	@Param			id					path		int		true	"Actor id"
+//	@Param			Accept-Language		header		string	false	"preferred languages of names"
+//	@Param			lang				query		string	false	"language of names, overrides Accept-Language"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{object}	v2.Envelope{data=v2.ActorWithFilms}
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		400					{object}	v2.Envelope
+//	@Failure		401					{object}	v2.Envelope
+//	@Failure		404					{object}	v2.Envelope
+//	@Failure		500					{object}	v2.Envelope
+//	@Router			/v2/actors/{id} [get]
+func (h *ActorsHandler) Get() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		actor, err := h.service.GetActorWithFilms(r.Context(), id)
+		if err != nil {
+			actorError(w, r, err)
+			return
+		}
+
+		writeData(w, newActorWithFilms(actor), r.URL.RequestURI(), http.StatusOK)
+	})
+}
+
+func actorError(w http.ResponseWriter, r *http.Request, err error) {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	if errors.As(err, &notFoundErr) {
+		writeError(w, r, "actor not found", http.StatusNotFound)
+		return
+	}
+	internalError(w, r)
+}
@@ -0,0 +1,166 @@
+package v2
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// Envelope wraps every response body of the API v2. Data is null
+// when the request failed, Error is omitted when it succeeded.
+type Envelope struct {
+	Data  any    `json:"data"`
+	Meta  Meta   `json:"meta"`
+	Links Links  `json:"links"`
+	Error *Error `json:"error,omitempty"`
+}
+
+type Meta struct {
+	// Count is the number of items of the list.
+	Count *int `json:"count,omitempty"`
+}
+
+type Links struct {
+	Self string `json:"self"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+	// ExistingID is the id of the record duplicated by the created one.
+	ExistingID *uint `json:"existingId,omitempty"`
+}
+
+// writeData writes the single resource located at the self link.
+func writeData(w http.ResponseWriter, data any, self string, status int) {
+	_ = writeEnvelope(w, Envelope{Data: data, Links: Links{Self: self}}, status)
+}
+
+// writeList writes the list of resources, the request URI is its self link.
+func writeList[T any](w http.ResponseWriter, r *http.Request, items []T) {
+	if items == nil {
+		items = make([]T, 0)
+	}
+
+	count := len(items)
+	resp := Envelope{
+		Data:  items,
+		Meta:  Meta{Count: &count},
+		Links: Links{Self: r.URL.RequestURI()},
+	}
+	_ = writeEnvelope(w, resp, http.StatusOK)
+}
+
+// writeCreated writes the created resource and points the Location
+// header to it.
+func writeCreated(w http.ResponseWriter, data any, location string) {
+	w.Header().Set("Location", location)
+	writeData(w, data, location, http.StatusCreated)
+}
+
+// writeError writes the error of the request.
+func writeError(w http.ResponseWriter, r *http.Request, message string, status int) {
+	resp := Envelope{
+		Links: Links{Self: r.URL.RequestURI()},
+		Error: &Error{Message: message},
+	}
+	_ = writeEnvelope(w, resp, status)
+}
+
+func internalError(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, "internal server error", http.StatusInternalServerError)
+}
+
+// noContent answers without a body, unlike v1 which writes null.
+func noContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeEnvelope(w http.ResponseWriter, resp Envelope, status int) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(data)
+	if err != nil {
+		return fmt.Errorf("failed to write response body: %w", err)
+	}
+	return nil
+}
+
+// EnvelopeErrors wraps the errors written outside of the handlers of v2,
+// e.g. the problems of the shared middlewares and the router, into the
+// envelope. The responses written by the handlers are left as they are.
+func EnvelopeErrors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ew := &errorWriter{ResponseWriter: w}
+		next.ServeHTTP(ew, r)
+
+		if ew.status != 0 {
+			writeError(w, r, ew.message(), ew.status)
+		}
+	})
+}
+
+// errorWriter holds back the error responses which are not enveloped.
+type errorWriter struct {
+	http.ResponseWriter
+	// status is set when the error response is held back
+	status  int
+	body    bytes.Buffer
+	written bool
+}
+
+func (w *errorWriter) WriteHeader(status int) {
+	if !w.written && status >= http.StatusBadRequest &&
+		w.Header().Get("Content-Type") != "application/json" {
+		w.status = status
+		w.written = true
+		return
+	}
+
+	w.written = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *errorWriter) Write(data []byte) (int, error) {
+	if !w.written {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.status != 0 {
+		return w.body.Write(data)
+	}
+
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *errorWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// message returns the detail of the problem or the text of the plain
+// error written by the router.
+func (w *errorWriter) message() string {
+	var problem schemas.Problem
+	if err := json.Unmarshal(w.body.Bytes(), &problem); err == nil {
+		if problem.Detail != "" {
+			return problem.Detail
+		}
+		if problem.Title != "" {
+			return problem.Title
+		}
+	}
+
+	if text := strings.TrimSpace(w.body.String()); text != "" {
+		return text
+	}
+
+	return http.StatusText(w.status)
+}
@@ -0,0 +1,98 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
+)
+
+func TestEnvelopeErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/films", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="restricted"`)
+		problems.Write(w, problems.New(http.StatusUnauthorized, "valid basic credentials are required"))
+	})
+	mux.HandleFunc("DELETE /api/v2/films/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, r, "film not found", http.StatusNotFound)
+	})
+	mux.HandleFunc("PUT /api/v2/films/{id}", func(w http.ResponseWriter, r *http.Request) {
+		noContent(w)
+	})
+	h := EnvelopeErrors(mux)
+
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		status  int
+		message string
+	}{
+		{
+			name:    "problem of the middleware",
+			method:  http.MethodGet,
+			target:  "/api/v2/films",
+			status:  http.StatusUnauthorized,
+			message: "valid basic credentials are required",
+		},
+		{
+			name:    "method not allowed by the router",
+			method:  http.MethodPost,
+			target:  "/api/v2/films/7",
+			status:  http.StatusMethodNotAllowed,
+			message: "Method Not Allowed",
+		},
+		{
+			name:    "envelope of the handler",
+			method:  http.MethodDelete,
+			target:  "/api/v2/films/7",
+			status:  http.StatusNotFound,
+			message: "film not found",
+		},
+		{
+			name:   "success",
+			method: http.MethodPut,
+			target: "/api/v2/films/7",
+			status: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(tt.method, tt.target, nil))
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.message == "" {
+				if w.Body.Len() != 0 {
+					t.Errorf("body = %q, want empty", w.Body)
+				}
+				return
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+			var resp Envelope
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid body: %v", err)
+			}
+			if resp.Error == nil || resp.Error.Message != tt.message || resp.Links.Self != tt.target {
+				t.Errorf("unexpected body: %s", w.Body)
+			}
+		})
+	}
+
+	t.Run("headers of the problem are kept", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v2/films", nil))
+
+		if w.Header().Get("WWW-Authenticate") == "" {
+			t.Error("WWW-Authenticate header is dropped")
+		}
+	})
+}
@@ -0,0 +1,345 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+)
+
+type FilmService interface {
+	AddFilm(context.Context, schemas.AddFilmRequest, bool) (models.Film, error)
+	UpdateFilm(context.Context, uint, schemas.UpdateFilmRequest) error
+	PartialUpdateFilm(context.Context, uint, schemas.PartialUpdateFilmRequest) error
+	RemoveFilm(context.Context, uint) error
+	GetFilmsWithActors(context.Context, string, string, schemas.FilmsFilter, schemas.ListOptions) ([]schemas.FilmWithActorsResponse, error)
+	GetFilmWithActors(context.Context, uint) (schemas.FilmWithActorsResponse, error)
+}
+
+type FilmsHandler struct {
+	service  FilmService
+	validate *validator.Validate
+}
+
+func NewFilmsHandler(service FilmService, validate *validator.Validate) *FilmsHandler {
+	return &FilmsHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// Add godoc
+//
+//	@Summary		Add film
+//	@Description	Add film to database
+//	@Security		BasicAuth
+//	@Tags			films v2
+//	@Accept			json
+//	@Produce		json
+//	@Param			film	body		v2.AddFilmRequest	true	"New film"
+//	@Param			force	query		bool				false	"create the film even if it duplicates an existing one"
+//	@Success		201		{object}	v2.Envelope{data=v2.Film}
+//	@Header			201		{string}	Location	"URI of the created film"
+//	@Failure		400		{object}	v2.Envelope
+//	@Failure		401		{object}	v2.Envelope
+//	@Failure		403		{object}	v2.Envelope
+//	@Failure		409		{object}	v2.Envelope
+//	@Failure		500		{object}	v2.Envelope
+//	@Router			/v2/films [post]
+func (h *FilmsHandler) Add() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		force, err := parseBoolQuery(r, "force")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var schema AddFilmRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		film, err := h.service.AddFilm(r.Context(), schema.toSchema(), force != nil && *force)
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			var duplicateErr *duplicates.ErrDuplicate
+			switch {
+			case errors.As(err, &notFoundErr):
+				writeError(w, r, "invalid actor id", http.StatusBadRequest)
+			case errors.As(err, &duplicateErr):
+				duplicateError(w, r, duplicateErr)
+			default:
+				internalError(w, r)
+			}
+			return
+		}
+
+		location := fmt.Sprintf("/api/v2/films/%d", film.ID)
+		writeCreated(w, newFilm(film), location)
+	})
+}
+
+// Update godoc
+//
+//	@Summary		Update film
+//	@Description	Update film
+//	@Security		BasicAuth
+//	@Tags			films v2
+//	@Accept			json
+//	@Produce		json
+//	@Param			film	body	v2.UpdateFilmRequest	true	"Update film"
+//	@Param			id		path	int						true	"Film id"
+//	@Success		204
+//	@Failure		400	{object}	v2.Envelope
+//	@Failure		401	{object}	v2.Envelope
+//	@Failure		403	{object}	v2.Envelope
+//	@Failure		404	{object}	v2.Envelope
+//	@Failure		500	{object}	v2.Envelope
+//	@Router			/v2/films/{id} [put]
+func (h *FilmsHandler) Update() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var schema UpdateFilmRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = h.service.UpdateFilm(r.Context(), id, schema.toSchema())
+		if err != nil {
+			filmError(w, r, err)
+			return
+		}
+
+		noContent(w)
+	})
+}
+
+// PartialUpdate godoc
+//
+//	@Summary		Partial update film
+//	@Description	Partial update film
+//	@Security		BasicAuth
+//	@Tags			films v2
+//	@Accept			json
+//	@Produce		json
+//	@Param			film	body	v2.PartialUpdateFilmRequest	true	"Update film"
+//	@Param			id		path	int							true	"Film id"
+//	@Success		204
+//	@Failure		400	{object}	v2.Envelope
+//	@Failure		401	{object}	v2.Envelope
+//	@Failure		403	{object}	v2.Envelope
+//	@Failure		404	{object}	v2.Envelope
+//	@Failure		500	{object}	v2.Envelope
+//	@Router			/v2/films/{id} [patch]
+func (h *FilmsHandler) PartialUpdate() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var schema PartialUpdateFilmRequest
+		err = validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = h.service.PartialUpdateFilm(r.Context(), id, schema.toSchema())
+		if err != nil {
+			filmError(w, r, err)
+			return
+		}
+
+		noContent(w)
+	})
+}
+
+// Remove godoc
+//
+//	@Summary		Remove film
+//	@Description	Remove film from database
+//	@Security		BasicAuth
+//	@Tags			films v2
+//	@Produce		json
+//	@Param			id	path	int	true	"Film id"
+//	@Success		204
+//	@Failure		400	{object}	v2.Envelope
+//	@Failure		401	{object}	v2.Envelope
+//	@Failure		403	{object}	v2.Envelope
+//	@Failure		404	{object}	v2.Envelope
+//	@Failure		500	{object}	v2.Envelope
+//	@Router			/v2/films/{id} [delete]
+func (h *FilmsHandler) Remove() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = h.service.RemoveFilm(r.Context(), id)
+		if err != nil {
+			filmError(w, r, err)
+			return
+		}
+
+		noContent(w)
+	})
+}
+
+// GetList godoc
+//
+//	@Summary		List films
+//	@Description	Get list of films
+//	@Security		BasicAuth
+//	@Tags			films v2
+//	@Produce		json
+//	@Param			search				query		string	false	"search by films title and actors names"
+//	@Param			sortBy				query		string	false	"sorting by field: id, title, releaseDate, rating, runtime, budget, boxOffice. Format: sortBy=field1,-field2"
+//	@Param			hasAwards			query		bool	false	"films with (true) or without (false) award nominations"
+//	@Param			awardWinner			query		bool	false	"films which won (true) or never won (false) an award"
+//	@Param			country				query		string	false	"films produced in the country (ISO 3166-1 alpha-2)"
+//	@Param			language			query		string	false	"films with the original language (ISO 639-1)"
+//	@Param			ageRating			query		string	false	"films with the age rating"
+//	@Param			minRuntime			query		int		false	"minimal runtime in minutes"
+//	@Param			maxRuntime			query		int		false	"maximal runtime in minutes"
+//	@Param			lang				query		string	false	"language of titles, overrides Accept-Language"
+//	@Param			Accept-Language		header		string	false	"preferred languages of titles"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{object}	v2.Envelope{data=[]v2.FilmWithActors}
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		400					{object}	v2.Envelope
+//	@Failure		401					{object}	v2.Envelope
+//	@Failure		500					{object}	v2.Envelope
+//	@Router			/v2/films [get]
+func (h *FilmsHandler) GetList() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, err := h.parseFilter(r)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		sortBy := r.URL.Query().Get("sortBy")
+		_, err = schemas.ParseSort(sortBy, schemas.FilmSortFields)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		films, err := h.service.GetFilmsWithActors(
+			r.Context(),
+			r.URL.Query().Get("search"),
+			sortBy,
+			filter,
+			schemas.DefaultListOptions,
+		)
+		if err != nil {
+			internalError(w, r)
+			return
+		}
+
+		writeList(w, r, mapList(films, newFilmWithActors))
+	})
+}
+
+// Get godoc
+//
+//	@Summary		Get film
+//	@Description	Get film with its actors, the created film is located here
+//	@Security		BasicAuth
+//	@Tags			films v2
+//	@Produce		json
+//	@Param			id					path		int		true	"Film id"
+//	@Param			lang				query		string	false	"language of titles, overrides Accept-Language"
+//	@Param			Accept-Language		header		string	false	"preferred languages of titles"
+//	@Param			If-Modified-Since	header		string	false	"time of the cached response"
+//	@Success		200					{object}	v2.Envelope{data=v2.FilmWithActors}
+//	@Success		304					{object}	nil	"not modified since If-Modified-Since"
+//	@Failure		400					{object}	v2.Envelope
+//	@Failure		401					{object}	v2.Envelope
+//	@Failure		404					{object}	v2.Envelope
+//	@Failure		500					{object}	v2.Envelope
+//	@Router			/v2/films/{id} [get]
+func (h *FilmsHandler) Get() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		film, err := h.service.GetFilmWithActors(r.Context(), id)
+		if err != nil {
+			filmError(w, r, err)
+			return
+		}
+
+		writeData(w, newFilmWithActors(film), r.URL.RequestURI(), http.StatusOK)
+	})
+}
+
+func (h *FilmsHandler) parseFilter(r *http.Request) (schemas.FilmsFilter, error) {
+	var filter schemas.FilmsFilter
+	var err error
+
+	filter.HasAwards, err = parseBoolQuery(r, "hasAwards")
+	if err != nil {
+		return filter, err
+	}
+
+	filter.AwardWinner, err = parseBoolQuery(r, "awardWinner")
+	if err != nil {
+		return filter, err
+	}
+
+	filter.MinRuntime, err = parseUintQuery(r, "minRuntime")
+	if err != nil {
+		return filter, err
+	}
+
+	filter.MaxRuntime, err = parseUintQuery(r, "maxRuntime")
+	if err != nil {
+		return filter, err
+	}
+
+	filter.Country = stringQuery(r, "country")
+	filter.Language = stringQuery(r, "language")
+	filter.AgeRating = stringQuery(r, "ageRating")
+
+	return filter, h.validate.Struct(filter)
+}
+
+func filmError(w http.ResponseWriter, r *http.Request, err error) {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	if errors.As(err, &notFoundErr) {
+		writeError(w, r, "film not found", http.StatusNotFound)
+		return
+	}
+	internalError(w, r)
+}
+
+func duplicateError(w http.ResponseWriter, r *http.Request, err *duplicates.ErrDuplicate) {
+	resp := Envelope{
+		Links: Links{Self: r.URL.RequestURI()},
+		Error: &Error{Message: err.Error(), ExistingID: &err.ID},
+	}
+	_ = writeEnvelope(w, resp, http.StatusConflict)
+}
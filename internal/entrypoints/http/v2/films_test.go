@@ -0,0 +1,176 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/validator"
+)
+
+type fakeFilms struct {
+	FilmService
+	added   schemas.AddFilmRequest
+	missing bool
+}
+
+func (f *fakeFilms) AddFilm(
+	_ context.Context, request schemas.AddFilmRequest, _ bool,
+) (models.Film, error) {
+	f.added = request
+	return models.Film{ID: 7, Title: request.Title, ReleaseDate: request.ReleaseDate.ToTime()}, nil
+}
+
+func (f *fakeFilms) RemoveFilm(context.Context, uint) error {
+	return nil
+}
+
+func (f *fakeFilms) GetFilmsWithActors(
+	context.Context, string, string, schemas.FilmsFilter, schemas.ListOptions,
+) ([]schemas.FilmWithActorsResponse, error) {
+	return []schemas.FilmWithActorsResponse{{
+		ID:          1,
+		Title:       "Drive",
+		ReleaseDate: "03-11-2011",
+		Actors: []schemas.ActorInfo{
+			{ID: 2, FirstName: "Ryan", LastName: "Gosling", Birthday: "12-11-1980"},
+		},
+	}}, nil
+}
+
+func (f *fakeFilms) GetFilmWithActors(
+	_ context.Context, id uint,
+) (schemas.FilmWithActorsResponse, error) {
+	if f.missing {
+		return schemas.FilmWithActorsResponse{}, &postgresql.ErrRecordNotFound{}
+	}
+	return schemas.FilmWithActorsResponse{ID: id, Title: "Drive", ReleaseDate: "03-11-2011"}, nil
+}
+
+func serve(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	r.SetPathValue("id", "7")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestFilmsHandler(t *testing.T) {
+	t.Run("add", func(t *testing.T) {
+		service := &fakeFilms{}
+		h := NewFilmsHandler(service, validator.New())
+
+		body := `{"title":"Drive","releaseDate":"2011-11-03","actorsIds":[]}`
+		w := serve(h.Add(), http.MethodPost, "/api/v2/films", body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusCreated, w.Body)
+		}
+		if loc := w.Header().Get("Location"); loc != "/api/v2/films/7" {
+			t.Errorf("Location = %q, want /api/v2/films/7", loc)
+		}
+		if service.added.ReleaseDate != "03-11-2011" {
+			t.Errorf("service got release date %q, want 03-11-2011", service.added.ReleaseDate)
+		}
+
+		var resp struct {
+			Data  Film  `json:"data"`
+			Links Links `json:"links"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid body: %v", err)
+		}
+		if resp.Data.ReleaseDate != "2011-11-03" || resp.Links.Self != "/api/v2/films/7" {
+			t.Errorf("unexpected body: %s", w.Body)
+		}
+	})
+
+	t.Run("legacy date", func(t *testing.T) {
+		h := NewFilmsHandler(&fakeFilms{}, validator.New())
+
+		body := `{"title":"Drive","releaseDate":"03-11-2011","actorsIds":[]}`
+		w := serve(h.Add(), http.MethodPost, "/api/v2/films", body)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+		}
+
+		var resp Envelope
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid body: %v", err)
+		}
+		if resp.Data != nil || resp.Error == nil {
+			t.Errorf("unexpected body: %s", w.Body)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		h := NewFilmsHandler(&fakeFilms{}, validator.New())
+
+		w := serve(h.Remove(), http.MethodDelete, "/api/v2/films/7", "")
+		if w.Code != http.StatusNoContent {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("body = %q, want empty", w.Body)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		h := NewFilmsHandler(&fakeFilms{}, validator.New())
+
+		w := serve(h.Get(), http.MethodGet, "/api/v2/films/7", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+		}
+
+		var resp struct {
+			Data  FilmWithActors `json:"data"`
+			Links Links          `json:"links"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("invalid body: %v", err)
+		}
+		if resp.Data.ID != 7 || resp.Data.ReleaseDate != "2011-11-03" ||
+			resp.Links.Self != "/api/v2/films/7" {
+			t.Errorf("unexpected body: %s", w.Body)
+		}
+
+		h = NewFilmsHandler(&fakeFilms{missing: true}, validator.New())
+		w = serve(h.Get(), http.MethodGet, "/api/v2/films/7", "")
+		if w.Code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		h := NewFilmsHandler(&fakeFilms{}, validator.New())
+
+		w := serve(h.GetList(), http.MethodGet, "/api/v2/films?sortBy=-rating", "")
+		want := `{"data":[{"id":1,"title":"Drive","description":"","releaseDate":"2011-11-03","rating":0,` +
+			`"runtime":null,"countries":null,"language":null,"ageRating":null,"budget":null,"boxOffice":null,"currency":null,` +
+			`"poster":null,"actors":[{"id":2,"firstName":"Ryan","lastName":"Gosling","middleName":null,"sex":"",` +
+			`"birthday":"1980-11-12","headshot":null}]}],` +
+			`"meta":{"count":1},"links":{"self":"/api/v2/films?sortBy=-rating"}}`
+		if got := w.Body.String(); got != want {
+			t.Errorf("body = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("unknown sort field", func(t *testing.T) {
+		h := NewFilmsHandler(&fakeFilms{}, validator.New())
+
+		for _, sortBy := range []string{"rating,", "id;DROP TABLE films"} {
+			w := serve(h.GetList(), http.MethodGet, "/api/v2/films?sortBy="+url.QueryEscape(sortBy), "")
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("sortBy %q: status = %d, want %d", sortBy, w.Code, http.StatusBadRequest)
+			}
+		}
+	})
+}
@@ -0,0 +1,197 @@
+package v2
+
+import (
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+// The services speak the schemas of v1, the mappers below translate
+// them from and to the schemas of v2.
+
+func legacyDate(d Date) schemas.Date {
+	return schemas.NewDate(d.ToTime())
+}
+
+func isoDate(d schemas.Date) Date {
+	return NewDate(d.ToTime())
+}
+
+func newUser(user models.User) User {
+	return User{ID: user.ID, Username: user.Username, IsAdmin: user.IsAdmin}
+}
+
+func (r AddActorRequest) toSchema() schemas.AddActorRequest {
+	return schemas.AddActorRequest{
+		FirstName:  r.FirstName,
+		LastName:   r.LastName,
+		MiddleName: r.MiddleName,
+		Sex:        r.Sex,
+		Birthday:   legacyDate(r.Birthday),
+	}
+}
+
+func (r UpdateActorRequest) toSchema() schemas.UpdateActorRequest {
+	return schemas.UpdateActorRequest{
+		FirstName:  r.FirstName,
+		LastName:   r.LastName,
+		MiddleName: r.MiddleName,
+		Sex:        r.Sex,
+		Birthday:   legacyDate(r.Birthday),
+	}
+}
+
+func (r PartialUpdateActorRequest) toSchema() schemas.PartialUpdateActorRequest {
+	schema := schemas.PartialUpdateActorRequest{
+		FirstName:  r.FirstName,
+		LastName:   r.LastName,
+		MiddleName: r.MiddleName,
+		Sex:        r.Sex,
+	}
+	if r.Birthday != nil {
+		birthday := legacyDate(*r.Birthday)
+		schema.Birthday = &birthday
+	}
+
+	return schema
+}
+
+func (r AddFilmRequest) toSchema() schemas.AddFilmRequest {
+	return schemas.AddFilmRequest{
+		Title:       r.Title,
+		Description: r.Description,
+		ReleaseDate: legacyDate(r.ReleaseDate),
+		Rating:      r.Rating,
+		Runtime:     r.Runtime,
+		Countries:   r.Countries,
+		Language:    r.Language,
+		AgeRating:   r.AgeRating,
+		Budget:      r.Budget,
+		BoxOffice:   r.BoxOffice,
+		Currency:    r.Currency,
+		ActorsIDs:   r.ActorsIDs,
+	}
+}
+
+func (r UpdateFilmRequest) toSchema() schemas.UpdateFilmRequest {
+	return schemas.UpdateFilmRequest{
+		Title:       r.Title,
+		Description: r.Description,
+		ReleaseDate: legacyDate(r.ReleaseDate),
+		Rating:      r.Rating,
+		Runtime:     r.Runtime,
+		Countries:   r.Countries,
+		Language:    r.Language,
+		AgeRating:   r.AgeRating,
+		Budget:      r.Budget,
+		BoxOffice:   r.BoxOffice,
+		Currency:    r.Currency,
+		ActorsIds:   r.ActorsIDs,
+	}
+}
+
+func (r PartialUpdateFilmRequest) toSchema() schemas.PartialUpdateFilmRequest {
+	schema := schemas.PartialUpdateFilmRequest{
+		Title:       r.Title,
+		Description: r.Description,
+		Rating:      r.Rating,
+		Runtime:     r.Runtime,
+		Countries:   r.Countries,
+		Language:    r.Language,
+		AgeRating:   r.AgeRating,
+		Budget:      r.Budget,
+		BoxOffice:   r.BoxOffice,
+		Currency:    r.Currency,
+		ActorsIDs:   r.ActorsIDs,
+	}
+	if r.ReleaseDate != nil {
+		releaseDate := legacyDate(*r.ReleaseDate)
+		schema.ReleaseDate = &releaseDate
+	}
+
+	return schema
+}
+
+func newActor(actor models.Actor) Actor {
+	return Actor{
+		ID:         actor.ID,
+		FirstName:  actor.FirstName,
+		LastName:   actor.LastName,
+		MiddleName: actor.MiddleName,
+		Sex:        actor.Sex,
+		Birthday:   NewDate(actor.Birthday),
+	}
+}
+
+func actorFromInfo(actor schemas.ActorInfo) Actor {
+	return Actor{
+		ID:         actor.ID,
+		FirstName:  actor.FirstName,
+		LastName:   actor.LastName,
+		MiddleName: actor.MiddleName,
+		Sex:        actor.Sex,
+		Birthday:   isoDate(actor.Birthday),
+		Headshot:   actor.Headshot,
+	}
+}
+
+func newActorWithFilms(actor schemas.ActorWithFilmsResponse) ActorWithFilms {
+	return ActorWithFilms{
+		Actor: Actor{
+			ID:         actor.ID,
+			FirstName:  actor.FirstName,
+			LastName:   actor.LastName,
+			MiddleName: actor.MiddleName,
+			Sex:        actor.Sex,
+			Birthday:   isoDate(actor.Birthday),
+			Headshot:   actor.Headshot,
+		},
+		Films: mapList(actor.Films, filmFromInfo),
+	}
+}
+
+func newFilm(film models.Film) Film {
+	return Film{
+		ID:           film.ID,
+		Title:        film.Title,
+		Description:  film.Description,
+		ReleaseDate:  NewDate(film.ReleaseDate),
+		Rating:       film.Rating,
+		FilmMetadata: schemas.NewFilmMetadata(film),
+	}
+}
+
+func filmFromInfo(film schemas.FilmInfo) Film {
+	return Film{
+		ID:           film.ID,
+		Title:        film.Title,
+		Description:  film.Description,
+		ReleaseDate:  isoDate(film.ReleaseDate),
+		Rating:       film.Rating,
+		FilmMetadata: film.FilmMetadata,
+		Poster:       film.Poster,
+	}
+}
+
+func newFilmWithActors(film schemas.FilmWithActorsResponse) FilmWithActors {
+	return FilmWithActors{
+		Film: Film{
+			ID:           film.ID,
+			Title:        film.Title,
+			Description:  film.Description,
+			ReleaseDate:  isoDate(film.ReleaseDate),
+			Rating:       film.Rating,
+			FilmMetadata: film.FilmMetadata,
+			Poster:       film.Poster,
+		},
+		Actors: mapList(film.Actors, actorFromInfo),
+	}
+}
+
+func mapList[S, T any](items []S, mapper func(S) T) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		result = append(result, mapper(item))
+	}
+
+	return result
+}
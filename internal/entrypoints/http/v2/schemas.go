@@ -0,0 +1,126 @@
+package v2
+
+import (
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+// Date is the calendar date formatted as ISO 8601 "yyyy-mm-dd".
+type Date string
+
+func NewDate(t time.Time) Date {
+	return Date(t.Format(time.DateOnly))
+}
+
+// ToTime converts the date string to time.Time.
+func (d Date) ToTime() time.Time {
+	parsedTime, _ := time.Parse(time.DateOnly, string(d))
+	return parsedTime
+}
+
+type User struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+	IsAdmin  bool   `json:"isAdmin"`
+}
+
+type AddActorRequest struct {
+	FirstName  string     `json:"firstName" validate:"required"`
+	LastName   string     `json:"lastName" validate:"required"`
+	MiddleName *string    `json:"middleName,omitempty"`
+	Sex        models.Sex `json:"sex" validate:"required,sexValidation"`
+	Birthday   Date       `json:"birthday" validate:"required,isoDateValidation" example:"2006-01-02"`
+}
+
+type UpdateActorRequest struct {
+	FirstName  string     `json:"firstName" validate:"required"`
+	LastName   string     `json:"lastName" validate:"required"`
+	MiddleName *string    `json:"middleName"`
+	Sex        models.Sex `json:"sex" validate:"required,sexValidation"`
+	Birthday   Date       `json:"birthday" validate:"required,isoDateValidation" example:"2006-01-02"`
+}
+
+type PartialUpdateActorRequest struct {
+	FirstName  *string     `json:"firstName" validate:"omitempty"`
+	LastName   *string     `json:"lastName" validate:"omitempty"`
+	MiddleName *string     `json:"middleName" validate:"omitempty"`
+	Sex        *models.Sex `json:"sex" validate:"omitempty,sexValidation"`
+	Birthday   *Date       `json:"birthday" validate:"omitempty,isoDateValidation" example:"2006-01-02"`
+}
+
+type AddFilmRequest struct {
+	Title       string            `json:"title" validate:"required,min=1,max=150"`
+	Description string            `json:"description" validate:"max=1000"`
+	ReleaseDate Date              `json:"releaseDate" validate:"required,isoDateValidation" example:"2006-01-02"`
+	Rating      uint8             `json:"rating" validate:"min=0,max=10"`
+	Runtime     *uint             `json:"runtime" validate:"omitempty,min=1,max=1000"`
+	Countries   []string          `json:"countries" validate:"omitempty,dive,countryValidation" example:"US,GB"`
+	Language    *string           `json:"language" validate:"omitempty,languageValidation" example:"en"`
+	AgeRating   *models.AgeRating `json:"ageRating" validate:"omitempty,ageRatingValidation" example:"PG-13"`
+	Budget      *string           `json:"budget" validate:"omitempty,moneyValidation" example:"185000000.00"`
+	BoxOffice   *string           `json:"boxOffice" validate:"omitempty,moneyValidation" example:"276600000.00"`
+	Currency    *string           `json:"currency" validate:"required_with=Budget BoxOffice,omitempty,currencyValidation" example:"USD"`
+	ActorsIDs   []uint            `json:"actorsIds" validate:"required"`
+}
+
+type UpdateFilmRequest struct {
+	Title       string            `json:"title" validate:"required,min=1,max=150"`
+	Description string            `json:"description" validate:"required,max=1000"`
+	ReleaseDate Date              `json:"releaseDate" validate:"required,isoDateValidation" example:"2006-01-02"`
+	Rating      uint8             `json:"rating" validate:"min=0,max=10"`
+	Runtime     *uint             `json:"runtime" validate:"omitempty,min=1,max=1000"`
+	Countries   []string          `json:"countries" validate:"omitempty,dive,countryValidation" example:"US,GB"`
+	Language    *string           `json:"language" validate:"omitempty,languageValidation" example:"en"`
+	AgeRating   *models.AgeRating `json:"ageRating" validate:"omitempty,ageRatingValidation" example:"PG-13"`
+	Budget      *string           `json:"budget" validate:"omitempty,moneyValidation" example:"185000000.00"`
+	BoxOffice   *string           `json:"boxOffice" validate:"omitempty,moneyValidation" example:"276600000.00"`
+	Currency    *string           `json:"currency" validate:"required_with=Budget BoxOffice,omitempty,currencyValidation" example:"USD"`
+	ActorsIDs   []uint            `json:"actorsIds" validate:"required"`
+}
+
+type PartialUpdateFilmRequest struct {
+	Title       *string           `json:"title" validate:"omitempty,min=1,max=150"`
+	Description *string           `json:"description" validate:"omitempty,max=1000"`
+	ReleaseDate *Date             `json:"releaseDate" validate:"omitempty,isoDateValidation" example:"2006-01-02"`
+	Rating      *uint8            `json:"rating" validate:"omitempty,min=0,max=10"`
+	Runtime     *uint             `json:"runtime" validate:"omitempty,min=1,max=1000"`
+	Countries   *[]string         `json:"countries" validate:"omitempty,dive,countryValidation" example:"US,GB"`
+	Language    *string           `json:"language" validate:"omitempty,languageValidation" example:"en"`
+	AgeRating   *models.AgeRating `json:"ageRating" validate:"omitempty,ageRatingValidation" example:"PG-13"`
+	Budget      *string           `json:"budget" validate:"omitempty,moneyValidation" example:"185000000.00"`
+	BoxOffice   *string           `json:"boxOffice" validate:"omitempty,moneyValidation" example:"276600000.00"`
+	Currency    *string           `json:"currency" validate:"omitempty,currencyValidation" example:"USD"`
+	ActorsIDs   *[]uint           `json:"actorsIds" validate:"omitempty"`
+}
+
+type Actor struct {
+	ID         uint               `json:"id"`
+	FirstName  string             `json:"firstName"`
+	LastName   string             `json:"lastName"`
+	MiddleName *string            `json:"middleName"`
+	Sex        models.Sex         `json:"sex"`
+	Birthday   Date               `json:"birthday" example:"2006-01-02"`
+	Headshot   *schemas.ImageInfo `json:"headshot"`
+}
+
+type ActorWithFilms struct {
+	Actor
+	Films []Film `json:"films"`
+}
+
+type Film struct {
+	ID          uint   `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ReleaseDate Date   `json:"releaseDate" example:"2006-01-02"`
+	Rating      uint8  `json:"rating"`
+	schemas.FilmMetadata
+	Poster *schemas.ImageInfo `json:"poster"`
+}
+
+type FilmWithActors struct {
+	Film
+	Actors []Actor `json:"actors"`
+}
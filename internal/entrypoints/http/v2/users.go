@@ -0,0 +1,107 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type UserService interface {
+	CreateUser(context.Context, schemas.CreateUserRequest) (models.User, error)
+	GetUser(context.Context, uint) (models.User, error)
+}
+
+type UsersHandler struct {
+	service  UserService
+	validate *validator.Validate
+}
+
+func NewUsersHandler(service UserService, validate *validator.Validate) *UsersHandler {
+	return &UsersHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// Create godoc
+//
+//	@Summary		Create user
+//	@Description	Create new user
+//	@Tags			users v2
+//	@Accept			json
+//	@Produce		json
+//	@Param			user	body		schemas.CreateUserRequest	true	"New user"
+//	@Success		201		{object}	v2.Envelope{data=v2.User}
+//	@Header			201		{string}	Location	"URI of the created user"
+//	@Failure		400		{object}	v2.Envelope
+//	@Failure		500		{object}	v2.Envelope
+//	@Router			/v2/users [post]
+func (h *UsersHandler) Create() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var schema schemas.CreateUserRequest
+		err := validateRequestBody(r, h.validate, &schema)
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		user, err := h.service.CreateUser(r.Context(), schema)
+		if err != nil {
+			internalError(w, r)
+			return
+		}
+
+		location := fmt.Sprintf("/api/v2/users/%d", user.ID)
+		writeCreated(w, newUser(user), location)
+	})
+}
+
+// Get godoc
+//
+//	@Summary		Get user
+//	@Description	Get user, the created user is located here. Readers get only themselves.
+//	@Security		BasicAuth
+//	@Tags			users v2
+//	@Produce		json
+//	@Param			id	path		int	true	"User id"
+//	@Success		200	{object}	v2.Envelope{data=v2.User}
+//	@Failure		400	{object}	v2.Envelope
+//	@Failure		401	{object}	v2.Envelope
+//	@Failure		403	{object}	v2.Envelope
+//	@Failure		404	{object}	v2.Envelope
+//	@Failure		500	{object}	v2.Envelope
+//	@Router			/v2/users/{id} [get]
+func (h *UsersHandler) Get() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := parseUintPath(r, "id")
+		if err != nil {
+			writeError(w, r, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		current := currentUser(r)
+		if current.ID != id && !current.IsAdmin {
+			writeError(w, r, "readers get only themselves", http.StatusForbidden)
+			return
+		}
+
+		user, err := h.service.GetUser(r.Context(), id)
+		if err != nil {
+			var notFoundErr *postgresql.ErrRecordNotFound
+			if errors.As(err, &notFoundErr) {
+				writeError(w, r, "user not found", http.StatusNotFound)
+				return
+			}
+			internalError(w, r)
+			return
+		}
+
+		writeData(w, newUser(user), r.URL.RequestURI(), http.StatusOK)
+	})
+}
@@ -0,0 +1,90 @@
+package v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator"
+	mw "github.com/sivistrukov/vk-assigment/internal/entrypoints/http/middlewares"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+// validateRequestBody unmarshals the request body into the given schema
+// pointer and checks it. If the request body is not valid, it returns
+// an error.
+func validateRequestBody(r *http.Request, validate *validator.Validate, schema any) error {
+	err := json.NewDecoder(r.Body).Decode(schema)
+	if err != nil {
+		return errors.New("invalid request body")
+	}
+
+	err = validate.Struct(schema)
+	if err != nil {
+		return fmt.Errorf("invalid request body: %v", err)
+	}
+
+	return nil
+}
+
+// parseBoolQuery parses the optional boolean query parameter.
+// Returns nil if the parameter is not set.
+func parseBoolQuery(r *http.Request, name string) (*bool, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(param)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query parameter: %s", name)
+	}
+
+	return &value, nil
+}
+
+// parseUintQuery parses the optional unsigned integer query parameter.
+// Returns nil if the parameter is not set.
+func parseUintQuery(r *http.Request, name string) (*uint, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseUint(param, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query parameter: %s", name)
+	}
+
+	result := uint(value)
+	return &result, nil
+}
+
+// stringQuery returns the optional string query parameter.
+// Returns nil if the parameter is not set.
+func stringQuery(r *http.Request, name string) *string {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil
+	}
+
+	return &param
+}
+
+// parseUintPath parses the unsigned integer path parameter.
+func parseUintPath(r *http.Request, name string) (uint, error) {
+	value, err := strconv.ParseUint(r.PathValue(name), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid path parameter: %s", name)
+	}
+
+	return uint(value), nil
+}
+
+// currentUser returns the user authenticated by the BasicAuth middleware.
+func currentUser(r *http.Request) models.User {
+	user, _ := r.Context().Value(mw.Key("user")).(models.User)
+	return user
+}
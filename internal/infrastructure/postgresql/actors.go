@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
 	"github.com/sivistrukov/vk-assigment/internal/models"
 )
@@ -21,8 +23,8 @@ func NewActorRepo(db *sql.DB) *ActorRepo {
 	}
 }
 
-func (r *ActorRepo) Create(_ context.Context, actor *models.Actor) error {
-	stmt, err := r.db.Prepare(`
+func (r *ActorRepo) Create(ctx context.Context, actor *models.Actor) error {
+	stmt, err := conn(ctx, r.db).Prepare(`
 	INSERT INTO actors (first_name, last_name, middle_name, sex, birthday) 
 	VALUES ($1, $2, $3, $4, $5)
 	RETURNING id;
@@ -47,7 +49,7 @@ func (r *ActorRepo) Create(_ context.Context, actor *models.Actor) error {
 }
 
 func (r *ActorRepo) Update(
-	_ context.Context, id uint, updates map[string]any,
+	ctx context.Context, id uint, updates map[string]any,
 ) error {
 	builder := strings.Builder{}
 	builder.WriteString("UPDATE actors SET ")
@@ -72,7 +74,7 @@ func (r *ActorRepo) Update(
 
 	stmt := builder.String()
 
-	result, err := r.db.Exec(stmt, values...)
+	result, err := conn(ctx, r.db).Exec(stmt, values...)
 	if err != nil {
 		return err
 	}
@@ -92,8 +94,8 @@ func (r *ActorRepo) Update(
 	return nil
 }
 
-func (r *ActorRepo) Remove(_ context.Context, id uint) error {
-	stmt, err := r.db.Prepare(`
+func (r *ActorRepo) Remove(ctx context.Context, id uint) error {
+	stmt, err := conn(ctx, r.db).Prepare(`
 	DELETE FROM actors WHERE id = $1
 	`)
 	if err != nil {
@@ -121,73 +123,538 @@ func (r *ActorRepo) Remove(_ context.Context, id uint) error {
 	return nil
 }
 
-func (r *ActorRepo) GetListWithFilms(_ context.Context) ([]schemas.ActorWithFilmsResponse, error) {
+// GetListWithFilms returns the fields of the actors selected by the options
+// with their films if the options include them.
+func (r *ActorRepo) GetListWithFilms(
+	ctx context.Context, options schemas.ListOptions,
+) ([]schemas.ActorWithFilmsResponse, error) {
+	return r.getWithFilms(ctx, options, nil)
+}
+
+// GetWithFilms returns the actor with the films.
+func (r *ActorRepo) GetWithFilms(
+	ctx context.Context, id uint,
+) (schemas.ActorWithFilmsResponse, error) {
+	actors, err := r.getWithFilms(ctx, schemas.DefaultListOptions, &id)
+	if err != nil {
+		return schemas.ActorWithFilmsResponse{}, err
+	}
+	if len(actors) == 0 {
+		return schemas.ActorWithFilmsResponse{}, &ErrRecordNotFound{
+			tableName: "actors",
+			identity:  fmt.Sprintf("%d", id),
+		}
+	}
+
+	return actors[0], nil
+}
+
+// getWithFilms returns the actor with the id or all actors if it is nil.
+func (r *ActorRepo) getWithFilms(
+	ctx context.Context, options schemas.ListOptions, id *uint,
+) ([]schemas.ActorWithFilmsResponse, error) {
+	args := make([]any, 0, 2)
+	fields, columns := selectList(actorFields, options.HasField, lazyParam(&args, localesParam(ctx)))
+
+	where := ""
+	if id != nil {
+		args = append(args, *id)
+		where = fmt.Sprintf("WHERE actors.id = $%d", len(args))
+	}
+
+	rows, err := r.db.Query(`
+	SELECT `+columns+`
+	FROM actors
+	`+where+`
+	ORDER BY actors.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	actors := make([]schemas.ActorWithFilmsResponse, 0)
+	ids := make([]uint, 0)
+	for rows.Next() {
+		var row actorRow
+		if err = rows.Scan(scanDest(fields, &row)...); err != nil {
+			return nil, err
+		}
+
+		actor, err := row.info()
+		if err != nil {
+			return nil, err
+		}
+		actors = append(actors, schemas.ActorWithFilmsResponse{
+			ID:         actor.ID,
+			FirstName:  actor.FirstName,
+			LastName:   actor.LastName,
+			MiddleName: actor.MiddleName,
+			Sex:        actor.Sex,
+			Birthday:   actor.Birthday,
+			Headshot:   actor.Headshot,
+		})
+		ids = append(ids, actor.ID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if !options.Include || len(actors) == 0 {
+		return actors, nil
+	}
+
+	filmographies, err := getFilmographies(ctx, r.db, ids, options.HasIncludedField)
+	if err != nil {
+		return nil, err
+	}
+	for i := range actors {
+		actors[i].Films = filmographies[actors[i].ID]
+		if actors[i].Films == nil {
+			actors[i].Films = make([]schemas.FilmInfo, 0)
+		}
+	}
+
+	return actors, nil
+}
+
+// GetCostars returns actors who starred in the same films as the given
+// one ranked by the number of shared films.
+func (r *ActorRepo) GetCostars(
+	ctx context.Context, id uint, limit uint,
+) ([]schemas.CostarInfo, error) {
+	err := r.checkExists(id)
+	if err != nil {
+		return nil, err
+	}
+
 	stmt := `
-	SELECT id, first_name, last_name, middle_name, sex, birthday 
-	FROM actors 
+	SELECT actors.id, ` +
+		translated("actors", "first_name", "$3") + `, ` +
+		translated("actors", "last_name", "$3") + `, ` +
+		translated("actors", "middle_name", "$3") + `,
+		COUNT(DISTINCT costar.film_id) AS shared_films
+	FROM actors_and_films AS actor
+	INNER JOIN actors_and_films AS costar
+		ON actor.film_id = costar.film_id AND actor.actor_id <> costar.actor_id
+	INNER JOIN actors ON costar.actor_id = actors.id
+	WHERE actor.actor_id = $1
+	GROUP BY actors.id
+	ORDER BY shared_films DESC, actors.last_name, actors.first_name
+	LIMIT $2
 	`
 
-	rows, err := r.db.Query(stmt)
+	rows, err := r.db.Query(stmt, id, limit, localesParam(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	actors := make([]schemas.ActorWithFilmsResponse, 0)
+	costars := make([]schemas.CostarInfo, 0)
 	for rows.Next() {
-		var actor schemas.ActorWithFilmsResponse
-		var date time.Time
+		var costar schemas.CostarInfo
 		err = rows.Scan(
-			&actor.ID,
-			&actor.FirstName,
-			&actor.LastName,
-			&actor.MiddleName,
-			&actor.Sex,
-			&date,
+			&costar.ID,
+			&costar.FirstName,
+			&costar.LastName,
+			&costar.MiddleName,
+			&costar.SharedFilms,
 		)
 		if err != nil {
 			return nil, err
 		}
-		actor.Birthday = schemas.NewDate(date)
 
-		stmt = `
-		SELECT films.id, films.title, films.description, films.release_date, films.rating 
-		FROM films 
-		INNER JOIN actors_and_films ON films.id = actors_and_films.film_id
-		WHERE actors_and_films.actor_id = $1
-		`
-		rows2, err := r.db.Query(stmt, actor.ID)
+		costars = append(costars, costar)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return costars, nil
+}
+
+// GetFilmography returns the actor and the films they starred in
+// ordered by the release date.
+func (r *ActorRepo) GetFilmography(
+	ctx context.Context, id uint,
+) (schemas.ActorInfo, []schemas.FilmInfo, error) {
+	var actor schemas.ActorInfo
+	var date time.Time
+	var headshot []byte
+
+	stmt := `
+	SELECT id, ` +
+		translated("actors", "first_name", "$2") + `, ` +
+		translated("actors", "last_name", "$2") + `, ` +
+		translated("actors", "middle_name", "$2") + `,
+		sex, birthday, ` +
+		imagesSubquery(models.ActorImage, "actors.id") + `
+	FROM actors
+	WHERE id = $1
+	`
+	err := r.db.QueryRow(stmt, id, localesParam(ctx)).Scan(
+		&actor.ID,
+		&actor.FirstName,
+		&actor.LastName,
+		&actor.MiddleName,
+		&actor.Sex,
+		&date,
+		&headshot,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return actor, nil, &ErrRecordNotFound{
+				tableName: "actors",
+				identity:  fmt.Sprintf("%d", id),
+			}
+		}
+		return actor, nil, err
+	}
+	actor.Birthday = schemas.NewDate(date)
+
+	actor.Headshot, err = parseImages(headshot)
+	if err != nil {
+		return actor, nil, err
+	}
+
+	stmt = `
+	SELECT ` + filmInfoColumns("$2") + `
+	FROM films
+	INNER JOIN actors_and_films ON films.id = actors_and_films.film_id
+	WHERE actors_and_films.actor_id = $1
+	ORDER BY films.release_date, films.id
+	`
+	rows, err := r.db.Query(stmt, id, localesParam(ctx))
+	if err != nil {
+		return actor, nil, err
+	}
+	defer rows.Close()
+
+	films, err := scanFilmInfos(rows)
+	if err != nil {
+		return actor, nil, err
+	}
+
+	return actor, films, nil
+}
+
+// pathLink is the film through which the actor was reached.
+type pathLink struct {
+	actorId uint
+	filmId  uint
+}
+
+// GetPath returns the shortest chain of films connecting two actors.
+// The breadth-first search expands one level per query and gives up
+// after maxDepth levels.
+func (r *ActorRepo) GetPath(
+	ctx context.Context, fromId uint, toId uint, maxDepth uint,
+) (schemas.ActorPathResponse, error) {
+	for _, id := range []uint{fromId, toId} {
+		if err := r.checkExists(id); err != nil {
+			return schemas.ActorPathResponse{}, err
+		}
+	}
+
+	path := schemas.ActorPathResponse{Steps: make([]schemas.ActorPathStep, 0)}
+	if fromId == toId {
+		return path, nil
+	}
+
+	links := map[uint]pathLink{fromId: {}}
+	frontier := []uint{fromId}
+	found := false
+	for depth := uint(0); depth < maxDepth && len(frontier) > 0 && !found; depth++ {
+		rows, err := r.db.Query(`
+		SELECT DISTINCT ON (costar.actor_id) costar.actor_id, actor.actor_id, actor.film_id
+		FROM actors_and_films AS actor
+		INNER JOIN actors_and_films AS costar
+			ON actor.film_id = costar.film_id AND actor.actor_id <> costar.actor_id
+		WHERE actor.actor_id = ANY($1)
+		ORDER BY costar.actor_id, actor.actor_id, actor.film_id
+		`, pq.Array(frontier))
 		if err != nil {
-			return nil, err
+			return schemas.ActorPathResponse{}, err
 		}
-		defer rows.Close()
-
-		films := make([]schemas.FilmInfo, 0)
-		for rows2.Next() {
-			var date time.Time
-			var film schemas.FilmInfo
-			err = rows2.Scan(
-				&film.ID,
-				&film.Title,
-				&film.Description,
-				&date,
-				&film.Rating,
-			)
+
+		next := make([]uint, 0)
+		for rows.Next() {
+			var costarId uint
+			var link pathLink
+			err = rows.Scan(&costarId, &link.actorId, &link.filmId)
 			if err != nil {
-				return nil, err
+				_ = rows.Close()
+				return schemas.ActorPathResponse{}, err
+			}
+
+			if _, ok := links[costarId]; ok {
+				continue
 			}
-			film.ReleaseDate = schemas.NewDate(date)
 
-			films = append(films, film)
+			links[costarId] = link
+			next = append(next, costarId)
+			if costarId == toId {
+				found = true
+			}
+		}
+		err = rows.Err()
+		_ = rows.Close()
+		if err != nil {
+			return schemas.ActorPathResponse{}, err
 		}
 
-		actor.Films = films
-		actors = append(actors, actor)
+		frontier = next
 	}
 
-	if err := rows.Err(); err != nil {
+	if !found {
+		return schemas.ActorPathResponse{}, ErrPathNotFound
+	}
+
+	for id := toId; id != fromId; id = links[id].actorId {
+		path.Steps = append(path.Steps, schemas.ActorPathStep{
+			FromActor: schemas.ActorRef{ID: links[id].actorId},
+			Film:      schemas.FilmRef{ID: links[id].filmId},
+			ToActor:   schemas.ActorRef{ID: id},
+		})
+	}
+	slices.Reverse(path.Steps)
+	path.Degrees = uint(len(path.Steps))
+
+	err := r.fillPathNames(ctx, path.Steps)
+	if err != nil {
+		return schemas.ActorPathResponse{}, err
+	}
+
+	return path, nil
+}
+
+// fillPathNames sets names of actors and titles of films of the path.
+func (r *ActorRepo) fillPathNames(
+	ctx context.Context, steps []schemas.ActorPathStep,
+) error {
+	actorsIds := make([]uint, 0, len(steps)+1)
+	filmsIds := make([]uint, 0, len(steps))
+	for _, step := range steps {
+		actorsIds = append(actorsIds, step.FromActor.ID, step.ToActor.ID)
+		filmsIds = append(filmsIds, step.Film.ID)
+	}
+
+	names := make(map[uint]string, len(actorsIds))
+	rows, err := r.db.Query(`
+	SELECT actors.id, CONCAT_WS(' ', `+
+		translated("actors", "first_name", "$2")+`, `+
+		translated("actors", "last_name", "$2")+`)
+	FROM actors WHERE actors.id = ANY($1)
+	`, pq.Array(actorsIds), localesParam(ctx))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uint
+		var name string
+		if err = rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		names[id] = name
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	titles := make(map[uint]string, len(filmsIds))
+	rows2, err := r.db.Query(`
+	SELECT films.id, `+translated("films", "title", "$2")+`
+	FROM films WHERE films.id = ANY($1)
+	`, pq.Array(filmsIds), localesParam(ctx))
+	if err != nil {
+		return err
+	}
+	defer rows2.Close()
+
+	for rows2.Next() {
+		var id uint
+		var title string
+		if err = rows2.Scan(&id, &title); err != nil {
+			return err
+		}
+		titles[id] = title
+	}
+
+	if err = rows2.Err(); err != nil {
+		return err
+	}
+
+	for i := range steps {
+		steps[i].FromActor.Name = names[steps[i].FromActor.ID]
+		steps[i].ToActor.Name = names[steps[i].ToActor.ID]
+		steps[i].Film.Title = titles[steps[i].Film.ID]
+	}
+
+	return nil
+}
+
+// GetDuplicateCandidates returns actors born on the given day,
+// which the new actor may duplicate.
+func (r *ActorRepo) GetDuplicateCandidates(
+	ctx context.Context, birthday time.Time,
+) ([]schemas.DuplicateCandidate, error) {
+	rows, err := conn(ctx, r.db).Query(`
+	SELECT id, first_name || ' ' || last_name, birthday FROM actors
+	WHERE birthday = $1
+	ORDER BY id
+	`, birthday)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return actors, nil
+	return scanDuplicateCandidates(rows)
+}
+
+// Merge moves the films, nominations and translations of the source actor
+// to the target, copies the listed columns from the source and removes it.
+// The source id is recorded in actor_redirects, so that it keeps resolving
+// to the target.
+func (r *ActorRepo) Merge(
+	ctx context.Context, targetId uint, sourceId uint, columns []string,
+) (result schemas.MergeActorsResponse, err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return result, err
+	}
+	defer func() { err = end(err) }()
+
+	result.ID = targetId
+	result.SourceID = sourceId
+
+	rows, err := tx.Query(`
+	SELECT id FROM actors WHERE id = ANY($1) ORDER BY id FOR UPDATE
+	`, pq.Array([]uint{targetId, sourceId}))
+	if err != nil {
+		return result, err
+	}
+	found := make(map[uint]bool, 2)
+	for rows.Next() {
+		var id uint
+		if err = rows.Scan(&id); err != nil {
+			_ = rows.Close()
+			return result, err
+		}
+		found[id] = true
+	}
+	err = rows.Err()
+	_ = rows.Close()
+	if err != nil {
+		return result, err
+	}
+	for _, id := range []uint{targetId, sourceId} {
+		if !found[id] {
+			return result, &ErrRecordNotFound{
+				tableName: "actors",
+				identity:  fmt.Sprintf("%d", id),
+			}
+		}
+	}
+
+	if len(columns) > 0 {
+		assignments := make([]string, 0, len(columns))
+		for _, column := range columns {
+			assignments = append(assignments, fmt.Sprintf("%s = source.%s", column, column))
+		}
+		_, err = tx.Exec(`
+		UPDATE actors SET `+strings.Join(assignments, ", ")+`
+		FROM actors AS source
+		WHERE actors.id = $1 AND source.id = $2
+		`, targetId, sourceId)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	duplicates, err := tx.Exec(`
+	DELETE FROM actors_and_films
+	WHERE actor_id = $2 AND film_id IN (
+		SELECT film_id FROM actors_and_films WHERE actor_id = $1
+	)
+	`, targetId, sourceId)
+	if err != nil {
+		return result, err
+	}
+	result.DuplicateFilms, err = duplicates.RowsAffected()
+	if err != nil {
+		return result, err
+	}
+
+	moved, err := tx.Exec(`
+	UPDATE actors_and_films SET actor_id = $1 WHERE actor_id = $2
+	`, targetId, sourceId)
+	if err != nil {
+		return result, err
+	}
+	result.MovedFilms, err = moved.RowsAffected()
+	if err != nil {
+		return result, err
+	}
+
+	for _, stmt := range []string{
+		`UPDATE nominations SET actor_id = $1 WHERE actor_id = $2`,
+		`UPDATE actor_translations SET actor_id = $1
+		WHERE actor_id = $2 AND locale NOT IN (
+			SELECT locale FROM actor_translations WHERE actor_id = $1
+		)`,
+		`UPDATE actor_redirects SET target_id = $1 WHERE target_id = $2`,
+		`INSERT INTO actor_redirects (source_id, target_id) VALUES ($2, $1)`,
+		`DELETE FROM actors WHERE id = $2`,
+	} {
+		_, err = tx.Exec(stmt, targetId, sourceId)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Resolve returns the id of the actor the given id was merged into,
+// or the id itself if it was never merged.
+func (r *ActorRepo) Resolve(ctx context.Context, id uint) (uint, error) {
+	return resolveActor(conn(ctx, r.db), id)
+}
+
+func resolveActor(db querier, id uint) (uint, error) {
+	var resolved uint
+	err := db.QueryRow(`
+	SELECT COALESCE(
+		(SELECT target_id FROM actor_redirects WHERE source_id = $1), $1
+	)
+	`, id).Scan(&resolved)
+	if err != nil {
+		return 0, err
+	}
+
+	return resolved, nil
+}
+
+func (r *ActorRepo) checkExists(id uint) error {
+	var exists bool
+	err := r.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM actors WHERE id = $1)", id,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return &ErrRecordNotFound{
+			tableName: "actors",
+			identity:  fmt.Sprintf("%d", id),
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,224 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+func TestActorRepo_GetPath(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewActorRepo(db)
+
+	expectExists := func(id uint) {
+		mock.ExpectQuery("SELECT EXISTS").
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	}
+	linkColumns := []string{"costar", "actor", "film"}
+
+	t.Run("basic", func(t *testing.T) {
+		expectExists(1)
+		expectExists(3)
+		mock.ExpectQuery("SELECT DISTINCT ON").
+			WillReturnRows(sqlmock.NewRows(linkColumns).AddRow(2, 1, 10))
+		mock.ExpectQuery("SELECT DISTINCT ON").
+			WillReturnRows(sqlmock.NewRows(linkColumns).
+				AddRow(1, 2, 10).
+				AddRow(3, 2, 11))
+		mock.ExpectQuery("FROM actors WHERE").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).
+				AddRow(1, "Ryan Gosling").
+				AddRow(2, "Margot Robbie").
+				AddRow(3, "Leonardo DiCaprio"))
+		mock.ExpectQuery("FROM films WHERE").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).
+				AddRow(10, "Barbie").
+				AddRow(11, "The Wolf of Wall Street"))
+
+		want := schemas.ActorPathResponse{
+			Degrees: 2,
+			Steps: []schemas.ActorPathStep{
+				{
+					FromActor: schemas.ActorRef{ID: 1, Name: "Ryan Gosling"},
+					Film:      schemas.FilmRef{ID: 10, Title: "Barbie"},
+					ToActor:   schemas.ActorRef{ID: 2, Name: "Margot Robbie"},
+				},
+				{
+					FromActor: schemas.ActorRef{ID: 2, Name: "Margot Robbie"},
+					Film:      schemas.FilmRef{ID: 11, Title: "The Wolf of Wall Street"},
+					ToActor:   schemas.ActorRef{ID: 3, Name: "Leonardo DiCaprio"},
+				},
+			},
+		}
+
+		got, err := repo.GetPath(context.Background(), 1, 3, 6)
+		if err != nil {
+			t.Fatalf("ActorRepo.GetPath() error = %v", err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ActorRepo.GetPath() = %v, want %v", got, want)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("depth cap", func(t *testing.T) {
+		expectExists(1)
+		expectExists(3)
+		mock.ExpectQuery("SELECT DISTINCT ON").
+			WillReturnRows(sqlmock.NewRows(linkColumns).AddRow(2, 1, 10))
+
+		_, err := repo.GetPath(context.Background(), 1, 3, 1)
+		if !errors.Is(err, ErrPathNotFound) {
+			t.Errorf("ActorRepo.GetPath() error = %v, wantErr %v", err, ErrPathNotFound)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestActorRepo_Merge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewActorRepo(db)
+
+	t.Run("basic", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM actors").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mock.ExpectExec("UPDATE actors SET birthday = source.birthday").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM actors_and_films").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE actors_and_films SET actor_id").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec("UPDATE nominations").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE actor_translations").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("UPDATE actor_redirects").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("INSERT INTO actor_redirects").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("DELETE FROM actors WHERE").
+			WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		want := schemas.MergeActorsResponse{
+			ID:             1,
+			SourceID:       2,
+			MovedFilms:     3,
+			DuplicateFilms: 1,
+		}
+
+		got, err := repo.Merge(context.Background(), 1, 2, []string{"birthday"})
+		if err != nil {
+			t.Fatalf("ActorRepo.Merge() error = %v", err)
+		}
+
+		if got != want {
+			t.Errorf("ActorRepo.Merge() = %v, want %v", got, want)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id FROM actors").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectRollback()
+
+		_, err := repo.Merge(context.Background(), 1, 2, nil)
+		var notFoundErr *ErrRecordNotFound
+		if !errors.As(err, &notFoundErr) || notFoundErr.identity != "2" {
+			t.Errorf("expected ErrRecordNotFound of actor 2, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestActorRepo_GetListWithFilms(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewActorRepo(db)
+
+	t.Run("sparse fields", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT actors.id, actors.sex\s+FROM actors`).
+			WithArgs().
+			WillReturnRows(sqlmock.NewRows([]string{"id", "sex"}).AddRow(1, "male"))
+
+		options := schemas.ListOptions{Fields: []string{"sex"}}
+		got, err := repo.GetListWithFilms(context.Background(), options)
+		if err != nil {
+			t.Fatalf("ActorRepo.GetListWithFilms() error = %v", err)
+		}
+
+		want := []schemas.ActorWithFilmsResponse{{ID: 1, Sex: "male"}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ActorRepo.GetListWithFilms() = %v, want %v", got, want)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("included films", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT actors.id\s+FROM actors`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+		mock.ExpectQuery(`SELECT actors_and_films.actor_id, films.id, films.rating\s+FROM films`).
+			WillReturnRows(sqlmock.NewRows([]string{"actor_id", "id", "rating"}).AddRow(1, 10, 8))
+
+		options := schemas.ListOptions{
+			Fields:         []string{"id"},
+			Include:        true,
+			IncludedFields: []string{"rating"},
+		}
+		got, err := repo.GetListWithFilms(context.Background(), options)
+		if err != nil {
+			t.Fatalf("ActorRepo.GetListWithFilms() error = %v", err)
+		}
+
+		if len(got) != 2 || len(got[0].Films) != 1 || got[0].Films[0].Rating != 8 {
+			t.Fatalf("ActorRepo.GetListWithFilms() = %v, want the film of the first actor", got)
+		}
+		if got[1].Films == nil || len(got[1].Films) != 0 {
+			t.Errorf("ActorRepo.GetListWithFilms() films = %v, want empty", got[1].Films)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type AwardRepo struct {
+	db *sql.DB
+}
+
+func NewAwardRepo(db *sql.DB) *AwardRepo {
+	return &AwardRepo{
+		db: db,
+	}
+}
+
+func (r *AwardRepo) CreateCeremony(
+	_ context.Context, ceremony *models.AwardCeremony,
+) error {
+	stmt := `
+	INSERT INTO award_ceremonies (name)
+	VALUES ($1)
+	RETURNING id;
+	`
+	err := r.db.QueryRow(stmt, ceremony.Name).Scan(&ceremony.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "uniq_award_ceremonies_name") {
+			return &ErrRecordAlreadyExists{
+				tableName: "award_ceremonies",
+				identity:  ceremony.Name,
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *AwardRepo) UpdateCeremony(
+	_ context.Context, id uint, updates map[string]any,
+) error {
+	err := updateRecord(r.db, "award_ceremonies", id, updates)
+	if err != nil && strings.Contains(err.Error(), "uniq_award_ceremonies_name") {
+		return &ErrRecordAlreadyExists{
+			tableName: "award_ceremonies",
+			identity:  fmt.Sprintf("%v", updates["name"]),
+		}
+	}
+
+	return err
+}
+
+func (r *AwardRepo) RemoveCeremony(_ context.Context, id uint) error {
+	return removeRecord(r.db, "award_ceremonies", id)
+}
+
+// GetCeremonies returns ceremonies with their categories.
+func (r *AwardRepo) GetCeremonies(
+	_ context.Context,
+) ([]schemas.CeremonyInfo, error) {
+	stmt := `
+	SELECT award_ceremonies.id, award_ceremonies.name,
+		award_categories.id, award_categories.name
+	FROM award_ceremonies
+	LEFT JOIN award_categories
+		ON award_ceremonies.id = award_categories.ceremony_id
+	ORDER BY award_ceremonies.name, award_categories.name
+	`
+
+	rows, err := r.db.Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ceremonies := make([]schemas.CeremonyInfo, 0)
+	for rows.Next() {
+		var ceremony models.AwardCeremony
+		var categoryId sql.NullInt64
+		var categoryName sql.NullString
+		err = rows.Scan(
+			&ceremony.ID,
+			&ceremony.Name,
+			&categoryId,
+			&categoryName,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		last := len(ceremonies) - 1
+		if last < 0 || ceremonies[last].ID != ceremony.ID {
+			ceremonies = append(ceremonies, schemas.NewCeremonyInfo(ceremony))
+			last++
+		}
+
+		if categoryId.Valid {
+			ceremonies[last].Categories = append(
+				ceremonies[last].Categories,
+				schemas.CategoryInfo{
+					ID:   uint(categoryId.Int64),
+					Name: categoryName.String,
+				},
+			)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ceremonies, nil
+}
+
+func (r *AwardRepo) CreateCategory(
+	_ context.Context, category *models.AwardCategory,
+) error {
+	stmt := `
+	INSERT INTO award_categories (ceremony_id, name)
+	VALUES ($1, $2)
+	RETURNING id;
+	`
+	err := r.db.QueryRow(
+		stmt, category.CeremonyID, category.Name,
+	).Scan(&category.ID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "violates foreign key constraint"):
+			return &ErrRecordNotFound{
+				tableName: "award_ceremonies",
+				identity:  fmt.Sprintf("%d", category.CeremonyID),
+			}
+		case strings.Contains(err.Error(), "uniq_award_categories_name"):
+			return &ErrRecordAlreadyExists{
+				tableName: "award_categories",
+				identity:  category.Name,
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *AwardRepo) RemoveCategory(_ context.Context, id uint) error {
+	return removeRecord(r.db, "award_categories", id)
+}
+
+func (r *AwardRepo) CreateNomination(
+	_ context.Context, nomination *models.Nomination,
+) error {
+	stmt := `
+	INSERT INTO nominations (category_id, year, film_id, actor_id, won)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id;
+	`
+	err := r.db.QueryRow(
+		stmt,
+		nomination.CategoryID,
+		nomination.Year,
+		nomination.FilmID,
+		nomination.ActorID,
+		nomination.Won,
+	).Scan(&nomination.ID)
+	if err != nil {
+		return nominationError(err, nomination)
+	}
+
+	return nil
+}
+
+func (r *AwardRepo) UpdateNomination(
+	_ context.Context, id uint, updates map[string]any,
+) error {
+	return updateRecord(r.db, "nominations", id, updates)
+}
+
+func (r *AwardRepo) RemoveNomination(_ context.Context, id uint) error {
+	return removeRecord(r.db, "nominations", id)
+}
+
+// GetNominations returns nominations ordered by year and ceremony.
+func (r *AwardRepo) GetNominations(
+	_ context.Context, filter schemas.NominationsFilter,
+) ([]schemas.NominationInfo, error) {
+	builder := strings.Builder{}
+	builder.WriteString(`
+	SELECT nominations.id, award_ceremonies.id, award_ceremonies.name,
+		award_categories.id, award_categories.name, nominations.year,
+		nominations.won, films.id, films.title, actors.id,
+		CONCAT_WS(' ', actors.first_name, actors.last_name)
+	FROM nominations
+	INNER JOIN award_categories ON nominations.category_id = award_categories.id
+	INNER JOIN award_ceremonies ON award_categories.ceremony_id = award_ceremonies.id
+	LEFT JOIN films ON nominations.film_id = films.id
+	LEFT JOIN actors ON nominations.actor_id = actors.id
+	`)
+
+	conditions := make([]string, 0, 5)
+	args := make([]any, 0, 5)
+	addCondition := func(column string, value *uint) {
+		if value == nil {
+			return
+		}
+		args = append(args, *value)
+		conditions = append(conditions, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+	addCondition("nominations.id", filter.ID)
+	addCondition("award_ceremonies.id", filter.CeremonyID)
+	addCondition("nominations.year", filter.Year)
+	addCondition("nominations.film_id", filter.FilmID)
+	addCondition("nominations.actor_id", filter.ActorID)
+
+	if len(conditions) > 0 {
+		builder.WriteString(" WHERE ")
+		builder.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	builder.WriteString(`
+	ORDER BY nominations.year DESC, award_ceremonies.name,
+		award_categories.name, nominations.won DESC
+	`)
+
+	rows, err := r.db.Query(builder.String(), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nominations := make([]schemas.NominationInfo, 0)
+	for rows.Next() {
+		var nomination schemas.NominationInfo
+		var actorName sql.NullString
+		err = rows.Scan(
+			&nomination.ID,
+			&nomination.CeremonyID,
+			&nomination.Ceremony,
+			&nomination.CategoryID,
+			&nomination.Category,
+			&nomination.Year,
+			&nomination.Won,
+			&nomination.FilmID,
+			&nomination.FilmTitle,
+			&nomination.ActorID,
+			&actorName,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if nomination.ActorID != nil {
+			nomination.ActorName = &actorName.String
+		}
+
+		nominations = append(nominations, nomination)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return nominations, nil
+}
+
+func nominationError(err error, nomination *models.Nomination) error {
+	switch {
+	case strings.Contains(err.Error(), "nominations_category_id_fkey"):
+		return &ErrRecordNotFound{
+			tableName: "award_categories",
+			identity:  fmt.Sprintf("%d", nomination.CategoryID),
+		}
+	case strings.Contains(err.Error(), "nominations_film_id_fkey"):
+		return &ErrRecordNotFound{
+			tableName: "films",
+			identity:  fmt.Sprintf("%d", *nomination.FilmID),
+		}
+	case strings.Contains(err.Error(), "nominations_actor_id_fkey"):
+		return &ErrRecordNotFound{
+			tableName: "actors",
+			identity:  fmt.Sprintf("%d", *nomination.ActorID),
+		}
+	default:
+		return err
+	}
+}
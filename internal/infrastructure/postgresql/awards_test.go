@@ -0,0 +1,51 @@
+package postgresql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+func TestAwardRepo_GetCeremonies(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewAwardRepo(db)
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "id", "name"}).
+			AddRow(1, "Academy Awards", 1, "Best Actor").
+			AddRow(1, "Academy Awards", 2, "Best Picture").
+			AddRow(2, "Cannes Film Festival", nil, nil))
+
+	want := []schemas.CeremonyInfo{
+		{
+			ID:   1,
+			Name: "Academy Awards",
+			Categories: []schemas.CategoryInfo{
+				{ID: 1, Name: "Best Actor"},
+				{ID: 2, Name: "Best Picture"},
+			},
+		},
+		{
+			ID:         2,
+			Name:       "Cannes Film Festival",
+			Categories: []schemas.CategoryInfo{},
+		},
+	}
+
+	got, err := repo.GetCeremonies(context.Background())
+	if err != nil {
+		t.Fatalf("AwardRepo.GetCeremonies() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AwardRepo.GetCeremonies() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+// CacheStore keeps the cached values in the unlogged table shared by the
+// instances of the application. The values are read and written outside
+// of the transactions of the context.
+type CacheStore struct {
+	db *sql.DB
+}
+
+func NewCacheStore(db *sql.DB) *CacheStore {
+	return &CacheStore{
+		db: db,
+	}
+}
+
+func (r *CacheStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var value []byte
+	err := r.db.QueryRowContext(ctx, `
+	SELECT value FROM cache_entries
+	WHERE key = $1 AND (expires_at IS NULL OR expires_at > now())
+	`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func (r *CacheStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expires *time.Time
+	if ttl > 0 {
+		at := time.Now().Add(ttl)
+		expires = &at
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+	INSERT INTO cache_entries (key, value, expires_at) VALUES ($1, $2, $3)
+	ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, expires_at = EXCLUDED.expires_at
+	`, key, value, expires)
+	return err
+}
+
+// DeletePrefix deletes the values with the key prefix along with the
+// expired values of any key.
+func (r *CacheStore) DeletePrefix(ctx context.Context, prefix string) error {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+
+	_, err := r.db.ExecContext(ctx, `
+	DELETE FROM cache_entries
+	WHERE key LIKE $1 || '%' OR expires_at <= now()
+	`, escaped)
+	return err
+}
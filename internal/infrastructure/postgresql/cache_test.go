@@ -0,0 +1,56 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestCacheStore(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	store := NewCacheStore(db)
+	ctx := context.Background()
+
+	t.Run("miss", func(t *testing.T) {
+		mock.ExpectQuery("SELECT value FROM cache_entries").
+			WithArgs("catalog:films").
+			WillReturnError(sql.ErrNoRows)
+
+		_, found, err := store.Get(ctx, "catalog:films")
+		if err != nil || found {
+			t.Errorf("CacheStore.Get() = %v, %v, want a miss", found, err)
+		}
+	})
+
+	t.Run("set", func(t *testing.T) {
+		mock.ExpectExec("INSERT INTO cache_entries").
+			WithArgs("catalog@state", []byte("{}"), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		if err := store.Set(ctx, "catalog@state", []byte("{}"), time.Minute); err != nil {
+			t.Errorf("CacheStore.Set() error = %v", err)
+		}
+	})
+
+	t.Run("delete prefix", func(t *testing.T) {
+		mock.ExpectExec("DELETE FROM cache_entries").
+			WithArgs(`cata\_log:`).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		if err := store.DeletePrefix(ctx, "cata_log:"); err != nil {
+			t.Errorf("CacheStore.DeletePrefix() error = %v", err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
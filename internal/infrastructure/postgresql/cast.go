@@ -0,0 +1,326 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type CastRepo struct {
+	db *sql.DB
+}
+
+func NewCastRepo(db *sql.DB) *CastRepo {
+	return &CastRepo{
+		db: db,
+	}
+}
+
+func (r *CastRepo) GetFilmActors(
+	ctx context.Context, filmId uint,
+) ([]schemas.ActorInfo, error) {
+	db := conn(ctx, r.db)
+	if err := checkRecord(db, "films", filmId); err != nil {
+		return nil, err
+	}
+
+	stmt := `
+	SELECT actors.id, ` +
+		translated("actors", "first_name", "$2") + `, ` +
+		translated("actors", "last_name", "$2") + `, ` +
+		translated("actors", "middle_name", "$2") + `,
+		actors.sex, actors.birthday, ` +
+		imagesSubquery(models.ActorImage, "actors.id") + `
+	FROM actors
+	INNER JOIN actors_and_films ON actors.id = actors_and_films.actor_id
+	WHERE actors_and_films.film_id = $1
+	ORDER BY actors.last_name, actors.first_name, actors.id
+	`
+	rows, err := db.Query(stmt, filmId, localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	actors := make([]schemas.ActorInfo, 0)
+	for rows.Next() {
+		var actor schemas.ActorInfo
+		var date time.Time
+		var headshot []byte
+		err = rows.Scan(
+			&actor.ID,
+			&actor.FirstName,
+			&actor.LastName,
+			&actor.MiddleName,
+			&actor.Sex,
+			&date,
+			&headshot,
+		)
+		if err != nil {
+			return nil, err
+		}
+		actor.Birthday = schemas.NewDate(date)
+
+		actor.Headshot, err = parseImages(headshot)
+		if err != nil {
+			return nil, err
+		}
+
+		actors = append(actors, actor)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return actors, nil
+}
+
+func (r *CastRepo) GetActorFilms(
+	ctx context.Context, actorId uint,
+) ([]schemas.FilmInfo, error) {
+	db := conn(ctx, r.db)
+	if err := checkRecord(db, "actors", actorId); err != nil {
+		return nil, err
+	}
+
+	stmt := `
+	SELECT ` + filmInfoColumns("$2") + `
+	FROM films
+	INNER JOIN actors_and_films ON films.id = actors_and_films.film_id
+	WHERE actors_and_films.actor_id = $1
+	ORDER BY films.release_date, films.id
+	`
+	rows, err := db.Query(stmt, actorId, localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFilmInfos(rows)
+}
+
+// AddLink adds the actor to the cast of the film.
+func (r *CastRepo) AddLink(
+	ctx context.Context, actorId uint, filmId uint,
+) (err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
+
+	_, err = tx.Exec(`
+	INSERT INTO actors_and_films (actor_id, film_id) VALUES ($1, $2)
+	`, actorId, filmId)
+	if err != nil {
+		return castError(err, actorId, filmId)
+	}
+
+	return writeOutbox(tx, models.FilmUpdated, filmId)
+}
+
+// RemoveLink removes the actor from the cast of the film. The error
+// names the missing actor or film if there is no link because of it.
+func (r *CastRepo) RemoveLink(
+	ctx context.Context, actorId uint, filmId uint,
+) (err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
+
+	result, err := tx.Exec(`
+	DELETE FROM actors_and_films WHERE actor_id = $1 AND film_id = $2
+	`, actorId, filmId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		if err = checkRecord(tx, "films", filmId); err != nil {
+			return err
+		}
+		if err = checkRecord(tx, "actors", actorId); err != nil {
+			return err
+		}
+		return &ErrRecordNotFound{
+			tableName: "actors_and_films",
+			identity:  fmt.Sprintf("%d, %d", actorId, filmId),
+		}
+	}
+
+	return writeOutbox(tx, models.FilmUpdated, filmId)
+}
+
+// SetFilmActors replaces the cast of the film and returns the actors
+// added or removed. The film row is locked, so that concurrent
+// replacements do not interleave.
+func (r *CastRepo) SetFilmActors(
+	ctx context.Context, filmId uint, actorsIds []uint,
+) ([]uint, error) {
+	return r.setLinks(ctx, "films", "film_id", "actor_id", filmId, actorsIds)
+}
+
+// SetActorFilms replaces the films the actor starred in and returns the
+// films added or removed. The actor row is locked, so that concurrent
+// replacements do not interleave.
+func (r *CastRepo) SetActorFilms(
+	ctx context.Context, actorId uint, filmsIds []uint,
+) ([]uint, error) {
+	return r.setLinks(ctx, "actors", "actor_id", "film_id", actorId, filmsIds)
+}
+
+// setLinks replaces the links of the owner and returns the linked ids
+// whose links were added or removed. The changed films are written to
+// the webhook outbox.
+func (r *CastRepo) setLinks(
+	ctx context.Context,
+	ownerTable string,
+	ownerColumn string,
+	linkedColumn string,
+	ownerId uint,
+	linkedIds []uint,
+) (changedIds []uint, err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = end(err) }()
+
+	var id uint
+	err = tx.QueryRow(
+		fmt.Sprintf("SELECT id FROM %s WHERE id = $1 FOR UPDATE", ownerTable),
+		ownerId,
+	).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &ErrRecordNotFound{
+				tableName: ownerTable,
+				identity:  fmt.Sprintf("%d", ownerId),
+			}
+		}
+		return nil, err
+	}
+
+	rows, err := tx.Query(fmt.Sprintf(
+		"DELETE FROM actors_and_films WHERE %s = $1 AND NOT (%s = ANY($2)) RETURNING %s",
+		ownerColumn, linkedColumn, linkedColumn,
+	), ownerId, pq.Array(linkedIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changedIds = make([]uint, 0)
+	for rows.Next() {
+		var linkedId uint
+		if err = rows.Scan(&linkedId); err != nil {
+			return nil, err
+		}
+
+		changedIds = append(changedIds, linkedId)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, linkedId := range linkedIds {
+		var result sql.Result
+		result, err = tx.Exec(fmt.Sprintf(`
+		INSERT INTO actors_and_films (%s, %s) VALUES ($1, $2)
+		ON CONFLICT ON CONSTRAINT uniq_actor_films DO NOTHING
+		`, ownerColumn, linkedColumn), ownerId, linkedId)
+		if err != nil {
+			if ownerColumn == "film_id" {
+				return nil, castError(err, linkedId, ownerId)
+			}
+			return nil, castError(err, ownerId, linkedId)
+		}
+
+		var rowsAffected int64
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if rowsAffected > 0 {
+			changedIds = append(changedIds, linkedId)
+		}
+	}
+
+	if ownerColumn == "film_id" {
+		if len(changedIds) > 0 {
+			err = writeOutbox(tx, models.FilmUpdated, ownerId)
+		}
+		return changedIds, err
+	}
+
+	for _, filmId := range changedIds {
+		if err = writeOutbox(tx, models.FilmUpdated, filmId); err != nil {
+			return nil, err
+		}
+	}
+
+	return changedIds, nil
+}
+
+// ResolveActor returns the id of the actor the given id was merged into.
+func (r *CastRepo) ResolveActor(ctx context.Context, id uint) (uint, error) {
+	return resolveActor(conn(ctx, r.db), id)
+}
+
+// castError converts constraint violations of actors_and_films.
+func castError(err error, actorId uint, filmId uint) error {
+	switch {
+	case strings.Contains(err.Error(), "uniq_actor_films"):
+		return &ErrRecordAlreadyExists{
+			tableName: "actors_and_films",
+			identity:  fmt.Sprintf("%d, %d", actorId, filmId),
+		}
+	case strings.Contains(err.Error(), "actors_and_films_actor_id_fkey"):
+		return &ErrRecordNotFound{
+			tableName: "actors",
+			identity:  fmt.Sprintf("%d", actorId),
+		}
+	case strings.Contains(err.Error(), "actors_and_films_film_id_fkey"):
+		return &ErrRecordNotFound{
+			tableName: "films",
+			identity:  fmt.Sprintf("%d", filmId),
+		}
+	default:
+		return err
+	}
+}
+
+// checkRecord returns ErrRecordNotFound if the table has no row with id.
+func checkRecord(db querier, table string, id uint) error {
+	var exists bool
+	err := db.QueryRow(
+		fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE id = $1)", table), id,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return &ErrRecordNotFound{
+			tableName: table,
+			identity:  fmt.Sprintf("%d", id),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+func TestCastRepo_AddLink(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewCastRepo(db)
+	outbox := regexp.QuoteMeta("INSERT INTO webhook_outbox (type, film_id) VALUES ($1, $2)")
+	query := regexp.QuoteMeta("INSERT INTO actors_and_films (actor_id, film_id) VALUES ($1, $2)")
+
+	t.Run("basic", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(outbox).WithArgs(models.FilmUpdated, 2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.AddLink(context.Background(), 1, 2); err != nil {
+			t.Errorf("error was not expected while adding link: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(1, 2).
+			WillReturnError(errors.New(`duplicate key value violates unique constraint "uniq_actor_films"`))
+		mock.ExpectRollback()
+
+		err := repo.AddLink(context.Background(), 1, 2)
+		var existsErr *ErrRecordAlreadyExists
+		if !errors.As(err, &existsErr) {
+			t.Errorf("expected ErrRecordAlreadyExists, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("missing film", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(1, 2).
+			WillReturnError(errors.New(`violates foreign key constraint "actors_and_films_film_id_fkey"`))
+		mock.ExpectRollback()
+
+		err := repo.AddLink(context.Background(), 1, 2)
+		var notFoundErr *ErrRecordNotFound
+		if !errors.As(err, &notFoundErr) || notFoundErr.tableName != "films" {
+			t.Errorf("expected films ErrRecordNotFound, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestCastRepo_RemoveLink(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewCastRepo(db)
+	outbox := regexp.QuoteMeta("INSERT INTO webhook_outbox (type, film_id) VALUES ($1, $2)")
+	query := regexp.QuoteMeta("DELETE FROM actors_and_films WHERE actor_id = $1 AND film_id = $2")
+
+	t.Run("basic", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(outbox).WithArgs(models.FilmUpdated, 2).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		if err := repo.RemoveLink(context.Background(), 1, 2); err != nil {
+			t.Errorf("error was not expected while removing link: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("missing film", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM films WHERE id = $1)")).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		err := repo.RemoveLink(context.Background(), 1, 2)
+		var notFoundErr *ErrRecordNotFound
+		if !errors.As(err, &notFoundErr) || notFoundErr.tableName != "films" {
+			t.Errorf("expected films ErrRecordNotFound, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("missing link", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(query).WithArgs(1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM films WHERE id = $1)")).
+			WithArgs(2).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM actors WHERE id = $1)")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+
+		err := repo.RemoveLink(context.Background(), 1, 2)
+		var notFoundErr *ErrRecordNotFound
+		if !errors.As(err, &notFoundErr) || notFoundErr.tableName != "actors_and_films" {
+			t.Errorf("expected actors_and_films ErrRecordNotFound, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestCastRepo_SetActorFilms(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewCastRepo(db)
+	outbox := regexp.QuoteMeta("INSERT INTO webhook_outbox (type, film_id) VALUES ($1, $2)")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM actors WHERE id = $1 FOR UPDATE")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM actors_and_films")).
+		WithArgs(1, pq.Array([]uint{2, 3})).
+		WillReturnRows(sqlmock.NewRows([]string{"film_id"}).AddRow(4))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors_and_films")).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors_and_films")).
+		WithArgs(1, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(outbox).WithArgs(models.FilmUpdated, 4).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(outbox).WithArgs(models.FilmUpdated, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	changed, err := repo.SetActorFilms(context.Background(), 1, []uint{2, 3})
+	if err != nil {
+		t.Fatalf("error was not expected while setting films: %s", err)
+	}
+	if !slices.Equal(changed, []uint{4, 3}) {
+		t.Errorf("changed films = %v, want [4 3]", changed)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
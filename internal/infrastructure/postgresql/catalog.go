@@ -0,0 +1,179 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+// CatalogRepo reads films and actors in batches for the GraphQL API.
+type CatalogRepo struct {
+	db *sql.DB
+}
+
+func NewCatalogRepo(db *sql.DB) *CatalogRepo {
+	return &CatalogRepo{
+		db: db,
+	}
+}
+
+func (r *CatalogRepo) GetFilms(
+	ctx context.Context, limit uint, offset uint,
+) ([]schemas.FilmInfo, error) {
+	rows, err := r.db.Query(`
+	SELECT `+filmInfoColumns("$3")+`
+	FROM films
+	ORDER BY films.id
+	LIMIT $1 OFFSET $2
+	`, limit, offset, localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFilmInfos(rows)
+}
+
+func (r *CatalogRepo) GetFilmsByIds(
+	ctx context.Context, ids []uint,
+) ([]schemas.FilmInfo, error) {
+	rows, err := r.db.Query(`
+	SELECT `+filmInfoColumns("$2")+`
+	FROM films
+	WHERE films.id = ANY($1)
+	ORDER BY films.id
+	`, pq.Array(ids), localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFilmInfos(rows)
+}
+
+func (r *CatalogRepo) GetActors(
+	ctx context.Context, limit uint, offset uint,
+) ([]schemas.ActorInfo, error) {
+	rows, err := r.db.Query(`
+	SELECT `+actorInfoColumns("$3")+`
+	FROM actors
+	ORDER BY actors.id
+	LIMIT $1 OFFSET $2
+	`, limit, offset, localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanActorInfos(rows)
+}
+
+func (r *CatalogRepo) GetActorsByIds(
+	ctx context.Context, ids []uint,
+) ([]schemas.ActorInfo, error) {
+	rows, err := r.db.Query(`
+	SELECT `+actorInfoColumns("$2")+`
+	FROM actors
+	WHERE actors.id = ANY($1)
+	ORDER BY actors.id
+	`, pq.Array(ids), localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanActorInfos(rows)
+}
+
+// GetCast returns the actors of each of the films keyed by the film id.
+func (r *CatalogRepo) GetCast(
+	ctx context.Context, filmsIds []uint,
+) (map[uint][]schemas.ActorInfo, error) {
+	return getCast(ctx, r.db, filmsIds, allFields)
+}
+
+// GetFilmographies returns the films of each of the actors keyed by the
+// actor id and ordered by the release date.
+func (r *CatalogRepo) GetFilmographies(
+	ctx context.Context, actorsIds []uint,
+) (map[uint][]schemas.FilmInfo, error) {
+	return getFilmographies(ctx, r.db, actorsIds, allFields)
+}
+
+// CountCast returns the number of actors of all the films.
+func (r *CatalogRepo) CountCast(ctx context.Context, filmsIds []uint) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+	SELECT count(*) FROM actors_and_films WHERE film_id = ANY($1)
+	`, pq.Array(filmsIds)).Scan(&count)
+
+	return count, err
+}
+
+// CountFilmographies returns the number of films of all the actors.
+func (r *CatalogRepo) CountFilmographies(ctx context.Context, actorsIds []uint) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `
+	SELECT count(*) FROM actors_and_films WHERE actor_id = ANY($1)
+	`, pq.Array(actorsIds)).Scan(&count)
+
+	return count, err
+}
+
+// actorInfoColumns returns the select list of schemas.ActorInfo with names
+// translated to the locales parameter.
+func actorInfoColumns(locales string) string {
+	return `actors.id, ` +
+		translated("actors", "first_name", locales) + `, ` +
+		translated("actors", "last_name", locales) + `, ` +
+		translated("actors", "middle_name", locales) + `,
+		actors.sex, actors.birthday, ` +
+		imagesSubquery(models.ActorImage, "actors.id")
+}
+
+// scanActorInfos reads the actors selected with actorInfoColumns.
+func scanActorInfos(rows *sql.Rows) ([]schemas.ActorInfo, error) {
+	actors := make([]schemas.ActorInfo, 0)
+	for rows.Next() {
+		var actor schemas.ActorInfo
+		var date time.Time
+		var headshot []byte
+		err := rows.Scan(actorInfoDest(&actor, &date, &headshot)...)
+		if err != nil {
+			return nil, err
+		}
+		actor.Birthday = schemas.NewDate(date)
+
+		actor.Headshot, err = parseImages(headshot)
+		if err != nil {
+			return nil, err
+		}
+
+		actors = append(actors, actor)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return actors, nil
+}
+
+// actorInfoDest returns the scan destinations of actorInfoColumns.
+func actorInfoDest(
+	actor *schemas.ActorInfo, date *time.Time, headshot *[]byte,
+) []any {
+	return []any{
+		&actor.ID,
+		&actor.FirstName,
+		&actor.LastName,
+		&actor.MiddleName,
+		&actor.Sex,
+		date,
+		headshot,
+	}
+}
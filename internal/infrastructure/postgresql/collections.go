@@ -0,0 +1,372 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type CollectionRepo struct {
+	db *sql.DB
+}
+
+func NewCollectionRepo(db *sql.DB) *CollectionRepo {
+	return &CollectionRepo{
+		db: db,
+	}
+}
+
+func (r *CollectionRepo) Create(
+	_ context.Context, collection *models.Collection, filmsIds ...uint,
+) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	stmt := `
+	INSERT INTO collections (title, description, visibility, owner_id)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id;
+	`
+	err = tx.QueryRow(
+		stmt,
+		collection.Title,
+		collection.Description,
+		collection.Visibility,
+		collection.OwnerID,
+	).Scan(&collection.ID)
+	if err != nil {
+		return err
+	}
+
+	for i, filmId := range filmsIds {
+		stmt = `
+		INSERT INTO collection_items (collection_id, film_id, position)
+		VALUES ($1, $2, $3);
+		`
+		_, err = tx.Exec(stmt, collection.ID, filmId, i+1)
+		if err != nil {
+			return collectionItemError(err, collection.ID, filmId)
+		}
+	}
+
+	return nil
+}
+
+func (r *CollectionRepo) Get(_ context.Context, id uint) (models.Collection, error) {
+	stmt := `
+	SELECT id, title, description, visibility, owner_id
+	FROM collections WHERE id = $1
+	`
+	row := r.db.QueryRow(stmt, id)
+
+	var collection models.Collection
+	err := row.Scan(
+		&collection.ID,
+		&collection.Title,
+		&collection.Description,
+		&collection.Visibility,
+		&collection.OwnerID,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return collection, &ErrRecordNotFound{
+				tableName: "collections",
+				identity:  fmt.Sprintf("%d", id),
+			}
+		}
+		return collection, err
+	}
+
+	return collection, nil
+}
+
+func (r *CollectionRepo) Update(
+	_ context.Context, id uint, updates map[string]any,
+) error {
+	return updateRecord(r.db, "collections", id, updates)
+}
+
+func (r *CollectionRepo) Remove(_ context.Context, id uint) error {
+	return removeRecord(r.db, "collections", id)
+}
+
+// GetList returns public collections and the private ones owned by
+// the given user. Admins see every collection.
+func (r *CollectionRepo) GetList(
+	_ context.Context, user models.User,
+) ([]schemas.CollectionInfo, error) {
+	stmt := `
+	SELECT id, title, description, visibility, owner_id
+	FROM collections
+	WHERE visibility = $1 OR owner_id = $2 OR $3
+	ORDER BY id
+	`
+
+	rows, err := r.db.Query(stmt, models.Public, user.ID, user.IsAdmin)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	collections := make([]schemas.CollectionInfo, 0)
+	for rows.Next() {
+		var collection schemas.CollectionInfo
+		err = rows.Scan(
+			&collection.ID,
+			&collection.Title,
+			&collection.Description,
+			&collection.Visibility,
+			&collection.OwnerID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		collections = append(collections, collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// GetFilms returns films of the collection ordered by their position.
+func (r *CollectionRepo) GetFilms(
+	ctx context.Context, id uint,
+) ([]schemas.FilmInfo, error) {
+	stmt := `
+	SELECT ` + filmInfoColumns("$2") + `
+	FROM films
+	INNER JOIN collection_items ON films.id = collection_items.film_id
+	WHERE collection_items.collection_id = $1
+	ORDER BY collection_items.position
+	`
+
+	rows, err := r.db.Query(stmt, id, localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFilmInfos(rows)
+}
+
+// AddFilm inserts the film into the collection at the given 1-based
+// position, shifting the following items down. A nil or out of range
+// position appends the film to the end of the collection.
+func (r *CollectionRepo) AddFilm(
+	_ context.Context, id uint, filmId uint, position *uint,
+) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// the collection row is locked so that concurrent appends get
+	// different positions
+	err = tx.QueryRow(`
+	SELECT id FROM collections WHERE id = $1 FOR UPDATE
+	`, id).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &ErrRecordNotFound{
+				tableName: "collections",
+				identity:  fmt.Sprintf("%d", id),
+			}
+		}
+		return err
+	}
+
+	var count uint
+	err = tx.QueryRow(`
+	SELECT COUNT(*) FROM collection_items WHERE collection_id = $1
+	`, id).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	pos := count + 1
+	if position != nil && *position > 0 && *position <= count {
+		pos = *position
+
+		_, err = tx.Exec(`
+		UPDATE collection_items SET position = position + 1
+		WHERE collection_id = $1 AND position >= $2
+		`, id, pos)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO collection_items (collection_id, film_id, position)
+	VALUES ($1, $2, $3);
+	`, id, filmId, pos)
+	if err != nil {
+		return collectionItemError(err, id, filmId)
+	}
+
+	return nil
+}
+
+// RemoveFilm removes the film from the collection and closes the gap
+// in items positions.
+func (r *CollectionRepo) RemoveFilm(
+	_ context.Context, id uint, filmId uint,
+) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	result, err := tx.Exec(`
+	DELETE FROM collection_items
+	WHERE collection_id = $1 AND film_id = $2
+	`, id, filmId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &ErrRecordNotFound{
+			tableName: "collection_items",
+			identity:  fmt.Sprintf("%d", filmId),
+		}
+	}
+
+	err = compactCollectionPositions(tx, id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Reorder atomically replaces the order of collection items. The given
+// films must match the current collection content exactly.
+func (r *CollectionRepo) Reorder(
+	_ context.Context, id uint, filmsIds []uint,
+) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	rows, err := tx.Query(`
+	SELECT film_id FROM collection_items
+	WHERE collection_id = $1
+	FOR UPDATE
+	`, id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var currentIds []uint
+	for rows.Next() {
+		var filmId uint
+		if err = rows.Scan(&filmId); err != nil {
+			return err
+		}
+
+		currentIds = append(currentIds, filmId)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	sortedIds := slices.Clone(filmsIds)
+	slices.Sort(sortedIds)
+	slices.Sort(currentIds)
+	if !slices.Equal(sortedIds, currentIds) {
+		return ErrItemsMismatch
+	}
+
+	for i, filmId := range filmsIds {
+		_, err = tx.Exec(`
+		UPDATE collection_items SET position = $1
+		WHERE collection_id = $2 AND film_id = $3
+		`, i+1, id, filmId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compactCollectionPositions renumbers collection items so that their
+// positions go from 1 without gaps, keeping the current order.
+func compactCollectionPositions(tx *sql.Tx, collectionId uint) error {
+	_, err := tx.Exec(`
+	UPDATE collection_items AS ci SET position = ordered.num
+	FROM (
+		SELECT id, ROW_NUMBER() OVER (ORDER BY position) AS num
+		FROM collection_items
+		WHERE collection_id = $1
+	) AS ordered
+	WHERE ci.id = ordered.id
+	`, collectionId)
+
+	return err
+}
+
+func collectionItemError(err error, collectionId uint, filmId uint) error {
+	switch {
+	case strings.Contains(err.Error(), "violates foreign key constraint"):
+		return &ErrRecordNotFound{
+			tableName: "films",
+			identity:  fmt.Sprintf("%d", filmId),
+		}
+	case strings.Contains(err.Error(), "uniq_collection_films"):
+		return &ErrRecordAlreadyExists{
+			tableName: "collection_items",
+			identity:  fmt.Sprintf("%d, %d", collectionId, filmId),
+		}
+	default:
+		return err
+	}
+}
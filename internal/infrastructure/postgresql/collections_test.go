@@ -0,0 +1,121 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestCollectionRepo_Reorder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewCollectionRepo(db)
+
+	type args struct {
+		context  context.Context
+		id       uint
+		filmsIds []uint
+	}
+
+	type mockBehavior func(args args)
+
+	tests := []struct {
+		name         string
+		args         args
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "basic",
+			args: args{
+				context:  context.Background(),
+				id:       1,
+				filmsIds: []uint{3, 1, 2},
+			},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT film_id FROM collection_items").
+					WithArgs(args.id).
+					WillReturnRows(sqlmock.NewRows([]string{"film_id"}).
+						AddRow(1).AddRow(2).AddRow(3))
+				for i, filmId := range args.filmsIds {
+					mock.ExpectExec("UPDATE collection_items").
+						WithArgs(i+1, args.id, filmId).
+						WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+		{
+			name: "films mismatch",
+			args: args{
+				context:  context.Background(),
+				id:       1,
+				filmsIds: []uint{1, 4},
+			},
+			mockBehavior: func(args args) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT film_id FROM collection_items").
+					WithArgs(args.id).
+					WillReturnRows(sqlmock.NewRows([]string{"film_id"}).
+						AddRow(1).AddRow(2))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrItemsMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior(tt.args)
+
+			err := repo.Reorder(tt.args.context, tt.args.id, tt.args.filmsIds)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CollectionRepo.Reorder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func TestCollectionRepo_AddFilm(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewCollectionRepo(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM collections WHERE id = \\$1 FOR UPDATE").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectExec("INSERT INTO collection_items").
+		WithArgs(1, 5, 3).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = repo.AddFilm(context.Background(), 1, 5, nil)
+	if err != nil {
+		t.Errorf("CollectionRepo.AddFilm() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
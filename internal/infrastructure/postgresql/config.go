@@ -35,13 +35,7 @@ func NewConfig() Config {
 }
 
 func NewConnection(cfg Config) (*sql.DB, error) {
-	builder := strings.Builder{}
-	builder.WriteString(fmt.Sprintf("host=%s port=%s ", cfg.Host, cfg.Port))
-	builder.WriteString(fmt.Sprintf("user=%s password=%s ", cfg.User, cfg.Password))
-	builder.WriteString(fmt.Sprintf("dbname=%s ", cfg.DBName))
-	builder.WriteString("sslmode=disable ")
-
-	params := builder.String()
+	params := connectionString(cfg)
 
 	var err error
 	var db *sql.DB
@@ -63,6 +57,16 @@ func NewConnection(cfg Config) (*sql.DB, error) {
 	return db, nil
 }
 
+func connectionString(cfg Config) string {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("host=%s port=%s ", cfg.Host, cfg.Port))
+	builder.WriteString(fmt.Sprintf("user=%s password=%s ", cfg.User, cfg.Password))
+	builder.WriteString(fmt.Sprintf("dbname=%s ", cfg.DBName))
+	builder.WriteString("sslmode=disable ")
+
+	return builder.String()
+}
+
 func runMigrations(cfg Config) {
 	dbUri := fmt.Sprintf(
 		"postgresql://%s:%s@%s:%s/%s?sslmode=disable",
@@ -0,0 +1,124 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+type DuplicateRepo struct {
+	db *sql.DB
+}
+
+func NewDuplicateRepo(db *sql.DB) *DuplicateRepo {
+	return &DuplicateRepo{
+		db: db,
+	}
+}
+
+// GetFilmPairs returns pairs of films with similar titles released
+// no more than a year apart, the most similar first.
+func (r *DuplicateRepo) GetFilmPairs(
+	ctx context.Context, threshold float64, limit uint,
+) ([]schemas.DuplicatePair, error) {
+	rows, err := r.db.QueryContext(ctx, `
+	SELECT
+		SIMILARITY(LOWER(a.title), LOWER(b.title)) AS score,
+		a.id, a.title, a.release_date,
+		b.id, b.title, b.release_date
+	FROM films AS a
+	INNER JOIN films AS b
+		ON a.id < b.id AND LOWER(a.title) % LOWER(b.title)
+	WHERE ABS(DATE_PART('year', a.release_date) - DATE_PART('year', b.release_date)) <= 1
+		AND SIMILARITY(LOWER(a.title), LOWER(b.title)) >= $1
+	ORDER BY score DESC, a.id, b.id
+	LIMIT $2
+	`, threshold, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDuplicatePairs(rows)
+}
+
+// GetActorPairs returns pairs of actors with similar names, the most
+// similar first. Actors born on the same day go before the others.
+func (r *DuplicateRepo) GetActorPairs(
+	ctx context.Context, threshold float64, limit uint,
+) ([]schemas.DuplicatePair, error) {
+	rows, err := r.db.QueryContext(ctx, `
+	WITH names AS (
+		SELECT id, first_name || ' ' || last_name AS name, birthday FROM actors
+	)
+	SELECT
+		SIMILARITY(LOWER(a.name), LOWER(b.name)) AS score,
+		a.id, a.name, a.birthday,
+		b.id, b.name, b.birthday
+	FROM names AS a
+	INNER JOIN names AS b
+		ON a.id < b.id AND LOWER(a.name) % LOWER(b.name)
+	WHERE SIMILARITY(LOWER(a.name), LOWER(b.name)) >= $1
+	ORDER BY a.birthday = b.birthday DESC, score DESC, a.id, b.id
+	LIMIT $2
+	`, threshold, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDuplicatePairs(rows)
+}
+
+func scanDuplicatePairs(rows *sql.Rows) ([]schemas.DuplicatePair, error) {
+	pairs := make([]schemas.DuplicatePair, 0)
+	for rows.Next() {
+		var pair schemas.DuplicatePair
+		var firstDate, secondDate time.Time
+		err := rows.Scan(
+			&pair.Score,
+			&pair.First.ID,
+			&pair.First.Name,
+			&firstDate,
+			&pair.Second.ID,
+			&pair.Second.Name,
+			&secondDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		pair.First.Date = schemas.NewDate(firstDate)
+		pair.Second.Date = schemas.NewDate(secondDate)
+
+		pairs = append(pairs, pair)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+func scanDuplicateCandidates(rows *sql.Rows) ([]schemas.DuplicateCandidate, error) {
+	candidates := make([]schemas.DuplicateCandidate, 0)
+	for rows.Next() {
+		var candidate schemas.DuplicateCandidate
+		var date time.Time
+		err := rows.Scan(&candidate.ID, &candidate.Name, &date)
+		if err != nil {
+			return nil, err
+		}
+		candidate.Date = schemas.NewDate(date)
+
+		candidates = append(candidates, candidate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
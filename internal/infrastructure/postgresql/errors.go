@@ -7,6 +7,10 @@ import (
 
 var (
 	ErrConnectionFailed = errors.New("connection failed")
+	ErrItemsMismatch    = errors.New("items do not match stored records")
+	ErrRelationCycle    = errors.New("relation creates a cycle")
+	ErrPathNotFound     = errors.New("path not found")
+	ErrAmbiguousRecord  = errors.New("several records match")
 )
 
 type ErrRecordNotFound struct {
@@ -17,3 +21,12 @@ type ErrRecordNotFound struct {
 func (e *ErrRecordNotFound) Error() string {
 	return fmt.Sprintf("record not found in %s with %s", e.tableName, e.identity)
 }
+
+type ErrRecordAlreadyExists struct {
+	tableName string
+	identity  string
+}
+
+func (e *ErrRecordAlreadyExists) Error() string {
+	return fmt.Sprintf("record already exists in %s with %s", e.tableName, e.identity)
+}
@@ -0,0 +1,140 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+// eventsChannel is notified by the trigger of the events table.
+const eventsChannel = "events"
+
+type EventRepo struct {
+	db *sql.DB
+}
+
+func NewEventRepo(db *sql.DB) *EventRepo {
+	return &EventRepo{
+		db: db,
+	}
+}
+
+// Create stores the event, the listeners are notified on commit. The
+// inserts are serialized so the events are committed in the order of ids
+// and the clients resuming after an id do not miss the events.
+func (r *EventRepo) Create(ctx context.Context, event *models.Event) (err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
+
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('events'))`)
+	if err != nil {
+		return err
+	}
+
+	return tx.QueryRow(`
+	INSERT INTO events (type, entity_id) VALUES ($1, $2)
+	RETURNING id, created_at
+	`, event.Type, event.EntityID).Scan(&event.ID, &event.CreatedAt)
+}
+
+// GetSince returns the stored events which follow the event with the id.
+func (r *EventRepo) GetSince(ctx context.Context, id int64) ([]models.Event, error) {
+	rows, err := r.db.QueryContext(ctx, `
+	SELECT id, type, entity_id, created_at FROM events
+	WHERE id > $1
+	ORDER BY id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]models.Event, 0)
+	for rows.Next() {
+		var event models.Event
+		err = rows.Scan(&event.ID, &event.Type, &event.EntityID, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// EventListener receives the events stored by all instances of the
+// application through LISTEN/NOTIFY.
+type EventListener struct {
+	cfg Config
+}
+
+func NewEventListener(cfg Config) *EventListener {
+	return &EventListener{
+		cfg: cfg,
+	}
+}
+
+type eventPayload struct {
+	ID        int64            `json:"id"`
+	Type      models.EventType `json:"type"`
+	EntityID  uint             `json:"entityId"`
+	CreatedAt time.Time        `json:"createdAt"`
+}
+
+// Listen calls fn for every event until ctx is done. After the connection
+// is re-established fn is called with nil, as the events published in the
+// meantime are lost.
+func (l *EventListener) Listen(ctx context.Context, fn func(*models.Event)) error {
+	listener := pq.NewListener(
+		connectionString(l.cfg), 10*time.Second, time.Minute,
+		func(_ pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Printf("events listener: %v", err)
+			}
+		},
+	)
+	defer listener.Close()
+
+	if err := listener.Listen(eventsChannel); err != nil {
+		return err
+	}
+
+	// the connection is checked as the notifications may never come
+	ticker := time.NewTicker(90 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			go func() { _ = listener.Ping() }()
+		case notification := <-listener.Notify:
+			if notification == nil {
+				fn(nil)
+				continue
+			}
+
+			var payload eventPayload
+			if err := json.Unmarshal([]byte(notification.Extra), &payload); err != nil {
+				log.Printf("invalid event notification: %v", err)
+				continue
+			}
+
+			fn(&models.Event{
+				ID:        payload.ID,
+				Type:      payload.Type,
+				EntityID:  payload.EntityID,
+				CreatedAt: payload.CreatedAt,
+			})
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+func TestEventRepo_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewEventRepo(db)
+	createdAt := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("basic", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT pg_advisory_xact_lock").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("INSERT INTO events").
+			WithArgs(models.FilmCreated, 7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(12, createdAt))
+		mock.ExpectCommit()
+
+		event := models.Event{Type: models.FilmCreated, EntityID: 7}
+		if err := repo.Create(context.Background(), &event); err != nil {
+			t.Fatalf("error was not expected while creating event: %s", err)
+		}
+
+		if event.ID != 12 || !event.CreatedAt.Equal(createdAt) {
+			t.Errorf("unexpected event %+v", event)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("insert error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SELECT pg_advisory_xact_lock").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery("INSERT INTO events").
+			WithArgs(models.ActorDeleted, 3).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		event := models.Event{Type: models.ActorDeleted, EntityID: 3}
+		if err := repo.Create(context.Background(), &event); err == nil {
+			t.Error("expected error, got nil")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
@@ -0,0 +1,226 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// exportFetchSize is the number of rows fetched from the cursor at once.
+const exportFetchSize = 500
+
+type ExportRepo struct {
+	db *sql.DB
+}
+
+func NewExportRepo(db *sql.DB) *ExportRepo {
+	return &ExportRepo{
+		db: db,
+	}
+}
+
+// Export reads rows of the entities updated after since through a cursor
+// and passes them to visit one by one. All entities are read from the
+// same snapshot. The deletions are read for the other requested entities,
+// the links deleted and linked again are left out.
+func (r *ExportRepo) Export(
+	ctx context.Context,
+	entities []schemas.ExportEntity,
+	since *time.Time,
+	visit func(schemas.ExportEntity, schemas.ExportRecord) error,
+) error {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return err
+	}
+	// the transaction only reads, so it is never committed
+	defer func() { _ = tx.Rollback() }()
+
+	updated := "TRUE"
+	deleted := "TRUE"
+	if since != nil {
+		updated = "updated_at > " + pq.QuoteLiteral(since.Format(time.RFC3339Nano))
+		deleted = "deleted_at > " + pq.QuoteLiteral(since.Format(time.RFC3339Nano))
+	}
+
+	deletedEntities := make([]string, 0, len(entities))
+	for _, entity := range entities {
+		if entity != schemas.ExportDeletions {
+			deletedEntities = append(deletedEntities, pq.QuoteLiteral(string(entity)))
+		}
+	}
+
+	for _, entity := range entities {
+		var query string
+		var scan func(*sql.Rows) (schemas.ExportRecord, error)
+		switch entity {
+		case schemas.ExportFilms:
+			query = `
+			SELECT films.id, films.title, films.description, films.release_date,
+				films.rating, ` + filmMetadataColumns + `, films.updated_at
+			FROM films WHERE ` + updated + ` ORDER BY films.id`
+			scan = scanExportFilm
+		case schemas.ExportActors:
+			query = `
+			SELECT id, first_name, last_name, middle_name, sex, birthday, updated_at
+			FROM actors WHERE ` + updated + ` ORDER BY id`
+			scan = scanExportActor
+		case schemas.ExportLinks:
+			query = `
+			SELECT actor_id, film_id, updated_at
+			FROM actors_and_films WHERE ` + updated + ` ORDER BY film_id, actor_id`
+			scan = scanExportLink
+		case schemas.ExportDeletions:
+			if len(deletedEntities) == 0 {
+				continue
+			}
+			query = `
+			SELECT entity, record_id, actor_id, film_id, max(deleted_at)
+			FROM deletions
+			WHERE ` + deleted + ` AND entity IN (` + strings.Join(deletedEntities, ", ") + `)
+				AND NOT EXISTS (
+					SELECT 1 FROM actors_and_films AS aaf
+					WHERE deletions.entity = 'links'
+						AND aaf.actor_id = deletions.actor_id
+						AND aaf.film_id = deletions.film_id
+				)
+			GROUP BY entity, record_id, actor_id, film_id
+			ORDER BY max(deleted_at)`
+			scan = scanExportDeletion
+		default:
+			return fmt.Errorf("unknown export entity: %s", entity)
+		}
+
+		err = readCursor(ctx, tx, query, func(rows *sql.Rows) error {
+			record, err := scan(rows)
+			if err != nil {
+				return err
+			}
+			return visit(entity, record)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readCursor declares the cursor for the query and passes the fetched
+// rows to visit.
+func readCursor(
+	ctx context.Context, tx *sql.Tx, query string, visit func(*sql.Rows) error,
+) error {
+	_, err := tx.ExecContext(ctx, "DECLARE export_cursor NO SCROLL CURSOR FOR "+query)
+	if err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM export_cursor", exportFetchSize)
+	for {
+		rows, err := tx.QueryContext(ctx, fetch)
+		if err != nil {
+			return err
+		}
+
+		fetched := 0
+		for rows.Next() {
+			fetched++
+			if err = visit(rows); err != nil {
+				_ = rows.Close()
+				return err
+			}
+		}
+		_ = rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		if fetched < exportFetchSize {
+			break
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "CLOSE export_cursor")
+	return err
+}
+
+func scanExportFilm(rows *sql.Rows) (schemas.ExportRecord, error) {
+	var film schemas.ExportFilm
+	var date time.Time
+	err := rows.Scan(
+		&film.ID,
+		&film.Title,
+		&film.Description,
+		&date,
+		&film.Rating,
+		&film.Runtime,
+		pq.Array(&film.Countries),
+		&film.Language,
+		&film.AgeRating,
+		&film.Budget,
+		&film.BoxOffice,
+		&film.Currency,
+		&film.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	film.ReleaseDate = schemas.NewDate(date)
+
+	return film, nil
+}
+
+func scanExportActor(rows *sql.Rows) (schemas.ExportRecord, error) {
+	var actor schemas.ExportActor
+	var date time.Time
+	err := rows.Scan(
+		&actor.ID,
+		&actor.FirstName,
+		&actor.LastName,
+		&actor.MiddleName,
+		&actor.Sex,
+		&date,
+		&actor.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	actor.Birthday = schemas.NewDate(date)
+
+	return actor, nil
+}
+
+func scanExportLink(rows *sql.Rows) (schemas.ExportRecord, error) {
+	var link schemas.ExportLink
+	err := rows.Scan(&link.ActorID, &link.FilmID, &link.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+func scanExportDeletion(rows *sql.Rows) (schemas.ExportRecord, error) {
+	var deletion schemas.ExportDeletion
+	err := rows.Scan(
+		&deletion.Entity,
+		&deletion.ID,
+		&deletion.ActorID,
+		&deletion.FilmID,
+		&deletion.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return deletion, nil
+}
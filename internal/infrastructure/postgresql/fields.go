@@ -0,0 +1,240 @@
+package postgresql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+// selectField is the column of the sparse select list scanned into the row R.
+type selectField[R any] struct {
+	// name is the JSON name of the field.
+	name string
+	// expr returns the select expression, localized expressions read
+	// the locales from the given parameter.
+	expr      func(locales string) string
+	localized bool
+	dest      func(*R) any
+}
+
+func column[R any](name string, expr string, dest func(*R) any) selectField[R] {
+	return selectField[R]{
+		name: name,
+		expr: func(string) string { return expr },
+		dest: dest,
+	}
+}
+
+func localizedColumn[R any](
+	name string, table string, column string, dest func(*R) any,
+) selectField[R] {
+	return selectField[R]{
+		name:      name,
+		expr:      func(locales string) string { return translated(table, column, locales) + " AS " + column },
+		localized: true,
+		dest:      dest,
+	}
+}
+
+// selectList returns the selected fields with their select list. The
+// locales parameter is only requested if the list has localized fields,
+// as the parameters not referenced by the query can not be typed.
+func selectList[R any](
+	fields []selectField[R], selected func(string) bool, locales func() string,
+) ([]selectField[R], string) {
+	result := make([]selectField[R], 0, len(fields))
+	exprs := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !selected(field.name) {
+			continue
+		}
+
+		param := ""
+		if field.localized {
+			param = locales()
+		}
+		result = append(result, field)
+		exprs = append(exprs, field.expr(param))
+	}
+
+	return result, strings.Join(exprs, ", ")
+}
+
+// scanDest returns the destinations of the fields in the row.
+func scanDest[R any](fields []selectField[R], row *R) []any {
+	dest := make([]any, 0, len(fields))
+	for _, field := range fields {
+		dest = append(dest, field.dest(row))
+	}
+
+	return dest
+}
+
+// lazyParam returns the function adding the value to the query arguments
+// on the first call and returning its placeholder.
+func lazyParam(args *[]any, value any) func() string {
+	placeholder := ""
+	return func() string {
+		if placeholder == "" {
+			*args = append(*args, value)
+			placeholder = "$" + strconv.Itoa(len(*args))
+		}
+		return placeholder
+	}
+}
+
+// filmRow is the scanned row of the film, the date is left empty if it
+// is not selected.
+type filmRow struct {
+	film   schemas.FilmInfo
+	date   time.Time
+	poster []byte
+}
+
+func (r *filmRow) info() (schemas.FilmInfo, error) {
+	var err error
+	if !r.date.IsZero() {
+		r.film.ReleaseDate = schemas.NewDate(r.date)
+	}
+	r.film.Poster, err = parseImages(r.poster)
+	return r.film, err
+}
+
+// filmFields lists the columns of schemas.FilmInfo by the JSON names.
+var filmFields = []selectField[filmRow]{
+	column("id", "films.id", func(r *filmRow) any { return &r.film.ID }),
+	localizedColumn("title", "films", "title", func(r *filmRow) any { return &r.film.Title }),
+	localizedColumn("description", "films", "description", func(r *filmRow) any { return &r.film.Description }),
+	column("releaseDate", "films.release_date", func(r *filmRow) any { return &r.date }),
+	column("rating", "films.rating", func(r *filmRow) any { return &r.film.Rating }),
+	column("runtime", "films.runtime", func(r *filmRow) any { return &r.film.Runtime }),
+	column("countries", "films.countries", func(r *filmRow) any { return pq.Array(&r.film.Countries) }),
+	column("language", "films.language", func(r *filmRow) any { return &r.film.Language }),
+	column("ageRating", "films.age_rating", func(r *filmRow) any { return &r.film.AgeRating }),
+	column("budget", "films.budget", func(r *filmRow) any { return &r.film.Budget }),
+	column("boxOffice", "films.box_office", func(r *filmRow) any { return &r.film.BoxOffice }),
+	column("currency", "films.currency", func(r *filmRow) any { return &r.film.Currency }),
+	column("poster", imagesSubquery(models.FilmImage, "films.id"), func(r *filmRow) any { return &r.poster }),
+}
+
+// actorRow is the scanned row of the actor, the date is left empty if it
+// is not selected.
+type actorRow struct {
+	actor    schemas.ActorInfo
+	date     time.Time
+	headshot []byte
+}
+
+func (r *actorRow) info() (schemas.ActorInfo, error) {
+	var err error
+	if !r.date.IsZero() {
+		r.actor.Birthday = schemas.NewDate(r.date)
+	}
+	r.actor.Headshot, err = parseImages(r.headshot)
+	return r.actor, err
+}
+
+// actorFields lists the columns of schemas.ActorInfo by the JSON names.
+var actorFields = []selectField[actorRow]{
+	column("id", "actors.id", func(r *actorRow) any { return &r.actor.ID }),
+	localizedColumn("firstName", "actors", "first_name", func(r *actorRow) any { return &r.actor.FirstName }),
+	localizedColumn("lastName", "actors", "last_name", func(r *actorRow) any { return &r.actor.LastName }),
+	localizedColumn("middleName", "actors", "middle_name", func(r *actorRow) any { return &r.actor.MiddleName }),
+	column("sex", "actors.sex", func(r *actorRow) any { return &r.actor.Sex }),
+	column("birthday", "actors.birthday", func(r *actorRow) any { return &r.date }),
+	column("headshot", imagesSubquery(models.ActorImage, "actors.id"), func(r *actorRow) any { return &r.headshot }),
+}
+
+// getCast returns the selected fields of the actors of each of the films
+// keyed by the film id.
+func getCast(
+	ctx context.Context, db querier, filmsIds []uint, selected func(string) bool,
+) (map[uint][]schemas.ActorInfo, error) {
+	args := []any{pq.Array(filmsIds)}
+	fields, columns := selectList(actorFields, selected, lazyParam(&args, localesParam(ctx)))
+
+	rows, err := db.Query(`
+	SELECT actors_and_films.film_id, `+columns+`
+	FROM actors
+	INNER JOIN actors_and_films ON actors.id = actors_and_films.actor_id
+	WHERE actors_and_films.film_id = ANY($1)
+	ORDER BY actors_and_films.film_id, actors.last_name, actors.first_name, actors.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cast := make(map[uint][]schemas.ActorInfo, len(filmsIds))
+	for rows.Next() {
+		var filmId uint
+		var row actorRow
+		if err = rows.Scan(append([]any{&filmId}, scanDest(fields, &row)...)...); err != nil {
+			return nil, err
+		}
+
+		actor, err := row.info()
+		if err != nil {
+			return nil, err
+		}
+		cast[filmId] = append(cast[filmId], actor)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return cast, nil
+}
+
+// getFilmographies returns the selected fields of the films of each of
+// the actors keyed by the actor id and ordered by the release date.
+func getFilmographies(
+	ctx context.Context, db querier, actorsIds []uint, selected func(string) bool,
+) (map[uint][]schemas.FilmInfo, error) {
+	args := []any{pq.Array(actorsIds)}
+	fields, columns := selectList(filmFields, selected, lazyParam(&args, localesParam(ctx)))
+
+	rows, err := db.Query(`
+	SELECT actors_and_films.actor_id, `+columns+`
+	FROM films
+	INNER JOIN actors_and_films ON films.id = actors_and_films.film_id
+	WHERE actors_and_films.actor_id = ANY($1)
+	ORDER BY actors_and_films.actor_id, films.release_date, films.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	films := make(map[uint][]schemas.FilmInfo, len(actorsIds))
+	for rows.Next() {
+		var actorId uint
+		var row filmRow
+		if err = rows.Scan(append([]any{&actorId}, scanDest(fields, &row)...)...); err != nil {
+			return nil, err
+		}
+
+		film, err := row.info()
+		if err != nil {
+			return nil, err
+		}
+		films[actorId] = append(films[actorId], film)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return films, nil
+}
+
+// allFields selects every field.
+func allFields(string) bool {
+	return true
+}
@@ -9,11 +9,16 @@ import (
 	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
 	"github.com/sivistrukov/vk-assigment/internal/models"
-	"github.com/sivistrukov/vk-assigment/internal/services/text"
 )
 
+// filmMetadataColumns lists the optional catalogue columns of the film
+// in the order of schemas.FilmMetadata fields.
+const filmMetadataColumns = `films.runtime, films.countries, films.language,
+	films.age_rating, films.budget, films.box_office, films.currency`
+
 type FilmRepo struct {
 	db *sql.DB
 }
@@ -25,29 +30,39 @@ func NewFilmRepo(db *sql.DB) *FilmRepo {
 }
 
 func (r *FilmRepo) Create(
-	_ context.Context, film *models.Film, actorsIds ...uint,
-) error {
-	var err error
-	tx, _ := r.db.Begin()
-	defer func() {
-		if err != nil {
-			_ = tx.Rollback()
-			return
-		}
-		_ = tx.Commit()
-	}()
+	ctx context.Context, film *models.Film, actorsIds ...uint,
+) (err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
 
 	stmt := `
-	INSERT INTO films (title, description, release_date, rating) 
-	VALUES ($1, $2, $3, $4)
+	INSERT INTO films (
+		title, description, release_date, rating, runtime, countries,
+		language, age_rating, budget, box_office, currency
+	) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	RETURNING id;
 	`
+	countries := film.Countries
+	if countries == nil {
+		countries = make([]string, 0)
+	}
 	err = tx.QueryRow(
 		stmt,
 		film.Title,
 		film.Description,
 		film.ReleaseDate,
 		film.Rating,
+		film.Runtime,
+		pq.Array(countries),
+		film.Language,
+		film.AgeRating,
+		film.Budget,
+		film.BoxOffice,
+		film.Currency,
 	).Scan(&film.ID)
 	if err != nil {
 		return err
@@ -70,21 +85,17 @@ func (r *FilmRepo) Create(
 		}
 	}
 
-	return nil
+	return writeOutbox(tx, models.FilmCreated, film.ID)
 }
 
 func (r *FilmRepo) Update(
 	ctx context.Context, id uint, updates map[string]any,
-) error {
-	var err error
-	tx, _ := r.db.Begin()
-	defer func() {
-		if err != nil {
-			_ = tx.Rollback()
-			return
-		}
-		_ = tx.Commit()
-	}()
+) (err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
 
 	err = r.updateFilm(ctx, tx, id, updates)
 	if err != nil {
@@ -101,7 +112,7 @@ func (r *FilmRepo) Update(
 		}
 	}
 
-	return nil
+	return writeOutbox(tx, models.FilmUpdated, id)
 }
 
 func (r *FilmRepo) updateFilm(
@@ -121,6 +132,13 @@ func (r *FilmRepo) updateFilm(
 		}
 		i++
 
+		if countries, ok := value.([]string); ok {
+			if countries == nil {
+				countries = make([]string, 0)
+			}
+			value = pq.Array(countries)
+		}
+
 		builder.WriteString(fmt.Sprintf("%s = $%v", field, i))
 		values = append(values, value)
 	}
@@ -220,16 +238,59 @@ func (r *FilmRepo) updateFilmActors(
 	return nil
 }
 
-func (r *FilmRepo) Remove(_ context.Context, id uint) error {
-	stmt, err := r.db.Prepare(`
-	DELETE FROM films WHERE id = $1
-	`)
+// GetDuplicateCandidates returns films released in the given year,
+// which the new film may duplicate.
+func (r *FilmRepo) GetDuplicateCandidates(
+	ctx context.Context, year int,
+) ([]schemas.DuplicateCandidate, error) {
+	rows, err := conn(ctx, r.db).Query(`
+	SELECT id, title, release_date FROM films
+	WHERE release_date >= MAKE_DATE($1, 1, 1) AND release_date < MAKE_DATE($1 + 1, 1, 1)
+	ORDER BY id
+	`, year)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanDuplicateCandidates(rows)
+}
+
+// Remove deletes the film and drops it from every collection,
+// renumbering the remaining collection items.
+func (r *FilmRepo) Remove(ctx context.Context, id uint) (err error) {
+	tx, end, err := beginTx(ctx, r.db)
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
+	defer func() { err = end(err) }()
 
-	result, err := stmt.Exec(id)
+	rows, err := tx.Query(`
+	DELETE FROM collection_items WHERE film_id = $1
+	RETURNING collection_id
+	`, id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var collectionsIds []uint
+	for rows.Next() {
+		var collectionId uint
+		if err = rows.Scan(&collectionId); err != nil {
+			return err
+		}
+
+		collectionsIds = append(collectionsIds, collectionId)
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`
+	DELETE FROM films WHERE id = $1
+	`, id)
 	if err != nil {
 		return err
 	}
@@ -246,106 +307,347 @@ func (r *FilmRepo) Remove(_ context.Context, id uint) error {
 		}
 	}
 
-	return nil
+	for _, collectionId := range collectionsIds {
+		err = compactCollectionPositions(tx, collectionId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeOutbox(tx, models.FilmDeleted, id)
 }
 
+// filmSortColumns maps the schemas.FilmSortFields to the columns, so only
+// the fixed columns get into the query.
+var filmSortColumns = map[string]string{
+	"id":          "films.id",
+	"title":       "films.title",
+	"releaseDate": "films.release_date",
+	"rating":      "films.rating",
+	"runtime":     "films.runtime",
+	"budget":      "films.budget",
+	"boxOffice":   "films.box_office",
+}
+
+// GetFilmsWithActors returns the fields of the films selected by the options
+// with their actors if the options include them.
 func (r *FilmRepo) GetFilmsWithActors(
-	ctx context.Context, search string, sortBy string,
+	ctx context.Context,
+	search string,
+	sortBy string,
+	filter schemas.FilmsFilter,
+	options schemas.ListOptions,
+) ([]schemas.FilmWithActorsResponse, error) {
+	return r.getWithActors(ctx, search, sortBy, filter, options, nil)
+}
+
+// GetFilmWithActors returns the film with the actors.
+func (r *FilmRepo) GetFilmWithActors(
+	ctx context.Context, id uint,
+) (schemas.FilmWithActorsResponse, error) {
+	films, err := r.getWithActors(
+		ctx, "", "", schemas.FilmsFilter{}, schemas.DefaultListOptions, &id,
+	)
+	if err != nil {
+		return schemas.FilmWithActorsResponse{}, err
+	}
+	if len(films) == 0 {
+		return schemas.FilmWithActorsResponse{}, &ErrRecordNotFound{
+			tableName: "films",
+			identity:  fmt.Sprintf("%d", id),
+		}
+	}
+
+	return films[0], nil
+}
+
+// getWithActors returns the film with the id or all films matching the
+// search and filter if it is nil. The actors are joined for the search
+// only, so the films without cast are returned too.
+func (r *FilmRepo) getWithActors(
+	ctx context.Context,
+	search string,
+	sortBy string,
+	filter schemas.FilmsFilter,
+	options schemas.ListOptions,
+	id *uint,
 ) ([]schemas.FilmWithActorsResponse, error) {
+	args := make([]any, 0, 8)
+	fields, columns := selectList(filmFields, options.HasField, lazyParam(&args, localesParam(ctx)))
+
 	builder := strings.Builder{}
 	builder.WriteString(`
-	SELECT films.id, films.title, films.description, films.release_date, films.rating
+	SELECT `)
+	builder.WriteString(columns)
+	builder.WriteString(`
 	FROM films
-	INNER JOIN actors_and_films AS aaf ON films.id = aaf.film_id
-	INNER JOIN actors ON aaf.actor_id = actors.id
+	LEFT JOIN actors_and_films AS aaf ON films.id = aaf.film_id
+	LEFT JOIN actors ON aaf.actor_id = actors.id
 	`)
 
+	conditions := make([]string, 0, 8)
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if id != nil {
+		addCondition("films.id = $%d", *id)
+	}
 	if len(search) > 0 {
-		builder.WriteString("WHERE films.title ILIKE '%" + search + "%'")
-		builder.WriteString("OR actors.first_name ILIKE '%" + search + "%'")
-		builder.WriteString("OR actors.last_name ILIKE '%" + search + "%'")
-		builder.WriteString("OR actors.middle_name ILIKE '%" + search + "%'")
+		addCondition(`(
+		films.title ILIKE $%[1]d
+		OR actors.first_name ILIKE $%[1]d
+		OR actors.last_name ILIKE $%[1]d
+		OR actors.middle_name ILIKE $%[1]d
+		OR EXISTS (
+			SELECT 1 FROM film_translations AS ft
+			WHERE ft.film_id = films.id AND ft.title ILIKE $%[1]d
+		)
+		OR EXISTS (
+			SELECT 1 FROM actor_translations AS atr
+			WHERE atr.actor_id = actors.id
+				AND (atr.first_name ILIKE $%[1]d OR atr.last_name ILIKE $%[1]d OR atr.middle_name ILIKE $%[1]d)
+		)
+		)`, "%"+search+"%")
 	}
 
-	builder.WriteString("GROUP BY films.id")
+	if filter.HasAwards != nil {
+		condition := "EXISTS (SELECT 1 FROM nominations WHERE nominations.film_id = films.id)"
+		if !*filter.HasAwards {
+			condition = "NOT " + condition
+		}
+		conditions = append(conditions, condition)
+	}
 
-	if len(sortBy) > 0 {
-		builder.WriteString(" ORDER BY ")
-		params := strings.Split(sortBy, ",")
-		for i, v := range params {
-			if i > 0 {
-				builder.WriteString(", ")
-			}
+	if filter.AwardWinner != nil {
+		condition := "EXISTS (SELECT 1 FROM nominations WHERE nominations.film_id = films.id AND nominations.won)"
+		if !*filter.AwardWinner {
+			condition = "NOT " + condition
+		}
+		conditions = append(conditions, condition)
+	}
+
+	if filter.Country != nil {
+		addCondition("$%d = ANY(films.countries)", *filter.Country)
+	}
+	if filter.Language != nil {
+		addCondition("films.language = $%d", *filter.Language)
+	}
+	if filter.AgeRating != nil {
+		addCondition("films.age_rating = $%d", *filter.AgeRating)
+	}
+	if filter.MinRuntime != nil {
+		addCondition("films.runtime >= $%d", *filter.MinRuntime)
+	}
+	if filter.MaxRuntime != nil {
+		addCondition("films.runtime <= $%d", *filter.MaxRuntime)
+	}
+
+	if len(conditions) > 0 {
+		builder.WriteString(" WHERE ")
+		builder.WriteString(strings.Join(conditions, " AND "))
+	}
+
+	builder.WriteString(" GROUP BY films.id")
 
-			sortParam := text.CamelToSnake(v)
-			if sortParam[0] == '-' {
-				builder.WriteString(fmt.Sprintf("%s DESC", sortParam[1:]))
-			} else {
-				builder.WriteString(fmt.Sprintf("%s ASC", sortParam))
+	order, err := schemas.ParseSort(sortBy, schemas.FilmSortFields)
+	if err != nil {
+		return nil, err
+	}
+	if len(order) > 0 {
+		terms := make([]string, 0, len(order))
+		for _, field := range order {
+			direction := "ASC"
+			if field.Descending {
+				direction = "DESC"
 			}
+			terms = append(terms, fmt.Sprintf("%s %s NULLS LAST", filmSortColumns[field.Name], direction))
 		}
+		builder.WriteString(" ORDER BY ")
+		builder.WriteString(strings.Join(terms, ", "))
 	} else {
 		builder.WriteString(" ORDER BY rating DESC")
 	}
 
-	stmt := builder.String()
-	rows, err := r.db.Query(stmt)
+	rows, err := r.db.Query(builder.String(), args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
 	films := make([]schemas.FilmWithActorsResponse, 0)
+	ids := make([]uint, 0)
 	for rows.Next() {
-		var film schemas.FilmWithActorsResponse
+		var row filmRow
+		if err = rows.Scan(scanDest(fields, &row)...); err != nil {
+			return nil, err
+		}
+
+		film, err := row.info()
+		if err != nil {
+			return nil, err
+		}
+		films = append(films, schemas.FilmWithActorsResponse{
+			ID:           film.ID,
+			Title:        film.Title,
+			Description:  film.Description,
+			ReleaseDate:  film.ReleaseDate,
+			Rating:       film.Rating,
+			FilmMetadata: film.FilmMetadata,
+			Poster:       film.Poster,
+		})
+		ids = append(ids, film.ID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if !options.Include || len(films) == 0 {
+		return films, nil
+	}
+
+	cast, err := getCast(ctx, r.db, ids, options.HasIncludedField)
+	if err != nil {
+		return nil, err
+	}
+	for i := range films {
+		films[i].Actors = cast[films[i].ID]
+		if films[i].Actors == nil {
+			films[i].Actors = make([]schemas.ActorInfo, 0)
+		}
+	}
+
+	return films, nil
+}
+
+// filmInfoColumns returns the select list read by scanFilmInfos, texts
+// are translated to the locales of the given query parameter.
+func filmInfoColumns(locales string) string {
+	return `films.id, ` +
+		translated("films", "title", locales) + `, ` +
+		translated("films", "description", locales) + `,
+		films.release_date, films.rating, ` +
+		filmMetadataColumns + `, ` +
+		imagesSubquery(models.FilmImage, "films.id")
+}
+
+// scanFilmInfos reads the films selected with filmInfoColumns.
+func scanFilmInfos(rows *sql.Rows) ([]schemas.FilmInfo, error) {
+	films := make([]schemas.FilmInfo, 0)
+	for rows.Next() {
+		var film schemas.FilmInfo
 		var date time.Time
-		err = rows.Scan(
-			&film.ID,
-			&film.Title,
-			&film.Description,
-			&date,
-			&film.Rating,
-		)
+		var poster []byte
+		err := rows.Scan(filmInfoDest(&film, &date, &poster)...)
 		if err != nil {
 			return nil, err
 		}
 		film.ReleaseDate = schemas.NewDate(date)
 
-		stmt = `
-		SELECT actors.id, actors.first_name, actors.last_name, actors.middle_name, actors.sex, actors.birthday
-		FROM actors 
-		INNER JOIN actors_and_films ON actors.id = actors_and_films.actor_id
-		WHERE actors_and_films.film_id = $1
-		`
-		rows2, err := r.db.Query(stmt, film.ID)
+		film.Poster, err = parseImages(poster)
 		if err != nil {
 			return nil, err
 		}
-		defer rows.Close()
-		actors := make([]schemas.ActorInfo, 0)
-		for rows2.Next() {
-			var date time.Time
-			var actor schemas.ActorInfo
-			err = rows2.Scan(
-				&actor.ID,
-				&actor.FirstName,
-				&actor.LastName,
-				&actor.MiddleName,
-				&actor.Sex,
-				&date,
-			)
-			if err != nil {
-				return nil, err
+
+		films = append(films, film)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return films, nil
+}
+
+// filmInfoDest returns scan destinations for the filmInfoColumns.
+func filmInfoDest(
+	film *schemas.FilmInfo, date *time.Time, poster *[]byte,
+) []any {
+	return []any{
+		&film.ID,
+		&film.Title,
+		&film.Description,
+		date,
+		&film.Rating,
+		&film.Runtime,
+		pq.Array(&film.Countries),
+		&film.Language,
+		&film.AgeRating,
+		&film.Budget,
+		&film.BoxOffice,
+		&film.Currency,
+		poster,
+	}
+}
+
+// GetSimilarityCandidates returns the film compared with others and every
+// other film with the number of actors shared with it.
+func (r *FilmRepo) GetSimilarityCandidates(
+	ctx context.Context, id uint,
+) (schemas.SimilarityTarget, []schemas.SimilarFilmInfo, error) {
+	var target schemas.SimilarityTarget
+	err := r.db.QueryRow(`
+	SELECT films.release_date,
+		(SELECT COUNT(*) FROM actors_and_films WHERE film_id = films.id)
+	FROM films WHERE films.id = $1
+	`, id).Scan(&target.ReleaseDate, &target.CastSize)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return target, nil, &ErrRecordNotFound{
+				tableName: "films",
+				identity:  fmt.Sprintf("%d", id),
 			}
-			actor.Birthday = schemas.NewDate(date)
+		}
+		return target, nil, err
+	}
+
+	stmt := `
+	SELECT ` + filmInfoColumns("$2") + `,
+		(SELECT COUNT(*) FROM actors_and_films WHERE film_id = films.id),
+		(
+			SELECT COUNT(*) FROM actors_and_films AS candidate
+			INNER JOIN actors_and_films AS target
+				ON candidate.actor_id = target.actor_id AND target.film_id = $1
+			WHERE candidate.film_id = films.id
+		)
+	FROM films
+	WHERE films.id <> $1
+	`
+
+	rows, err := r.db.Query(stmt, id, localesParam(ctx))
+	if err != nil {
+		return target, nil, err
+	}
+	defer rows.Close()
 
-			actors = append(actors, actor)
+	candidates := make([]schemas.SimilarFilmInfo, 0)
+	for rows.Next() {
+		var candidate schemas.SimilarFilmInfo
+		var date time.Time
+		var poster []byte
+		dest := append(
+			filmInfoDest(&candidate.Film, &date, &poster),
+			&candidate.CastSize,
+			&candidate.SharedActors,
+		)
+		err = rows.Scan(dest...)
+		if err != nil {
+			return target, nil, err
 		}
+		candidate.Film.ReleaseDate = schemas.NewDate(date)
 
-		film.Actors = actors
+		candidate.Film.Poster, err = parseImages(poster)
+		if err != nil {
+			return target, nil, err
+		}
 
-		films = append(films, film)
+		candidates = append(candidates, candidate)
 	}
 
-	return films, nil
+	if err := rows.Err(); err != nil {
+		return target, nil, err
+	}
+
+	return target, candidates, nil
 }
@@ -0,0 +1,64 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestFilmRepo_GetFilmWithActors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewFilmRepo(db)
+	query := regexp.QuoteMeta("LEFT JOIN actors_and_films AS aaf ON films.id = aaf.film_id")
+	castQuery := regexp.QuoteMeta("SELECT actors_and_films.film_id")
+	columns := []string{
+		"id", "title", "description", "release_date", "rating", "runtime",
+		"countries", "language", "age_rating", "budget", "box_office",
+		"currency", "poster",
+	}
+
+	t.Run("no actors", func(t *testing.T) {
+		mock.ExpectQuery(query).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(
+				1, "Drive", "", time.Date(2011, 9, 16, 0, 0, 0, 0, time.UTC), 8,
+				nil, "{}", nil, nil, nil, nil, nil, nil,
+			))
+		mock.ExpectQuery(castQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"film_id", "id"}))
+
+		film, err := repo.GetFilmWithActors(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("error was not expected while getting film: %s", err)
+		}
+		if film.ID != 1 || film.Actors == nil || len(film.Actors) != 0 {
+			t.Errorf("GetFilmWithActors() = %+v, want film 1 without actors", film)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("missing film", func(t *testing.T) {
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows(columns))
+
+		_, err := repo.GetFilmWithActors(context.Background(), 1)
+		var notFoundErr *ErrRecordNotFound
+		if !errors.As(err, &notFoundErr) {
+			t.Errorf("expected ErrRecordNotFound, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
@@ -0,0 +1,439 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type FranchiseRepo struct {
+	db *sql.DB
+}
+
+func NewFranchiseRepo(db *sql.DB) *FranchiseRepo {
+	return &FranchiseRepo{
+		db: db,
+	}
+}
+
+func (r *FranchiseRepo) Create(
+	_ context.Context, franchise *models.Franchise, filmsIds ...uint,
+) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	stmt := `
+	INSERT INTO franchises (name, description)
+	VALUES ($1, $2)
+	RETURNING id;
+	`
+	err = tx.QueryRow(
+		stmt, franchise.Name, franchise.Description,
+	).Scan(&franchise.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "uniq_franchises_name") {
+			return &ErrRecordAlreadyExists{
+				tableName: "franchises",
+				identity:  franchise.Name,
+			}
+		}
+		return err
+	}
+
+	err = insertFranchiseFilms(tx, franchise.ID, filmsIds)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *FranchiseRepo) Get(_ context.Context, id uint) (models.Franchise, error) {
+	stmt := `
+	SELECT id, name, description
+	FROM franchises WHERE id = $1
+	`
+
+	var franchise models.Franchise
+	err := r.db.QueryRow(stmt, id).Scan(
+		&franchise.ID,
+		&franchise.Name,
+		&franchise.Description,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return franchise, &ErrRecordNotFound{
+				tableName: "franchises",
+				identity:  fmt.Sprintf("%d", id),
+			}
+		}
+		return franchise, err
+	}
+
+	return franchise, nil
+}
+
+func (r *FranchiseRepo) Update(
+	_ context.Context, id uint, updates map[string]any,
+) error {
+	err := updateRecord(r.db, "franchises", id, updates)
+	if err != nil && strings.Contains(err.Error(), "uniq_franchises_name") {
+		return &ErrRecordAlreadyExists{
+			tableName: "franchises",
+			identity:  fmt.Sprintf("%v", updates["name"]),
+		}
+	}
+
+	return err
+}
+
+func (r *FranchiseRepo) Remove(_ context.Context, id uint) error {
+	return removeRecord(r.db, "franchises", id)
+}
+
+func (r *FranchiseRepo) GetList(_ context.Context) ([]schemas.FranchiseInfo, error) {
+	stmt := `
+	SELECT id, name, description
+	FROM franchises
+	ORDER BY name
+	`
+
+	rows, err := r.db.Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	franchises := make([]schemas.FranchiseInfo, 0)
+	for rows.Next() {
+		var franchise schemas.FranchiseInfo
+		err = rows.Scan(
+			&franchise.ID,
+			&franchise.Name,
+			&franchise.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		franchises = append(franchises, franchise)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return franchises, nil
+}
+
+// GetFilms returns films of the franchise ordered by their position.
+func (r *FranchiseRepo) GetFilms(
+	ctx context.Context, id uint,
+) ([]schemas.FilmInfo, error) {
+	stmt := `
+	SELECT ` + filmInfoColumns("$2") + `
+	FROM films
+	INNER JOIN franchise_films ON films.id = franchise_films.film_id
+	WHERE franchise_films.franchise_id = $1
+	ORDER BY franchise_films.position
+	`
+
+	rows, err := r.db.Query(stmt, id, localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanFilmInfos(rows)
+}
+
+// SetFilms replaces films of the franchise keeping the given order.
+func (r *FranchiseRepo) SetFilms(
+	_ context.Context, id uint, filmsIds []uint,
+) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	var franchiseId uint
+	err = tx.QueryRow(`
+	SELECT id FROM franchises WHERE id = $1 FOR UPDATE
+	`, id).Scan(&franchiseId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &ErrRecordNotFound{
+				tableName: "franchises",
+				identity:  fmt.Sprintf("%d", id),
+			}
+		}
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM franchise_films WHERE franchise_id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	err = insertFranchiseFilms(tx, id, filmsIds)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddRelation stores the relation between films. Sequels and prequels
+// must not form a cycle, the same goes for remakes and spin-offs.
+func (r *FranchiseRepo) AddRelation(
+	_ context.Context, relation models.FilmRelation,
+) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	// concurrent inserts could create a cycle unnoticed by each other
+	_, err = tx.Exec("LOCK TABLE film_relations IN SHARE ROW EXCLUSIVE MODE")
+	if err != nil {
+		return err
+	}
+
+	source, target := relationEdge(relation)
+	group := relationGroup(relation.Type)
+
+	var cycle bool
+	err = tx.QueryRow(`
+	WITH RECURSIVE edges AS (
+		SELECT
+			CASE WHEN type = $3 THEN film_id ELSE related_film_id END AS source,
+			CASE WHEN type = $3 THEN related_film_id ELSE film_id END AS target
+		FROM film_relations
+		WHERE type = $4 OR type = $5
+	), reachable AS (
+		SELECT $1::integer AS id
+		UNION
+		SELECT edges.target FROM edges
+		INNER JOIN reachable ON edges.source = reachable.id
+	)
+	SELECT EXISTS (SELECT 1 FROM reachable WHERE id = $2)
+	`, target, source, models.PrequelOf, group[0], group[1]).Scan(&cycle)
+	if err != nil {
+		return err
+	}
+
+	if cycle {
+		return ErrRelationCycle
+	}
+
+	_, err = tx.Exec(`
+	INSERT INTO film_relations (film_id, related_film_id, type)
+	VALUES ($1, $2, $3)
+	`, relation.FilmID, relation.RelatedFilmID, relation.Type)
+	if err != nil {
+		return relationError(err, relation)
+	}
+
+	return nil
+}
+
+// RemoveRelation removes the relation between films in either direction.
+func (r *FranchiseRepo) RemoveRelation(
+	_ context.Context, filmId uint, relatedFilmId uint,
+) error {
+	result, err := r.db.Exec(`
+	DELETE FROM film_relations
+	WHERE (film_id = $1 AND related_film_id = $2)
+		OR (film_id = $2 AND related_film_id = $1)
+	`, filmId, relatedFilmId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &ErrRecordNotFound{
+			tableName: "film_relations",
+			identity:  fmt.Sprintf("%d, %d", filmId, relatedFilmId),
+		}
+	}
+
+	return nil
+}
+
+// GetRelated returns films related to the given one ordered by their
+// release date.
+func (r *FranchiseRepo) GetRelated(
+	ctx context.Context, filmId uint,
+) ([]schemas.RelatedFilmInfo, error) {
+	var exists bool
+	err := r.db.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM films WHERE id = $1)", filmId,
+	).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, &ErrRecordNotFound{
+			tableName: "films",
+			identity:  fmt.Sprintf("%d", filmId),
+		}
+	}
+
+	stmt := `
+	SELECT relations.type, relations.inverse, ` + filmInfoColumns("$2") + `
+	FROM (
+		SELECT related_film_id AS id, type, true AS inverse
+		FROM film_relations WHERE film_id = $1
+		UNION ALL
+		SELECT film_id AS id, type, false AS inverse
+		FROM film_relations WHERE related_film_id = $1
+	) AS relations
+	INNER JOIN films ON relations.id = films.id
+	ORDER BY films.release_date
+	`
+
+	rows, err := r.db.Query(stmt, filmId, localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	related := make([]schemas.RelatedFilmInfo, 0)
+	for rows.Next() {
+		var info schemas.RelatedFilmInfo
+		var inverse bool
+		var date time.Time
+		var poster []byte
+		dest := append(
+			[]any{&info.Relation, &inverse},
+			filmInfoDest(&info.Film, &date, &poster)...,
+		)
+		err = rows.Scan(dest...)
+		if err != nil {
+			return nil, err
+		}
+		info.Film.ReleaseDate = schemas.NewDate(date)
+
+		info.Film.Poster, err = parseImages(poster)
+		if err != nil {
+			return nil, err
+		}
+
+		// stored relation describes the film in the film_id column
+		if inverse {
+			info.Relation = info.Relation.Inverse()
+		}
+
+		related = append(related, info)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return related, nil
+}
+
+func insertFranchiseFilms(tx *sql.Tx, franchiseId uint, filmsIds []uint) error {
+	for i, filmId := range filmsIds {
+		_, err := tx.Exec(`
+		INSERT INTO franchise_films (franchise_id, film_id, position)
+		VALUES ($1, $2, $3);
+		`, franchiseId, filmId, i+1)
+		if err != nil {
+			switch {
+			case strings.Contains(err.Error(), "violates foreign key constraint"):
+				return &ErrRecordNotFound{
+					tableName: "films",
+					identity:  fmt.Sprintf("%d", filmId),
+				}
+			case strings.Contains(err.Error(), "uniq_franchise_films"):
+				return &ErrRecordAlreadyExists{
+					tableName: "franchise_films",
+					identity:  fmt.Sprintf("%d, %d", franchiseId, filmId),
+				}
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// relationEdge returns the relation as the edge going from the earlier
+// or original film to the later or derived one.
+func relationEdge(relation models.FilmRelation) (uint, uint) {
+	if relation.Type == models.PrequelOf {
+		return relation.FilmID, relation.RelatedFilmID
+	}
+
+	return relation.RelatedFilmID, relation.FilmID
+}
+
+// relationGroup returns relation types checked together for cycles.
+func relationGroup(relationType models.RelationType) [2]models.RelationType {
+	switch relationType {
+	case models.SequelOf, models.PrequelOf:
+		return [2]models.RelationType{models.SequelOf, models.PrequelOf}
+	default:
+		return [2]models.RelationType{models.RemakeOf, models.SpinOffOf}
+	}
+}
+
+func relationError(err error, relation models.FilmRelation) error {
+	switch {
+	case strings.Contains(err.Error(), "film_relations_film_id_fkey"):
+		return &ErrRecordNotFound{
+			tableName: "films",
+			identity:  fmt.Sprintf("%d", relation.FilmID),
+		}
+	case strings.Contains(err.Error(), "film_relations_related_film_id_fkey"):
+		return &ErrRecordNotFound{
+			tableName: "films",
+			identity:  fmt.Sprintf("%d", relation.RelatedFilmID),
+		}
+	case strings.Contains(err.Error(), "uniq_film_relations"):
+		return &ErrRecordAlreadyExists{
+			tableName: "film_relations",
+			identity:  fmt.Sprintf("%d, %d", relation.FilmID, relation.RelatedFilmID),
+		}
+	case strings.Contains(err.Error(), "film_relations_not_self"):
+		return ErrRelationCycle
+	default:
+		return err
+	}
+}
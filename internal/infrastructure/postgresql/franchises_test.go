@@ -0,0 +1,97 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+func TestFranchiseRepo_AddRelation(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewFranchiseRepo(db)
+
+	type mockBehavior func(relation models.FilmRelation)
+
+	tests := []struct {
+		name         string
+		relation     models.FilmRelation
+		mockBehavior mockBehavior
+		wantErr      error
+	}{
+		{
+			name: "basic",
+			relation: models.FilmRelation{
+				FilmID: 2, RelatedFilmID: 1, Type: models.SequelOf,
+			},
+			mockBehavior: func(relation models.FilmRelation) {
+				mock.ExpectBegin()
+				mock.ExpectExec("LOCK TABLE film_relations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH RECURSIVE").
+					WithArgs(uint(2), uint(1), models.PrequelOf, models.SequelOf, models.PrequelOf).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec("INSERT INTO film_relations").
+					WithArgs(relation.FilmID, relation.RelatedFilmID, relation.Type).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: nil,
+		},
+		{
+			name: "prequel cycle",
+			relation: models.FilmRelation{
+				FilmID: 1, RelatedFilmID: 2, Type: models.PrequelOf,
+			},
+			mockBehavior: func(relation models.FilmRelation) {
+				mock.ExpectBegin()
+				mock.ExpectExec("LOCK TABLE film_relations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH RECURSIVE").
+					WithArgs(uint(2), uint(1), models.PrequelOf, models.SequelOf, models.PrequelOf).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrRelationCycle,
+		},
+		{
+			name: "remake cycle",
+			relation: models.FilmRelation{
+				FilmID: 3, RelatedFilmID: 1, Type: models.RemakeOf,
+			},
+			mockBehavior: func(relation models.FilmRelation) {
+				mock.ExpectBegin()
+				mock.ExpectExec("LOCK TABLE film_relations").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("WITH RECURSIVE").
+					WithArgs(uint(3), uint(1), models.PrequelOf, models.RemakeOf, models.SpinOffOf).
+					WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrRelationCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehavior(tt.relation)
+
+			err := repo.AddRelation(context.Background(), tt.relation)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("FranchiseRepo.AddRelation() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
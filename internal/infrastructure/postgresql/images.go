@@ -0,0 +1,169 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type ImageRepo struct {
+	db *sql.DB
+}
+
+func NewImageRepo(db *sql.DB) *ImageRepo {
+	return &ImageRepo{
+		db: db,
+	}
+}
+
+// Replace removes images of the owner and stores the new ones. Returns
+// the keys of the removed images.
+func (r *ImageRepo) Replace(
+	ctx context.Context,
+	owner models.ImageOwner,
+	ownerId uint,
+	images []models.Image,
+) (replaced []string, err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = end(err) }()
+
+	column := imageOwnerColumn(owner)
+
+	rows, err := tx.Query(
+		fmt.Sprintf("DELETE FROM images WHERE %s = $1 RETURNING key", column), ownerId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	replaced = make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		replaced = append(replaced, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	stmt := fmt.Sprintf(`
+	INSERT INTO images (%s, key, url, width, height, original)
+	VALUES ($1, $2, $3, $4, $5, $6);
+	`, column)
+	for _, image := range images {
+		_, err = tx.Exec(
+			stmt,
+			ownerId,
+			image.Key,
+			image.URL,
+			image.Width,
+			image.Height,
+			image.Original,
+		)
+		if err != nil {
+			if strings.Contains(err.Error(), "violates foreign key constraint") {
+				return nil, &ErrRecordNotFound{
+					tableName: string(owner) + "s",
+					identity:  fmt.Sprintf("%d", ownerId),
+				}
+			}
+			return nil, err
+		}
+	}
+
+	return replaced, nil
+}
+
+// Remove deletes image records of the owner.
+func (r *ImageRepo) Remove(
+	ctx context.Context, owner models.ImageOwner, ownerId uint,
+) ([]string, error) {
+	stmt := fmt.Sprintf(
+		"DELETE FROM images WHERE %s = $1 RETURNING key", imageOwnerColumn(owner),
+	)
+
+	rows, err := conn(ctx, r.db).Query(stmt, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	removed := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err = rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		removed = append(removed, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(removed) == 0 {
+		return nil, &ErrRecordNotFound{
+			tableName: "images",
+			identity:  fmt.Sprintf("%s %d", owner, ownerId),
+		}
+	}
+
+	return removed, nil
+}
+
+func imageOwnerColumn(owner models.ImageOwner) string {
+	if owner == models.ActorImage {
+		return "actor_id"
+	}
+	return "film_id"
+}
+
+// imagesSubquery returns the select expression aggregating images
+// of the owner referenced by ownerRef column into json array.
+func imagesSubquery(owner models.ImageOwner, ownerRef string) string {
+	return fmt.Sprintf(`(
+		SELECT json_agg(json_build_object(
+			'url', url, 'width', width, 'height', height, 'original', original
+		) ORDER BY width)
+		FROM images WHERE images.%s = %s
+	)`, imageOwnerColumn(owner), ownerRef)
+}
+
+// parseImages converts images aggregated by imagesSubquery.
+func parseImages(data []byte) (*schemas.ImageInfo, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var rows []struct {
+		URL      string `json:"url"`
+		Width    uint   `json:"width"`
+		Height   uint   `json:"height"`
+		Original bool   `json:"original"`
+	}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+
+	images := make([]models.Image, 0, len(rows))
+	for _, row := range rows {
+		images = append(images, models.Image{
+			URL:      row.URL,
+			Width:    row.Width,
+			Height:   row.Height,
+			Original: row.Original,
+		})
+	}
+
+	return schemas.NewImageInfo(images), nil
+}
@@ -0,0 +1,85 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type ImportRepo struct {
+	db *sql.DB
+}
+
+func NewImportRepo(db *sql.DB) *ImportRepo {
+	return &ImportRepo{
+		db: db,
+	}
+}
+
+// ResolveFilm returns id of the only film with the title released on
+// the date, the natural key of the imported cast links.
+func (r *ImportRepo) ResolveFilm(
+	ctx context.Context, title string, releaseDate time.Time,
+) (uint, error) {
+	return resolveOne(
+		conn(ctx, r.db), "films", filmKey(title, releaseDate.Format("02-01-2006")), `
+		SELECT id FROM films WHERE title = $1 AND release_date = $2 LIMIT 2
+		`, title, releaseDate,
+	)
+}
+
+// ResolveActor returns id of the only actor with the name born on the
+// birthday.
+func (r *ImportRepo) ResolveActor(
+	ctx context.Context, firstName string, lastName string, birthday time.Time,
+) (uint, error) {
+	return resolveOne(
+		conn(ctx, r.db), "actors", actorKey(firstName, lastName, birthday.Format("02-01-2006")), `
+		SELECT id FROM actors
+		WHERE first_name = $1 AND last_name = $2 AND birthday = $3
+		LIMIT 2
+		`, firstName, lastName, birthday,
+	)
+}
+
+// resolveOne returns id of the only record selected by the query.
+func resolveOne(
+	db querier, table string, identity string, query string, args ...any,
+) (uint, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	ids := make([]uint, 0, 2)
+	for rows.Next() {
+		var id uint
+		if err = rows.Scan(&id); err != nil {
+			return 0, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	switch len(ids) {
+	case 0:
+		return 0, &ErrRecordNotFound{tableName: table, identity: identity}
+	case 1:
+		return ids[0], nil
+	default:
+		return 0, fmt.Errorf("%w in %s with %s", ErrAmbiguousRecord, table, identity)
+	}
+}
+
+func actorKey(firstName string, lastName string, birthday string) string {
+	return fmt.Sprintf("%s %s, %s", firstName, lastName, birthday)
+}
+
+func filmKey(title string, releaseDate string) string {
+	return fmt.Sprintf("%s, %s", title, releaseDate)
+}
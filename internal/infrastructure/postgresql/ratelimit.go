@@ -0,0 +1,59 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
+)
+
+// RateLimitStore keeps the token buckets in the unlogged table shared by
+// the instances of the application.
+type RateLimitStore struct {
+	db *sql.DB
+}
+
+func NewRateLimitStore(db *sql.DB) *RateLimitStore {
+	return &RateLimitStore{
+		db: db,
+	}
+}
+
+// Update locks the bucket of the key until the updated bucket is stored.
+// The first requests of the client may race for the missing row, the last
+// of them wins.
+func (r *RateLimitStore) Update(
+	ctx context.Context, key string, fn func(bucket ratelimit.Bucket, found bool) ratelimit.Bucket,
+) (err error) {
+	tx, end, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer func() { err = end(err) }()
+
+	var bucket ratelimit.Bucket
+	found := true
+	err = tx.QueryRowContext(ctx, `
+	SELECT tokens, updated_at FROM rate_limits WHERE key = $1 FOR UPDATE
+	`, key).Scan(&bucket.Tokens, &bucket.Updated)
+	if errors.Is(err, sql.ErrNoRows) {
+		found = false
+	} else if err != nil {
+		return err
+	}
+
+	bucket = fn(bucket, found)
+
+	_, err = tx.ExecContext(ctx, `
+	INSERT INTO rate_limits (key, tokens, updated_at) VALUES ($1, $2, $3)
+	ON CONFLICT (key) DO UPDATE SET tokens = EXCLUDED.tokens, updated_at = EXCLUDED.updated_at
+	`, key, bucket.Tokens, bucket.Updated)
+	return err
+}
+
+func (r *RateLimitStore) DeleteIdle(ctx context.Context, before time.Time) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM rate_limits WHERE updated_at < $1`, before)
+	return err
+}
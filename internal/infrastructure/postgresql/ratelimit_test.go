@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sivistrukov/vk-assigment/internal/services/ratelimit"
+)
+
+func TestRateLimitStore_Update(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	store := NewRateLimitStore(db)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("new bucket", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT tokens, updated_at FROM rate_limits").
+			WithArgs("read:ip:127.0.0.1").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec("INSERT INTO rate_limits").
+			WithArgs("read:ip:127.0.0.1", 9.0, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := store.Update(context.Background(), "read:ip:127.0.0.1",
+			func(bucket ratelimit.Bucket, found bool) ratelimit.Bucket {
+				if found {
+					t.Errorf("RateLimitStore.Update() found the missing bucket")
+				}
+				return ratelimit.Bucket{Tokens: 9, Updated: now}
+			})
+		if err != nil {
+			t.Errorf("RateLimitStore.Update() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("existing bucket", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT tokens, updated_at FROM rate_limits").
+			WithArgs("write:user:admin").
+			WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at"}).AddRow(3.5, now))
+		mock.ExpectExec("INSERT INTO rate_limits").
+			WithArgs("write:user:admin", 2.5, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := store.Update(context.Background(), "write:user:admin",
+			func(bucket ratelimit.Bucket, found bool) ratelimit.Bucket {
+				bucket.Tokens--
+				return bucket
+			})
+		if err != nil {
+			t.Errorf("RateLimitStore.Update() error = %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// statsViews are the materialized views backing the statistics.
+var statsViews = []string{
+	"stats_films_by_year",
+	"stats_rating_histogram",
+	"stats_actor_films",
+	"stats_summary",
+}
+
+type StatsRepo struct {
+	db *sql.DB
+}
+
+func NewStatsRepo(db *sql.DB) *StatsRepo {
+	return &StatsRepo{
+		db: db,
+	}
+}
+
+// Refresh recomputes the statistics views without blocking readers.
+func (r *StatsRepo) Refresh(ctx context.Context) error {
+	for _, view := range statsViews {
+		stmt := fmt.Sprintf("REFRESH MATERIALIZED VIEW CONCURRENTLY %s", view)
+		if _, err := r.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("refresh %s: %w", view, err)
+		}
+	}
+
+	return nil
+}
+
+// GetStats returns the statistics with the limit most prolific actors.
+func (r *StatsRepo) GetStats(
+	ctx context.Context, limit uint,
+) (schemas.StatsResponse, error) {
+	var stats schemas.StatsResponse
+
+	err := r.db.QueryRow(`
+	SELECT COALESCE(avg_cast_size, 0), male_actors, female_actors,
+		avg_age_at_release, refreshed_at
+	FROM stats_summary
+	`).Scan(
+		&stats.AverageCastSize,
+		&stats.SexSplit.Male,
+		&stats.SexSplit.Female,
+		&stats.AverageAgeAtRelease,
+		&stats.RefreshedAt,
+	)
+	if err != nil {
+		return stats, err
+	}
+
+	stats.FilmsByYear, err = r.getFilmsByYear()
+	if err != nil {
+		return stats, err
+	}
+
+	stats.RatingHistogram, err = r.getRatingHistogram()
+	if err != nil {
+		return stats, err
+	}
+
+	stats.ProlificActors, err = r.getProlificActors(ctx, limit)
+	if err != nil {
+		return stats, err
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepo) getFilmsByYear() ([]schemas.YearCount, error) {
+	rows, err := r.db.Query(
+		"SELECT year, films FROM stats_films_by_year ORDER BY year",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]schemas.YearCount, 0)
+	for rows.Next() {
+		var count schemas.YearCount
+		if err = rows.Scan(&count.Year, &count.Films); err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *StatsRepo) getRatingHistogram() ([]schemas.RatingCount, error) {
+	rows, err := r.db.Query(
+		"SELECT rating, films FROM stats_rating_histogram ORDER BY rating",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make([]schemas.RatingCount, 0, 11)
+	for rows.Next() {
+		var count schemas.RatingCount
+		if err = rows.Scan(&count.Rating, &count.Films); err != nil {
+			return nil, err
+		}
+
+		counts = append(counts, count)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *StatsRepo) getProlificActors(
+	ctx context.Context, limit uint,
+) ([]schemas.ActorFilmCount, error) {
+	stmt := `
+	SELECT actors.id, CONCAT_WS(' ', ` +
+		translated("actors", "first_name", "$2") + `, ` +
+		translated("actors", "last_name", "$2") + `),
+		stats_actor_films.films
+	FROM stats_actor_films
+	INNER JOIN actors ON actors.id = stats_actor_films.actor_id
+	WHERE stats_actor_films.films > 0
+	ORDER BY stats_actor_films.films DESC, actors.id
+	LIMIT $1
+	`
+
+	rows, err := r.db.Query(stmt, limit, localesParam(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	actors := make([]schemas.ActorFilmCount, 0)
+	for rows.Next() {
+		var actor schemas.ActorFilmCount
+		err = rows.Scan(&actor.Actor.ID, &actor.Actor.Name, &actor.Films)
+		if err != nil {
+			return nil, err
+		}
+
+		actors = append(actors, actor)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return actors, nil
+}
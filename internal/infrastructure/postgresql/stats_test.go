@@ -0,0 +1,49 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestStatsRepo_Refresh(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewStatsRepo(db)
+
+	t.Run("basic", func(t *testing.T) {
+		for _, view := range statsViews {
+			mock.ExpectExec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + view).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+
+		if err := repo.Refresh(context.Background()); err != nil {
+			t.Errorf("error was not expected while refreshing stats: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		dbErr := errors.New("could not refresh")
+		mock.ExpectExec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + statsViews[0]).
+			WillReturnError(dbErr)
+
+		err := repo.Refresh(context.Background())
+		if !errors.Is(err, dbErr) {
+			t.Errorf("expected error %v, got %v", dbErr, err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
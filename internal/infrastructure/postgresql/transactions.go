@@ -0,0 +1,137 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txKey struct{}
+
+// txState is the transaction shared through the context.
+type txState struct {
+	tx          *sql.Tx
+	afterCommit []func(context.Context)
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx.
+type querier interface {
+	Exec(string, ...any) (sql.Result, error)
+	Query(string, ...any) (*sql.Rows, error)
+	QueryRow(string, ...any) *sql.Row
+	Prepare(string) (*sql.Stmt, error)
+}
+
+type TxManager struct {
+	db *sql.DB
+}
+
+func NewTxManager(db *sql.DB) *TxManager {
+	return &TxManager{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn in a transaction which is committed if fn
+// returns no error. Repositories called with the context passed to fn
+// join the transaction instead of using their own connections.
+func (m *TxManager) WithinTransaction(
+	ctx context.Context, fn func(context.Context) error,
+) (err error) {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	state := &txState{tx: tx}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+			return
+		}
+		if err = tx.Commit(); err != nil {
+			return
+		}
+		for _, fn := range state.afterCommit {
+			fn(ctx)
+		}
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, state))
+}
+
+// WithinSavepoint runs fn in a savepoint of the transaction of the context
+// which is rolled back to if fn returns an error, so the transaction can go
+// on without the changes of fn. The functions fn added with AfterCommit are
+// dropped with its changes. Without the transaction of the context fn runs
+// in a transaction of its own.
+func (m *TxManager) WithinSavepoint(
+	ctx context.Context, fn func(context.Context) error,
+) error {
+	state, ok := ctx.Value(txKey{}).(*txState)
+	if !ok {
+		return m.WithinTransaction(ctx, fn)
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT within_savepoint"); err != nil {
+		return err
+	}
+
+	hooks := len(state.afterCommit)
+	if err := fn(ctx); err != nil {
+		state.afterCommit = state.afterCommit[:hooks]
+		if _, rollbackErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT within_savepoint"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+
+	_, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT within_savepoint")
+	return err
+}
+
+// AfterCommit runs fn once the transaction of the context is committed,
+// and right away if there is no transaction. It is meant for side effects
+// which can not be rolled back, like removing files.
+func AfterCommit(ctx context.Context, fn func(context.Context)) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+
+	fn(ctx)
+}
+
+// conn returns the transaction of the context or the database.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+
+	return db
+}
+
+// beginTx starts a transaction or joins the transaction of the context.
+// The returned end function commits or rolls back the transaction
+// depending on the error, joined transactions are left to their owner.
+func beginTx(
+	ctx context.Context, db *sql.DB,
+) (*sql.Tx, func(error) error, error) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx, func(err error) error { return err }, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	end := func(err error) error {
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		return tx.Commit()
+	}
+
+	return tx, end, nil
+}
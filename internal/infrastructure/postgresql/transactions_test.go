@@ -0,0 +1,124 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestTxManager_WithinTransaction(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	manager := NewTxManager(db)
+	repo := NewActorRepo(db)
+
+	t.Run("repositories join the transaction", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare("DELETE FROM actors").
+			ExpectExec().
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectPrepare("DELETE FROM actors").
+			ExpectExec().
+			WithArgs(2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		committed := false
+		err := manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func(context.Context) { committed = true })
+			if err := repo.Remove(ctx, 1); err != nil {
+				return err
+			}
+			if committed {
+				t.Error("after commit function ran before the commit")
+			}
+			return repo.Remove(ctx, 2)
+		})
+		if err != nil {
+			t.Errorf("error was not expected: %s", err)
+		}
+		if !committed {
+			t.Error("after commit function did not run")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("rolled back on error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectPrepare("DELETE FROM actors").
+			ExpectExec().
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		committed := false
+		err := manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func(context.Context) { committed = true })
+			return repo.Remove(ctx, 3)
+		})
+
+		var notFoundErr *ErrRecordNotFound
+		if !errors.As(err, &notFoundErr) {
+			t.Errorf("expected not found error, got %v", err)
+		}
+		if committed {
+			t.Error("after commit function ran after the rollback")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+	t.Run("savepoint is rolled back on error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("SAVEPOINT within_savepoint").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectPrepare("DELETE FROM actors").
+			ExpectExec().
+			WithArgs(4).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ROLLBACK TO SAVEPOINT within_savepoint").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("SAVEPOINT within_savepoint").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectPrepare("DELETE FROM actors").
+			ExpectExec().
+			WithArgs(5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("RELEASE SAVEPOINT within_savepoint").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		committed := make([]uint, 0)
+		remove := func(id uint) func(context.Context) error {
+			return func(ctx context.Context) error {
+				AfterCommit(ctx, func(context.Context) { committed = append(committed, id) })
+				return repo.Remove(ctx, id)
+			}
+		}
+
+		err := manager.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			var notFoundErr *ErrRecordNotFound
+			if err := manager.WithinSavepoint(ctx, remove(4)); !errors.As(err, &notFoundErr) {
+				t.Errorf("expected not found error, got %v", err)
+			}
+			return manager.WithinSavepoint(ctx, remove(5))
+		})
+		if err != nil {
+			t.Errorf("error was not expected: %s", err)
+		}
+		if len(committed) != 1 || committed[0] != 5 {
+			t.Errorf("after commit functions ran for %v, want [5]", committed)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
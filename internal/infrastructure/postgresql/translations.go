@@ -0,0 +1,224 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/i18n"
+)
+
+type TranslationRepo struct {
+	db *sql.DB
+}
+
+func NewTranslationRepo(db *sql.DB) *TranslationRepo {
+	return &TranslationRepo{
+		db: db,
+	}
+}
+
+// SetFilmTranslation creates or replaces the film translation.
+func (r *TranslationRepo) SetFilmTranslation(
+	_ context.Context, translation models.FilmTranslation,
+) error {
+	stmt := `
+	INSERT INTO film_translations (film_id, locale, title, description)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (film_id, locale)
+	DO UPDATE SET title = EXCLUDED.title, description = EXCLUDED.description;
+	`
+	_, err := r.db.Exec(
+		stmt,
+		translation.FilmID,
+		translation.Locale,
+		translation.Title,
+		translation.Description,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return &ErrRecordNotFound{
+				tableName: "films",
+				identity:  fmt.Sprintf("%d", translation.FilmID),
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *TranslationRepo) RemoveFilmTranslation(
+	_ context.Context, filmId uint, locale string,
+) error {
+	return removeTranslation(r.db, "film_translations", "film_id", filmId, locale)
+}
+
+func (r *TranslationRepo) GetFilmTranslations(
+	_ context.Context, filmId uint,
+) ([]schemas.FilmTranslationInfo, error) {
+	stmt := `
+	SELECT locale, title, description
+	FROM film_translations
+	WHERE film_id = $1
+	ORDER BY locale
+	`
+
+	rows, err := r.db.Query(stmt, filmId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := make([]schemas.FilmTranslationInfo, 0)
+	for rows.Next() {
+		var translation schemas.FilmTranslationInfo
+		err = rows.Scan(
+			&translation.Locale,
+			&translation.Title,
+			&translation.Description,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		translations = append(translations, translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// SetActorTranslation creates or replaces the actor translation.
+func (r *TranslationRepo) SetActorTranslation(
+	_ context.Context, translation models.ActorTranslation,
+) error {
+	stmt := `
+	INSERT INTO actor_translations (actor_id, locale, first_name, last_name, middle_name)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (actor_id, locale)
+	DO UPDATE SET first_name = EXCLUDED.first_name,
+		last_name = EXCLUDED.last_name,
+		middle_name = EXCLUDED.middle_name;
+	`
+	_, err := r.db.Exec(
+		stmt,
+		translation.ActorID,
+		translation.Locale,
+		translation.FirstName,
+		translation.LastName,
+		translation.MiddleName,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "violates foreign key constraint") {
+			return &ErrRecordNotFound{
+				tableName: "actors",
+				identity:  fmt.Sprintf("%d", translation.ActorID),
+			}
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *TranslationRepo) RemoveActorTranslation(
+	_ context.Context, actorId uint, locale string,
+) error {
+	return removeTranslation(r.db, "actor_translations", "actor_id", actorId, locale)
+}
+
+func (r *TranslationRepo) GetActorTranslations(
+	_ context.Context, actorId uint,
+) ([]schemas.ActorTranslationInfo, error) {
+	stmt := `
+	SELECT locale, first_name, last_name, middle_name
+	FROM actor_translations
+	WHERE actor_id = $1
+	ORDER BY locale
+	`
+
+	rows, err := r.db.Query(stmt, actorId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := make([]schemas.ActorTranslationInfo, 0)
+	for rows.Next() {
+		var translation schemas.ActorTranslationInfo
+		err = rows.Scan(
+			&translation.Locale,
+			&translation.FirstName,
+			&translation.LastName,
+			&translation.MiddleName,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		translations = append(translations, translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+func removeTranslation(
+	db execer, table string, ownerColumn string, ownerId uint, locale string,
+) error {
+	stmt := fmt.Sprintf(
+		"DELETE FROM %s WHERE %s = $1 AND locale = $2", table, ownerColumn,
+	)
+
+	result, err := db.Exec(stmt, ownerId, locale)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &ErrRecordNotFound{
+			tableName: table,
+			identity:  fmt.Sprintf("%d, %s", ownerId, locale),
+		}
+	}
+
+	return nil
+}
+
+// localesParam returns query parameter with the preferred locales
+// stored in the context.
+func localesParam(ctx context.Context) any {
+	return pq.Array(i18n.Locales(ctx))
+}
+
+// translated returns the select expression picking the column value from
+// the first translation matching the locales parameter and falling back
+// to the column of the table itself. The table is "films" or "actors".
+func translated(table string, column string, locales string) string {
+	owner := strings.TrimSuffix(table, "s")
+
+	return fmt.Sprintf(`COALESCE((
+		SELECT tr.%[2]s FROM %[3]s_translations AS tr
+		WHERE tr.%[3]s_id = %[1]s.id
+			AND tr.locale = ANY(%[4]s::varchar[])
+			AND tr.%[2]s IS NOT NULL
+		ORDER BY array_position(%[4]s::varchar[], tr.locale)
+		LIMIT 1
+	), %[1]s.%[2]s)`, table, column, owner, locales)
+}
@@ -3,6 +3,7 @@ package postgresql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/sivistrukov/vk-assigment/internal/models"
 )
@@ -61,3 +62,25 @@ func (r *UserRepo) GetByUsername(_ context.Context, username string) (models.Use
 
 	return user, nil
 }
+
+func (r *UserRepo) GetByID(ctx context.Context, id uint) (models.User, error) {
+	stmt := `
+	SELECT id, username, password, is_admin
+	FROM users WHERE id = $1
+	`
+	row := r.db.QueryRowContext(ctx, stmt, id)
+
+	var user models.User
+	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.IsAdmin)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return user, &ErrRecordNotFound{
+				tableName: "users",
+				identity:  fmt.Sprintf("%d", id),
+			}
+		}
+		return user, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,81 @@
+package postgresql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type execer interface {
+	Exec(string, ...any) (sql.Result, error)
+}
+
+// updateRecord sets columns of the table row with the given id.
+// Keys of the updates map are column names.
+func updateRecord(
+	db execer, table string, id uint, updates map[string]any,
+) error {
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("UPDATE %s SET ", table))
+
+	values := make([]any, 0, len(updates)+1)
+	i := 0
+	for field, value := range updates {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		i++
+
+		builder.WriteString(fmt.Sprintf("%s = $%v", field, i))
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	builder.WriteString(fmt.Sprintf(" WHERE id = $%v", i+1))
+	values = append(values, id)
+
+	result, err := db.Exec(builder.String(), values...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &ErrRecordNotFound{
+			tableName: table,
+			identity:  fmt.Sprintf("%d", id),
+		}
+	}
+
+	return nil
+}
+
+// removeRecord deletes the table row with the given id.
+func removeRecord(db execer, table string, id uint) error {
+	result, err := db.Exec(
+		fmt.Sprintf("DELETE FROM %s WHERE id = $1", table), id,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &ErrRecordNotFound{
+			tableName: table,
+			identity:  fmt.Sprintf("%d", id),
+		}
+	}
+
+	return nil
+}
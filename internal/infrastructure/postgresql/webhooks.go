@@ -0,0 +1,265 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type WebhookRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookRepo(db *sql.DB) *WebhookRepo {
+	return &WebhookRepo{
+		db: db,
+	}
+}
+
+// writeOutbox records the change of the film in the transaction of the
+// change, so the webhooks are called only for the committed changes.
+func writeOutbox(tx *sql.Tx, eventType models.EventType, filmId uint) error {
+	_, err := tx.Exec(`
+	INSERT INTO webhook_outbox (type, film_id) VALUES ($1, $2)
+	`, eventType, filmId)
+	return err
+}
+
+func (r *WebhookRepo) Create(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.QueryRowContext(ctx, `
+	INSERT INTO webhooks (url, event_types, secret) VALUES ($1, $2, $3)
+	RETURNING id, created_at
+	`, webhook.URL, eventTypesArray(webhook.EventTypes), webhook.Secret,
+	).Scan(&webhook.ID, &webhook.CreatedAt)
+}
+
+func (r *WebhookRepo) Update(ctx context.Context, webhook *models.Webhook) error {
+	err := r.db.QueryRowContext(ctx, `
+	UPDATE webhooks SET url = $1, event_types = $2, secret = $3
+	WHERE id = $4
+	RETURNING created_at
+	`, webhook.URL, eventTypesArray(webhook.EventTypes), webhook.Secret, webhook.ID,
+	).Scan(&webhook.CreatedAt)
+	if err == sql.ErrNoRows {
+		return &ErrRecordNotFound{
+			tableName: "webhooks",
+			identity:  fmt.Sprintf("%d", webhook.ID),
+		}
+	}
+
+	return err
+}
+
+func (r *WebhookRepo) Remove(ctx context.Context, id uint) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return &ErrRecordNotFound{
+			tableName: "webhooks",
+			identity:  fmt.Sprintf("%d", id),
+		}
+	}
+
+	return nil
+}
+
+func (r *WebhookRepo) GetList(ctx context.Context) ([]models.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `
+	SELECT id, url, event_types, secret, created_at FROM webhooks
+	ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]models.Webhook, 0)
+	for rows.Next() {
+		var webhook models.Webhook
+		var eventTypes pq.StringArray
+		err = rows.Scan(
+			&webhook.ID, &webhook.URL, &eventTypes, &webhook.Secret, &webhook.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook.EventTypes = make([]models.EventType, 0, len(eventTypes))
+		for _, eventType := range eventTypes {
+			webhook.EventTypes = append(webhook.EventTypes, models.EventType(eventType))
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// GetDeliveries returns the latest deliveries of the webhook.
+func (r *WebhookRepo) GetDeliveries(
+	ctx context.Context, webhookId uint, limit uint,
+) ([]models.WebhookDelivery, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `
+	SELECT EXISTS (SELECT 1 FROM webhooks WHERE id = $1)
+	`, webhookId).Scan(&exists)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, &ErrRecordNotFound{
+			tableName: "webhooks",
+			identity:  fmt.Sprintf("%d", webhookId),
+		}
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+	SELECT `+deliveryColumns+` FROM webhook_deliveries AS d
+	WHERE d.webhook_id = $1
+	ORDER BY d.id DESC
+	LIMIT $2
+	`, webhookId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err = rows.Scan(deliveryDest(&delivery)...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// FanOut moves up to limit changes from the outbox to the deliveries of
+// the webhooks subscribed to them.
+func (r *WebhookRepo) FanOut(ctx context.Context, limit uint) error {
+	_, err := r.db.ExecContext(ctx, `
+	WITH events AS (
+		DELETE FROM webhook_outbox WHERE id IN (
+			SELECT id FROM webhook_outbox
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, type, film_id, created_at
+	)
+	INSERT INTO webhook_deliveries (webhook_id, event_id, type, film_id, occurred_at)
+	SELECT webhooks.id, events.id, events.type, events.film_id, events.created_at
+	FROM events
+	INNER JOIN webhooks ON events.type = ANY (webhooks.event_types)
+	ORDER BY events.id
+	`, limit)
+	return err
+}
+
+// ClaimDeliveries returns up to limit pending deliveries which are due
+// along with their webhooks. The deliveries are counted as attempted and
+// postponed for the lease, so other instances do not pick them up while
+// they are being sent.
+func (r *WebhookRepo) ClaimDeliveries(
+	ctx context.Context, limit uint, lease time.Duration,
+) ([]models.WebhookDelivery, map[uint]models.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `
+	UPDATE webhook_deliveries AS d SET
+		attempts = d.attempts + 1,
+		last_attempt_at = now(),
+		next_attempt_at = now() + $2 * INTERVAL '1 millisecond'
+	FROM webhooks AS w
+	WHERE w.id = d.webhook_id AND d.id IN (
+		SELECT id FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= now()
+		ORDER BY next_attempt_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING `+deliveryColumns+`, w.url, w.secret
+	`, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	webhooks := make(map[uint]models.Webhook)
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		var webhook models.Webhook
+		dest := append(deliveryDest(&delivery), &webhook.URL, &webhook.Secret)
+		if err = rows.Scan(dest...); err != nil {
+			return nil, nil, err
+		}
+
+		webhook.ID = delivery.WebhookID
+		webhooks[webhook.ID] = webhook
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return deliveries, webhooks, nil
+}
+
+// RecordAttempt stores the result of the last attempt of the delivery.
+func (r *WebhookRepo) RecordAttempt(
+	ctx context.Context, delivery models.WebhookDelivery,
+) error {
+	_, err := r.db.ExecContext(ctx, `
+	UPDATE webhook_deliveries SET
+		status = $1, next_attempt_at = $2, response_status = $3, error = $4
+	WHERE id = $5
+	`, delivery.Status, delivery.NextAttemptAt, delivery.ResponseStatus,
+		delivery.Error, delivery.ID)
+	return err
+}
+
+// deliveryColumns are selected from the webhook_deliveries aliased as d.
+const deliveryColumns = `d.id, d.webhook_id, d.event_id, d.type, d.film_id,
+	d.occurred_at, d.status, d.attempts, d.next_attempt_at, d.last_attempt_at,
+	d.response_status, d.error, d.created_at`
+
+func deliveryDest(delivery *models.WebhookDelivery) []any {
+	return []any{
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.Type,
+		&delivery.FilmID,
+		&delivery.OccurredAt,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.Error,
+		&delivery.CreatedAt,
+	}
+}
+
+func eventTypesArray(eventTypes []models.EventType) pq.StringArray {
+	values := make(pq.StringArray, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		values = append(values, string(eventType))
+	}
+
+	return values
+}
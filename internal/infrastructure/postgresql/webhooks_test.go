@@ -0,0 +1,96 @@
+package postgresql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+func TestFilmRepo_UpdateWritesOutbox(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewFilmRepo(db)
+
+	t.Run("basic", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE films SET title").WithArgs("Drive", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO webhook_outbox").WithArgs(models.FilmUpdated, 1).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Update(context.Background(), 1, map[string]any{"title": "Drive"})
+		if err != nil {
+			t.Errorf("error was not expected while updating film: %s", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("outbox error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE films SET title").WithArgs("Drive", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO webhook_outbox").WithArgs(models.FilmUpdated, 1).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		err := repo.Update(context.Background(), 1, map[string]any{"title": "Drive"})
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	t.Run("missing film", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE films SET title").WithArgs("Drive", 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.Update(context.Background(), 2, map[string]any{"title": "Drive"})
+		var notFoundErr *ErrRecordNotFound
+		if !errors.As(err, &notFoundErr) {
+			t.Errorf("expected ErrRecordNotFound, got %v", err)
+		}
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+}
+
+func TestWebhookRepo_Remove(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+
+	mock.ExpectExec("DELETE FROM webhooks").WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.Remove(context.Background(), 3)
+	var notFoundErr *ErrRecordNotFound
+	if !errors.As(err, &notFoundErr) || notFoundErr.tableName != "webhooks" {
+		t.Errorf("expected webhooks ErrRecordNotFound, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
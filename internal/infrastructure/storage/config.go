@@ -0,0 +1,15 @@
+package storage
+
+import "os"
+
+type Config struct {
+	Dir     string
+	BaseURL string
+}
+
+func NewConfig() Config {
+	return Config{
+		Dir:     os.Getenv("MEDIA_DIR"),
+		BaseURL: os.Getenv("MEDIA_URL"),
+	}
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrInvalidKey = errors.New("invalid storage key")
+)
+
+// FileSystem stores blobs as files under the root directory.
+// Blob keys are slash separated paths relative to the root.
+type FileSystem struct {
+	root    string
+	baseURL string
+}
+
+func NewFileSystem(cfg Config) (*FileSystem, error) {
+	root := cfg.Dir
+	if root == "" {
+		root = "media"
+	}
+
+	err := os.MkdirAll(root, 0o755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create media directory: %w", err)
+	}
+
+	return &FileSystem{
+		root:    root,
+		baseURL: strings.TrimSuffix(cfg.BaseURL, "/"),
+	}, nil
+}
+
+// Put writes the blob, replacing an existing one with the same key.
+func (s *FileSystem) Put(_ context.Context, key string, data io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Remove removes the blob, removing the missing blob is not an error.
+// The directory left empty by the blob is removed as well.
+func (s *FileSystem) Remove(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// the directory is kept if other blobs are still stored in it
+	_ = os.Remove(filepath.Dir(path))
+
+	return nil
+}
+
+// RemovePrefix removes every blob which key starts with the given prefix.
+func (s *FileSystem) RemovePrefix(_ context.Context, prefix string) error {
+	path, err := s.path(prefix)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(path)
+}
+
+// URL returns the public address of the blob.
+func (s *FileSystem) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// Handler serves stored blobs, it is expected to be mounted
+// with the base url path stripped.
+func (s *FileSystem) Handler() http.Handler {
+	return http.FileServer(http.Dir(s.root))
+}
+
+func (s *FileSystem) path(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == "." ||
+		strings.HasPrefix(cleaned, "..") {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(s.root, cleaned), nil
+}
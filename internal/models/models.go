@@ -9,6 +9,28 @@ const (
 	Female Sex = "female"
 )
 
+type Visibility string
+
+const (
+	Public  Visibility = "public"
+	Private Visibility = "private"
+)
+
+type AgeRating string
+
+const (
+	RatedG    AgeRating = "G"
+	RatedPG   AgeRating = "PG"
+	RatedPG13 AgeRating = "PG-13"
+	RatedR    AgeRating = "R"
+	RatedNC17 AgeRating = "NC-17"
+	Rated0    AgeRating = "0+"
+	Rated6    AgeRating = "6+"
+	Rated12   AgeRating = "12+"
+	Rated16   AgeRating = "16+"
+	Rated18   AgeRating = "18+"
+)
+
 type User struct {
 	ID       uint   `json:"id"`
 	Username string `json:"username"`
@@ -31,4 +53,165 @@ type Film struct {
 	Description string
 	ReleaseDate time.Time
 	Rating      uint8
+	Runtime     *uint
+	Countries   []string
+	Language    *string
+	AgeRating   *AgeRating
+	Budget      *string
+	BoxOffice   *string
+	Currency    *string
+}
+
+type Collection struct {
+	ID          uint
+	Title       string
+	Description string
+	Visibility  Visibility
+	OwnerID     uint
+}
+
+type ImageOwner string
+
+const (
+	FilmImage  ImageOwner = "film"
+	ActorImage ImageOwner = "actor"
+)
+
+type Image struct {
+	Key      string
+	URL      string
+	Width    uint
+	Height   uint
+	Original bool
+}
+
+type AwardCeremony struct {
+	ID   uint
+	Name string
+}
+
+type AwardCategory struct {
+	ID         uint
+	CeremonyID uint
+	Name       string
+}
+
+type Nomination struct {
+	ID         uint
+	CategoryID uint
+	Year       uint
+	FilmID     *uint
+	ActorID    *uint
+	Won        bool
+}
+
+type FilmTranslation struct {
+	FilmID      uint
+	Locale      string
+	Title       string
+	Description *string
+}
+
+type ActorTranslation struct {
+	ActorID    uint
+	Locale     string
+	FirstName  string
+	LastName   string
+	MiddleName *string
+}
+
+type RelationType string
+
+const (
+	SequelOf  RelationType = "sequel_of"
+	PrequelOf RelationType = "prequel_of"
+	RemakeOf  RelationType = "remake_of"
+	SpinOffOf RelationType = "spin_off_of"
+
+	// OriginalOf and SpunOffInto are never stored, they describe
+	// the inverse side of remake_of and spin_off_of relations.
+	OriginalOf  RelationType = "original_of"
+	SpunOffInto RelationType = "spun_off_into"
+)
+
+// Inverse returns the relation type seen from the related film.
+func (t RelationType) Inverse() RelationType {
+	switch t {
+	case SequelOf:
+		return PrequelOf
+	case PrequelOf:
+		return SequelOf
+	case RemakeOf:
+		return OriginalOf
+	case OriginalOf:
+		return RemakeOf
+	case SpinOffOf:
+		return SpunOffInto
+	case SpunOffInto:
+		return SpinOffOf
+	default:
+		return t
+	}
+}
+
+type FilmRelation struct {
+	FilmID        uint
+	RelatedFilmID uint
+	Type          RelationType
+}
+
+type Franchise struct {
+	ID          uint
+	Name        string
+	Description string
+}
+
+type EventType string
+
+const (
+	FilmCreated  EventType = "film.created"
+	FilmUpdated  EventType = "film.updated"
+	FilmDeleted  EventType = "film.deleted"
+	ActorCreated EventType = "actor.created"
+	ActorUpdated EventType = "actor.updated"
+	ActorDeleted EventType = "actor.deleted"
+)
+
+type Event struct {
+	ID        int64
+	Type      EventType
+	EntityID  uint
+	CreatedAt time.Time
+}
+
+type Webhook struct {
+	ID         uint
+	URL        string
+	EventTypes []EventType
+	Secret     string
+	CreatedAt  time.Time
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      uint
+	EventID        int64
+	Type           EventType
+	FilmID         uint
+	OccurredAt     time.Time
+	Status         DeliveryStatus
+	Attempts       uint
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	Error          *string
+	CreatedAt      time.Time
 }
@@ -0,0 +1,68 @@
+package actors
+
+import (
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// Filmography groups films ordered by the release date into years and
+// summarizes the career of the actor.
+func Filmography(
+	actor schemas.ActorInfo, films []schemas.FilmInfo,
+) schemas.FilmographyResponse {
+	response := schemas.FilmographyResponse{
+		Actor: actor,
+		Years: make([]schemas.FilmographyYear, 0),
+		Summary: schemas.FilmographySummary{
+			Films: uint(len(films)),
+		},
+	}
+	if len(films) == 0 {
+		return response
+	}
+
+	birthday := actor.Birthday.ToTime()
+	var ratings uint
+	for _, film := range films {
+		released := film.ReleaseDate.ToTime()
+		entry := schemas.FilmographyEntry{
+			Film:         film,
+			AgeAtRelease: age(birthday, released),
+		}
+
+		last := len(response.Years) - 1
+		if last < 0 || response.Years[last].Year != released.Year() {
+			response.Years = append(response.Years, schemas.FilmographyYear{
+				Year:  released.Year(),
+				Films: make([]schemas.FilmographyEntry, 0, 1),
+			})
+			last++
+		}
+		response.Years[last].Films = append(response.Years[last].Films, entry)
+
+		ratings += uint(film.Rating)
+	}
+
+	first := films[0].ReleaseDate
+	latest := films[len(films)-1].ReleaseDate
+	averageRating := float64(ratings) / float64(len(films))
+
+	response.Summary.FirstAppearance = &first
+	response.Summary.LatestAppearance = &latest
+	response.Summary.CareerSpan = uint(latest.ToTime().Year() - first.ToTime().Year())
+	response.Summary.AverageRating = &averageRating
+
+	return response
+}
+
+// age returns the number of full years between birthday and date.
+func age(birthday time.Time, date time.Time) int {
+	years := date.Year() - birthday.Year()
+	if date.Month() < birthday.Month() ||
+		date.Month() == birthday.Month() && date.Day() < birthday.Day() {
+		years--
+	}
+
+	return years
+}
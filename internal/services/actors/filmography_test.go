@@ -0,0 +1,66 @@
+package actors
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+func TestFilmography(t *testing.T) {
+	actor := schemas.ActorInfo{
+		ID:       2,
+		Birthday: schemas.NewDate(time.Date(1980, 11, 12, 0, 0, 0, 0, time.UTC)),
+	}
+	film := func(id uint, date string, rating uint8) schemas.FilmInfo {
+		return schemas.FilmInfo{ID: id, ReleaseDate: schemas.Date(date), Rating: rating}
+	}
+
+	t.Run("grouped by year", func(t *testing.T) {
+		films := []schemas.FilmInfo{
+			film(1, "03-11-2011", 7),
+			film(3, "16-12-2011", 6),
+			film(2, "05-10-2017", 8),
+		}
+
+		got := Filmography(actor, films)
+
+		gotYears := make([]int, 0)
+		gotAges := make([]int, 0)
+		for _, year := range got.Years {
+			gotYears = append(gotYears, year.Year)
+			for _, entry := range year.Films {
+				gotAges = append(gotAges, entry.AgeAtRelease)
+			}
+		}
+		if want := []int{2011, 2017}; !reflect.DeepEqual(gotYears, want) {
+			t.Errorf("years = %v, want %v", gotYears, want)
+		}
+		if want := []int{30, 31, 36}; !reflect.DeepEqual(gotAges, want) {
+			t.Errorf("ages = %v, want %v", gotAges, want)
+		}
+
+		summary := got.Summary
+		if summary.Films != 3 || summary.CareerSpan != 6 {
+			t.Errorf("summary = %+v, want 3 films over 6 years", summary)
+		}
+		if *summary.FirstAppearance != "03-11-2011" || *summary.LatestAppearance != "05-10-2017" {
+			t.Errorf("appearances = %s - %s", *summary.FirstAppearance, *summary.LatestAppearance)
+		}
+		if *summary.AverageRating != 7 {
+			t.Errorf("average rating = %v, want 7", *summary.AverageRating)
+		}
+	})
+
+	t.Run("no films", func(t *testing.T) {
+		got := Filmography(actor, nil)
+
+		if len(got.Years) != 0 || got.Summary.Films != 0 {
+			t.Errorf("expected empty filmography, got %+v", got)
+		}
+		if got.Summary.FirstAppearance != nil || got.Summary.AverageRating != nil {
+			t.Errorf("expected empty summary, got %+v", got.Summary)
+		}
+	})
+}
@@ -2,11 +2,19 @@ package actors
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"net/url"
 	"reflect"
+	"strconv"
+	"time"
 
 	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
 	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/cache"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
 	"github.com/sivistrukov/vk-assigment/internal/services/text"
 )
 
@@ -14,22 +22,75 @@ type actorRepo interface {
 	Create(context.Context, *models.Actor) error
 	Update(context.Context, uint, map[string]any) error
 	Remove(context.Context, uint) error
-	GetListWithFilms(context.Context) ([]schemas.ActorWithFilmsResponse, error)
+	GetListWithFilms(context.Context, schemas.ListOptions) ([]schemas.ActorWithFilmsResponse, error)
+	GetWithFilms(context.Context, uint) (schemas.ActorWithFilmsResponse, error)
+	GetCostars(context.Context, uint, uint) ([]schemas.CostarInfo, error)
+	GetPath(context.Context, uint, uint, uint) (schemas.ActorPathResponse, error)
+	GetFilmography(context.Context, uint) (schemas.ActorInfo, []schemas.FilmInfo, error)
+	Merge(context.Context, uint, uint, []string) (schemas.MergeActorsResponse, error)
+	Resolve(context.Context, uint) (uint, error)
+	GetDuplicateCandidates(context.Context, time.Time) ([]schemas.DuplicateCandidate, error)
+}
+
+const (
+	// MaxPathDepth caps the number of films in the chain between actors.
+	MaxPathDepth = 6
+
+	DefaultCostarsLimit = 20
+	MaxCostarsLimit     = 100
+)
+
+var ErrSelfMerge = errors.New("actor can not be merged into itself")
+
+type imageCleaner interface {
+	RemoveActorImages(context.Context, uint) error
+}
+
+type eventPublisher interface {
+	Publish(context.Context, models.EventType, uint)
+}
+
+type readCache interface {
+	Load(ctx context.Context, key string, dest any, load func() error) error
+	Invalidate(context.Context)
 }
 
 type Service struct {
 	actorRepo actorRepo
+	images    imageCleaner
+	events    eventPublisher
+	cache     readCache
 }
 
-func NewService(actorRepo actorRepo) *Service {
+func NewService(
+	actorRepo actorRepo, images imageCleaner, events eventPublisher, cache readCache,
+) *Service {
 	return &Service{
 		actorRepo: actorRepo,
+		images:    images,
+		events:    events,
+		cache:     cache,
 	}
 }
 
+// AddActor creates the actor unless an actor with the same normalized
+// name was born on the same day. The check is skipped if force is set.
 func (s *Service) AddActor(
-	ctx context.Context, request schemas.AddActorRequest,
+	ctx context.Context, request schemas.AddActorRequest, force bool,
 ) (models.Actor, error) {
+	if !force {
+		candidates, err := s.actorRepo.GetDuplicateCandidates(
+			ctx, request.Birthday.ToTime(),
+		)
+		if err != nil {
+			return models.Actor{}, fmt.Errorf("error checking duplicates: %v", err)
+		}
+		name := request.FirstName + " " + request.LastName
+		if id, ok := duplicates.Find(name, candidates); ok {
+			return models.Actor{}, &duplicates.ErrDuplicate{Kind: "actor", ID: id}
+		}
+	}
+
 	actor := models.Actor{
 		FirstName:  request.FirstName,
 		LastName:   request.LastName,
@@ -43,6 +104,9 @@ func (s *Service) AddActor(
 		return models.Actor{}, fmt.Errorf("error creating actor: %v", err)
 	}
 
+	s.events.Publish(ctx, models.ActorCreated, actor.ID)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
 	return actor, nil
 }
 
@@ -80,6 +144,9 @@ func (s *Service) UpdateActor(
 		return err
 	}
 
+	s.events.Publish(ctx, models.ActorUpdated, id)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
 	return nil
 }
 
@@ -116,15 +183,178 @@ func (s *Service) PartialUpdateActor(
 		return err
 	}
 
+	s.events.Publish(ctx, models.ActorUpdated, id)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
 	return nil
 }
 
 func (s *Service) RemoveActor(ctx context.Context, id uint) error {
-	return s.actorRepo.Remove(ctx, id)
+	err := s.actorRepo.Remove(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// image files can not be restored if the transaction is rolled back
+	postgresql.AfterCommit(ctx, func(ctx context.Context) {
+		err := s.images.RemoveActorImages(ctx, id)
+		if err != nil {
+			log.Printf("error removing images of actor %d: %v", id, err)
+		}
+	})
+
+	s.events.Publish(ctx, models.ActorDeleted, id)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
 }
 
 func (s *Service) GetActorsWithFilms(
-	ctx context.Context,
+	ctx context.Context, options schemas.ListOptions,
 ) ([]schemas.ActorWithFilmsResponse, error) {
-	return s.actorRepo.GetListWithFilms(ctx)
+	params := url.Values{}
+	cache.SetList(params, "fields", options.Fields)
+	params.Set("include", strconv.FormatBool(options.Include))
+	cache.SetList(params, "includedFields", options.IncludedFields)
+
+	var actors []schemas.ActorWithFilmsResponse
+	err := s.cache.Load(ctx, cache.Key(ctx, "actors", params), &actors, func() (err error) {
+		actors, err = s.actorRepo.GetListWithFilms(ctx, options)
+		return err
+	})
+
+	return actors, err
+}
+
+func (s *Service) GetActorWithFilms(
+	ctx context.Context, id uint,
+) (schemas.ActorWithFilmsResponse, error) {
+	params := url.Values{}
+	params.Set("id", strconv.FormatUint(uint64(id), 10))
+
+	var actor schemas.ActorWithFilmsResponse
+	err := s.cache.Load(ctx, cache.Key(ctx, "actor", params), &actor, func() (err error) {
+		id, err := s.actorRepo.Resolve(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		actor, err = s.actorRepo.GetWithFilms(ctx, id)
+		return err
+	})
+
+	return actor, err
+}
+
+// GetCostars returns co-actors ranked by the number of shared films.
+// A nil limit means DefaultCostarsLimit, limit is capped at MaxCostarsLimit.
+func (s *Service) GetCostars(
+	ctx context.Context, id uint, limit *uint,
+) ([]schemas.CostarInfo, error) {
+	n := uint(DefaultCostarsLimit)
+	if limit != nil {
+		n = min(*limit, MaxCostarsLimit)
+	}
+
+	params := url.Values{}
+	params.Set("id", strconv.FormatUint(uint64(id), 10))
+	params.Set("limit", strconv.FormatUint(uint64(n), 10))
+
+	var costars []schemas.CostarInfo
+	err := s.cache.Load(ctx, cache.Key(ctx, "costars", params), &costars, func() error {
+		id, err := s.actorRepo.Resolve(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		costars, err = s.actorRepo.GetCostars(ctx, id, n)
+		return err
+	})
+
+	return costars, err
+}
+
+func (s *Service) GetActorPath(
+	ctx context.Context, fromId uint, toId uint,
+) (schemas.ActorPathResponse, error) {
+	params := url.Values{}
+	params.Set("from", strconv.FormatUint(uint64(fromId), 10))
+	params.Set("to", strconv.FormatUint(uint64(toId), 10))
+
+	var path schemas.ActorPathResponse
+	err := s.cache.Load(ctx, cache.Key(ctx, "path", params), &path, func() error {
+		fromId, err := s.actorRepo.Resolve(ctx, fromId)
+		if err != nil {
+			return err
+		}
+
+		toId, err := s.actorRepo.Resolve(ctx, toId)
+		if err != nil {
+			return err
+		}
+
+		path, err = s.actorRepo.GetPath(ctx, fromId, toId, MaxPathDepth)
+		return err
+	})
+
+	return path, err
+}
+
+func (s *Service) GetFilmography(
+	ctx context.Context, id uint,
+) (schemas.FilmographyResponse, error) {
+	params := url.Values{}
+	params.Set("id", strconv.FormatUint(uint64(id), 10))
+
+	var filmography schemas.FilmographyResponse
+	err := s.cache.Load(ctx, cache.Key(ctx, "filmography", params), &filmography, func() error {
+		id, err := s.actorRepo.Resolve(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		actor, films, err := s.actorRepo.GetFilmography(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		filmography = Filmography(actor, films)
+		return nil
+	})
+
+	return filmography, err
+}
+
+// MergeActors merges the source actor of the request into the actor with
+// the given id. Images of the source actor are removed once the merge is
+// committed.
+func (s *Service) MergeActors(
+	ctx context.Context, id uint, request schemas.MergeActorsRequest,
+) (schemas.MergeActorsResponse, error) {
+	if id == request.SourceID {
+		return schemas.MergeActorsResponse{}, ErrSelfMerge
+	}
+
+	columns := make([]string, 0, len(request.Fields))
+	for _, field := range request.Fields {
+		columns = append(columns, text.CamelToSnake(field))
+	}
+
+	result, err := s.actorRepo.Merge(ctx, id, request.SourceID, columns)
+	if err != nil {
+		return schemas.MergeActorsResponse{}, err
+	}
+
+	postgresql.AfterCommit(ctx, func(ctx context.Context) {
+		err := s.images.RemoveActorImages(ctx, request.SourceID)
+		if err != nil {
+			log.Printf("error removing images of actor %d: %v", request.SourceID, err)
+		}
+	})
+
+	s.events.Publish(ctx, models.ActorUpdated, id)
+	s.events.Publish(ctx, models.ActorDeleted, request.SourceID)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return result, nil
 }
@@ -0,0 +1,47 @@
+package actors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/services/cache"
+)
+
+// fakeActorRepo stores the actors by id and the merged ids by source.
+type fakeActorRepo struct {
+	actorRepo
+	actors    map[uint]schemas.ActorWithFilmsResponse
+	redirects map[uint]uint
+}
+
+func (r fakeActorRepo) Resolve(_ context.Context, id uint) (uint, error) {
+	if target, ok := r.redirects[id]; ok {
+		return target, nil
+	}
+	return id, nil
+}
+
+func (r fakeActorRepo) GetWithFilms(
+	_ context.Context, id uint,
+) (schemas.ActorWithFilmsResponse, error) {
+	return r.actors[id], nil
+}
+
+func TestService_GetActorWithFilms_Merged(t *testing.T) {
+	repo := fakeActorRepo{
+		actors:    map[uint]schemas.ActorWithFilmsResponse{2: {ID: 2, LastName: "Gosling"}},
+		redirects: map[uint]uint{5: 2},
+	}
+	s := NewService(repo, nil, nil, cache.New(cache.NewLRU(10), "catalog", time.Minute))
+
+	actor, err := s.GetActorWithFilms(context.Background(), 5)
+	if err != nil {
+		t.Fatalf("Service.GetActorWithFilms() error = %v", err)
+	}
+
+	if actor.ID != 2 || actor.LastName != "Gosling" {
+		t.Errorf("Service.GetActorWithFilms() = %+v, want the actor 2", actor)
+	}
+}
@@ -0,0 +1,201 @@
+package awards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/text"
+)
+
+type awardRepo interface {
+	CreateCeremony(context.Context, *models.AwardCeremony) error
+	UpdateCeremony(context.Context, uint, map[string]any) error
+	RemoveCeremony(context.Context, uint) error
+	GetCeremonies(context.Context) ([]schemas.CeremonyInfo, error)
+	CreateCategory(context.Context, *models.AwardCategory) error
+	RemoveCategory(context.Context, uint) error
+	CreateNomination(context.Context, *models.Nomination) error
+	UpdateNomination(context.Context, uint, map[string]any) error
+	RemoveNomination(context.Context, uint) error
+	GetNominations(context.Context, schemas.NominationsFilter) ([]schemas.NominationInfo, error)
+}
+
+// readCache holds the cached reads of the films and actors which embed
+// the nominations in the filters.
+type readCache interface {
+	Invalidate(context.Context)
+}
+
+type Service struct {
+	awardRepo awardRepo
+	cache     readCache
+}
+
+func NewService(awardRepo awardRepo, cache readCache) *Service {
+	return &Service{
+		awardRepo: awardRepo,
+		cache:     cache,
+	}
+}
+
+func (s *Service) AddCeremony(
+	ctx context.Context, request schemas.AddCeremonyRequest,
+) (models.AwardCeremony, error) {
+	ceremony := models.AwardCeremony{
+		Name: request.Name,
+	}
+
+	err := s.awardRepo.CreateCeremony(ctx, &ceremony)
+	if err != nil {
+		return models.AwardCeremony{}, wrapError("error creating ceremony", err)
+	}
+
+	return ceremony, nil
+}
+
+func (s *Service) UpdateCeremony(
+	ctx context.Context, id uint, request schemas.UpdateCeremonyRequest,
+) error {
+	return s.awardRepo.UpdateCeremony(ctx, id, map[string]any{
+		"name": request.Name,
+	})
+}
+
+func (s *Service) RemoveCeremony(ctx context.Context, id uint) error {
+	err := s.awardRepo.RemoveCeremony(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
+}
+
+func (s *Service) GetCeremonies(
+	ctx context.Context,
+) ([]schemas.CeremonyInfo, error) {
+	return s.awardRepo.GetCeremonies(ctx)
+}
+
+func (s *Service) AddCategory(
+	ctx context.Context, ceremonyId uint, request schemas.AddCategoryRequest,
+) (models.AwardCategory, error) {
+	category := models.AwardCategory{
+		CeremonyID: ceremonyId,
+		Name:       request.Name,
+	}
+
+	err := s.awardRepo.CreateCategory(ctx, &category)
+	if err != nil {
+		return models.AwardCategory{}, wrapError("error creating category", err)
+	}
+
+	return category, nil
+}
+
+func (s *Service) RemoveCategory(ctx context.Context, id uint) error {
+	err := s.awardRepo.RemoveCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
+}
+
+func (s *Service) AddNomination(
+	ctx context.Context, request schemas.AddNominationRequest,
+) (schemas.NominationInfo, error) {
+	nomination := models.Nomination{
+		CategoryID: request.CategoryID,
+		Year:       request.Year,
+		FilmID:     request.FilmID,
+		ActorID:    request.ActorID,
+		Won:        request.Won,
+	}
+
+	err := s.awardRepo.CreateNomination(ctx, &nomination)
+	if err != nil {
+		return schemas.NominationInfo{}, wrapError("error creating nomination", err)
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	nominations, err := s.awardRepo.GetNominations(
+		ctx, schemas.NominationsFilter{ID: &nomination.ID},
+	)
+	if err != nil {
+		return schemas.NominationInfo{}, err
+	}
+	if len(nominations) == 0 {
+		return schemas.NominationInfo{}, fmt.Errorf(
+			"nomination %d not found after creation", nomination.ID,
+		)
+	}
+
+	return nominations[0], nil
+}
+
+func (s *Service) PartialUpdateNomination(
+	ctx context.Context, id uint, request schemas.PartialUpdateNominationRequest,
+) error {
+	reqType := reflect.TypeOf(request)
+	reqValues := reflect.ValueOf(request)
+
+	var updates = make(map[string]any, reqType.NumField())
+	for i := 0; i < reqType.NumField(); i++ {
+		field := reqType.Field(i)
+		value := reqValues.Field(i)
+
+		if value.IsNil() {
+			continue
+		}
+
+		updates[text.CamelToSnake(field.Name)] = value.Elem().Interface()
+	}
+
+	err := s.awardRepo.UpdateNomination(ctx, id, updates)
+	if err != nil {
+		return err
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
+}
+
+func (s *Service) RemoveNomination(ctx context.Context, id uint) error {
+	err := s.awardRepo.RemoveNomination(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
+}
+
+func (s *Service) GetNominations(
+	ctx context.Context, filter schemas.NominationsFilter,
+) ([]schemas.NominationInfo, error) {
+	return s.awardRepo.GetNominations(ctx, filter)
+}
+
+// wrapError keeps repository errors which are handled by callers
+// and wraps the unexpected ones.
+func wrapError(msg string, err error) error {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	if errors.As(err, &notFoundErr) || errors.As(err, &existsErr) {
+		return err
+	}
+
+	return fmt.Errorf("%s: %v", msg, err)
+}
@@ -0,0 +1,191 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/services/i18n"
+)
+
+// Store keeps the cached values, the implementations are safe for
+// concurrent use. Zero ttl keeps the value until it is deleted.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// state is stored under the state key of the namespace. The entries are
+// valid only for the version they were loaded with, the modification time
+// is rounded up to seconds as the HTTP dates are.
+type state struct {
+	Version  string    `json:"version"`
+	Modified time.Time `json:"modified"`
+}
+
+type entry struct {
+	Version string          `json:"version"`
+	Value   json.RawMessage `json:"value"`
+}
+
+// Cache caches the values of the namespace in the store. The values of the
+// namespace are invalidated all together, so the records embedding each
+// other share the namespace.
+type Cache struct {
+	store     Store
+	namespace string
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+func New(store Store, namespace string, ttl time.Duration) *Cache {
+	return &Cache{
+		store:     store,
+		namespace: namespace,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Key returns the key of the value loaded by the operation with the
+// parameters in the locales of the context. The parameters are encoded
+// sorted by name.
+func Key(ctx context.Context, operation string, params url.Values) string {
+	if locales := i18n.Locales(ctx); len(locales) > 0 {
+		params.Set("locales", strings.Join(locales, ","))
+	}
+
+	return operation + "?" + params.Encode()
+}
+
+// SetList sets the parameter to the sorted values, so the key does not
+// depend on their order. Empty values are not set.
+func SetList(params url.Values, name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	params.Set(name, strings.Join(sorted, ","))
+}
+
+// Load decodes the value cached under the key into dest. On a miss it
+// calls load which fills dest and caches the result. The errors of the
+// store are logged and the value is loaded bypassing the cache.
+func (c *Cache) Load(ctx context.Context, key string, dest any, load func() error) error {
+	current, err := c.state(ctx)
+	if err != nil {
+		log.Printf("error reading cache state: %v", err)
+		return load()
+	}
+
+	key = c.namespace + ":" + key
+	data, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		log.Printf("error reading cache entry %s: %v", key, err)
+	}
+	if found {
+		var cached entry
+		if json.Unmarshal(data, &cached) == nil && cached.Version == current.Version {
+			if json.Unmarshal(cached.Value, dest) == nil {
+				return nil
+			}
+		}
+	}
+
+	if err = load(); err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(dest)
+	if err != nil {
+		log.Printf("error encoding cache entry %s: %v", key, err)
+		return nil
+	}
+	// the version read before loading keeps the values loaded
+	// concurrently with the invalidation out of the cache
+	data, _ = json.Marshal(entry{Version: current.Version, Value: value})
+	if err = c.store.Set(ctx, key, data, c.ttl); err != nil {
+		log.Printf("error writing cache entry %s: %v", key, err)
+	}
+
+	return nil
+}
+
+// Invalidate drops the values of the namespace and moves its modification
+// time forward.
+func (c *Cache) Invalidate(ctx context.Context) {
+	current, err := c.state(ctx)
+	if err != nil {
+		log.Printf("error reading cache state: %v", err)
+	}
+
+	next := c.newState()
+	if !next.Modified.After(current.Modified) {
+		next.Modified = current.Modified.Add(time.Second)
+	}
+	if err = c.setState(ctx, next); err != nil {
+		log.Printf("error writing cache state: %v", err)
+	}
+
+	if err = c.store.DeletePrefix(ctx, c.namespace+":"); err != nil {
+		log.Printf("error deleting cache entries: %v", err)
+	}
+}
+
+// Modified returns the time of the last invalidation of the namespace. The
+// values cached without the invalidation expire with the state, so the time
+// is not older than ttl. Returns the current time if the store fails.
+func (c *Cache) Modified(ctx context.Context) time.Time {
+	current, err := c.state(ctx)
+	if err != nil {
+		log.Printf("error reading cache state: %v", err)
+		return c.newState().Modified
+	}
+
+	return current.Modified
+}
+
+func (c *Cache) state(ctx context.Context) (state, error) {
+	data, found, err := c.store.Get(ctx, c.stateKey())
+	if err != nil {
+		return state{}, err
+	}
+
+	var current state
+	if found && json.Unmarshal(data, &current) == nil {
+		return current, nil
+	}
+
+	current = c.newState()
+	return current, c.setState(ctx, current)
+}
+
+func (c *Cache) setState(ctx context.Context, current state) error {
+	data, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	return c.store.Set(ctx, c.stateKey(), data, c.ttl)
+}
+
+func (c *Cache) newState() state {
+	now := c.now()
+	return state{
+		Version:  strconv.FormatInt(now.UnixNano(), 36),
+		Modified: now.Truncate(time.Second).Add(time.Second).UTC(),
+	}
+}
+
+// stateKey is kept out of the prefix of the entries.
+func (c *Cache) stateKey() string {
+	return c.namespace + "@state"
+}
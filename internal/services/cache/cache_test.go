@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/services/i18n"
+)
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := NewLRU(2)
+	s.now = func() time.Time { return now }
+
+	_ = s.Set(ctx, "a", []byte("1"), 0)
+	_ = s.Set(ctx, "b", []byte("2"), time.Minute)
+	_, _, _ = s.Get(ctx, "a")
+	_ = s.Set(ctx, "c", []byte("3"), 0)
+
+	if _, found, _ := s.Get(ctx, "b"); found {
+		t.Error("LRU.Get(b) found the least recently used value")
+	}
+	if value, found, _ := s.Get(ctx, "a"); !found || string(value) != "1" {
+		t.Errorf("LRU.Get(a) = %q, %v, want 1", value, found)
+	}
+
+	_ = s.Set(ctx, "b", []byte("2"), time.Minute)
+	now = now.Add(time.Minute)
+	if _, found, _ := s.Get(ctx, "b"); found {
+		t.Error("LRU.Get(b) found the expired value")
+	}
+
+	_ = s.DeletePrefix(ctx, "a")
+	if _, found, _ := s.Get(ctx, "a"); found {
+		t.Error("LRU.Get(a) found the deleted value")
+	}
+}
+
+func TestKey(t *testing.T) {
+	ctx := i18n.WithLocales(context.Background(), []string{"ru", "en"})
+
+	params := url.Values{}
+	params.Set("search", "drive")
+	SetList(params, "fields", []string{"title", "id"})
+	SetList(params, "includedFields", nil)
+
+	want := "films?fields=id%2Ctitle&locales=ru%2Cen&search=drive"
+	if got := Key(ctx, "films", params); got != want {
+		t.Errorf("Key() = %q, want %q", got, want)
+	}
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 500, time.UTC)
+	c := New(NewLRU(10), "catalog", time.Minute)
+	c.now = func() time.Time { return now }
+
+	loads := 0
+	load := func(dest *[]string) func() error {
+		return func() error {
+			loads++
+			*dest = []string{"Drive"}
+			return nil
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		var films []string
+		if err := c.Load(ctx, "films", &films, load(&films)); err != nil {
+			t.Fatalf("Cache.Load() error = %v", err)
+		}
+		if len(films) != 1 || films[0] != "Drive" {
+			t.Errorf("Cache.Load() = %v, want [Drive]", films)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("Cache.Load() loaded %d times, want 1", loads)
+	}
+
+	modified := c.Modified(ctx)
+	if want := time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC); !modified.Equal(want) {
+		t.Errorf("Cache.Modified() = %v, want %v", modified, want)
+	}
+
+	// the invalidation in the same second still moves the time forward
+	c.Invalidate(ctx)
+	if got := c.Modified(ctx); !got.After(modified) {
+		t.Errorf("Cache.Modified() = %v, want after %v", got, modified)
+	}
+
+	var films []string
+	_ = c.Load(ctx, "films", &films, load(&films))
+	if loads != 2 {
+		t.Errorf("Cache.Load() loaded %d times after the invalidation, want 2", loads)
+	}
+}
+
+func TestCache_LoadDuringInvalidation(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New(NewLRU(10), "catalog", time.Minute)
+	c.now = func() time.Time {
+		now = now.Add(time.Millisecond)
+		return now
+	}
+
+	var films []string
+	_ = c.Load(ctx, "films", &films, func() error {
+		films = []string{"stale"}
+		c.Invalidate(ctx)
+		return nil
+	})
+
+	loaded := false
+	_ = c.Load(ctx, "films", &films, func() error {
+		loaded = true
+		films = []string{"fresh"}
+		return nil
+	})
+	if !loaded || films[0] != "fresh" {
+		t.Errorf("Cache.Load() = %v, want the value loaded after the invalidation", films)
+	}
+}
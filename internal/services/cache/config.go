@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultTTL  = time.Minute
+	DefaultSize = 1000
+
+	// StoreMemory keeps the values in the LRU of the instance.
+	StoreMemory = "memory"
+	// StorePostgres shares the values between the instances.
+	StorePostgres = "postgres"
+)
+
+type Config struct {
+	Store string
+	TTL   time.Duration
+	Size  int
+}
+
+func NewConfig() Config {
+	ttl, err := time.ParseDuration(os.Getenv("CACHE_TTL"))
+	if err != nil || ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	size, err := strconv.Atoi(os.Getenv("CACHE_SIZE"))
+	if err != nil || size <= 0 {
+		size = DefaultSize
+	}
+
+	store := os.Getenv("CACHE_STORE")
+	if store != StorePostgres {
+		store = StoreMemory
+	}
+
+	return Config{
+		Store: store,
+		TTL:   ttl,
+		Size:  size,
+	}
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+type lruItem struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// LRU is the in-process store evicting the least recently used values
+// once it holds size values.
+type LRU struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List
+	items map[string]*list.Element
+	now   func() time.Time
+}
+
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element, size),
+		now:   time.Now,
+	}
+}
+
+func (s *LRU) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	item := element.Value.(*lruItem)
+	if !item.expires.IsZero() && !s.now().Before(item.expires) {
+		s.remove(element)
+		return nil, false, nil
+	}
+
+	s.order.MoveToFront(element)
+	return item.value, true, nil
+}
+
+func (s *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = s.now().Add(ttl)
+	}
+
+	if element, ok := s.items[key]; ok {
+		item := element.Value.(*lruItem)
+		item.value = value
+		item.expires = expires
+		s.order.MoveToFront(element)
+		return nil
+	}
+
+	s.items[key] = s.order.PushFront(&lruItem{key: key, value: value, expires: expires})
+	for s.order.Len() > s.size {
+		s.remove(s.order.Back())
+	}
+
+	return nil
+}
+
+func (s *LRU) DeletePrefix(_ context.Context, prefix string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, element := range s.items {
+		if strings.HasPrefix(key, prefix) {
+			s.remove(element)
+		}
+	}
+
+	return nil
+}
+
+func (s *LRU) remove(element *list.Element) {
+	s.order.Remove(element)
+	delete(s.items, element.Value.(*lruItem).key)
+}
@@ -0,0 +1,137 @@
+package cast
+
+import (
+	"context"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type castRepo interface {
+	GetFilmActors(context.Context, uint) ([]schemas.ActorInfo, error)
+	GetActorFilms(context.Context, uint) ([]schemas.FilmInfo, error)
+	AddLink(context.Context, uint, uint) error
+	RemoveLink(context.Context, uint, uint) error
+	SetFilmActors(context.Context, uint, []uint) ([]uint, error)
+	SetActorFilms(context.Context, uint, []uint) ([]uint, error)
+	ResolveActor(context.Context, uint) (uint, error)
+}
+
+type eventPublisher interface {
+	Publish(context.Context, models.EventType, uint)
+}
+
+// readCache holds the cached reads of the films and actors which embed
+// the cast.
+type readCache interface {
+	Invalidate(context.Context)
+}
+
+type Service struct {
+	castRepo castRepo
+	events   eventPublisher
+	cache    readCache
+}
+
+func NewService(
+	castRepo castRepo, events eventPublisher, cache readCache,
+) *Service {
+	return &Service{
+		castRepo: castRepo,
+		events:   events,
+		cache:    cache,
+	}
+}
+
+func (s *Service) GetFilmActors(
+	ctx context.Context, filmId uint,
+) ([]schemas.ActorInfo, error) {
+	return s.castRepo.GetFilmActors(ctx, filmId)
+}
+
+func (s *Service) GetActorFilms(
+	ctx context.Context, actorId uint,
+) ([]schemas.FilmInfo, error) {
+	actorId, err := s.castRepo.ResolveActor(ctx, actorId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.castRepo.GetActorFilms(ctx, actorId)
+}
+
+func (s *Service) AddLink(
+	ctx context.Context, actorId uint, filmId uint,
+) (schemas.CastLink, error) {
+	err := s.castRepo.AddLink(ctx, actorId, filmId)
+	if err != nil {
+		return schemas.CastLink{}, err
+	}
+
+	s.events.Publish(ctx, models.FilmUpdated, filmId)
+	s.events.Publish(ctx, models.ActorUpdated, actorId)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return schemas.CastLink{ActorID: actorId, FilmID: filmId}, nil
+}
+
+func (s *Service) RemoveLink(ctx context.Context, actorId uint, filmId uint) error {
+	err := s.castRepo.RemoveLink(ctx, actorId, filmId)
+	if err != nil {
+		return err
+	}
+
+	s.events.Publish(ctx, models.FilmUpdated, filmId)
+	s.events.Publish(ctx, models.ActorUpdated, actorId)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
+}
+
+func (s *Service) SetFilmActors(
+	ctx context.Context, filmId uint, request schemas.SetFilmActorsRequest,
+) error {
+	actorsIds, err := s.castRepo.SetFilmActors(ctx, filmId, request.ActorsIDs)
+	if err != nil {
+		return err
+	}
+
+	s.publishChanges(ctx, models.FilmUpdated, filmId, models.ActorUpdated, actorsIds)
+
+	return nil
+}
+
+func (s *Service) SetActorFilms(
+	ctx context.Context, actorId uint, request schemas.SetActorFilmsRequest,
+) error {
+	filmsIds, err := s.castRepo.SetActorFilms(ctx, actorId, request.FilmsIDs)
+	if err != nil {
+		return err
+	}
+
+	s.publishChanges(ctx, models.ActorUpdated, actorId, models.FilmUpdated, filmsIds)
+
+	return nil
+}
+
+// publishChanges publishes the update of the owner and of the linked
+// records whose links were replaced and invalidates the read cache.
+// Nothing is done if no link changed.
+func (s *Service) publishChanges(
+	ctx context.Context,
+	ownerEvent models.EventType,
+	ownerId uint,
+	linkedEvent models.EventType,
+	linkedIds []uint,
+) {
+	if len(linkedIds) == 0 {
+		return
+	}
+
+	s.events.Publish(ctx, ownerEvent, ownerId)
+	for _, id := range linkedIds {
+		s.events.Publish(ctx, linkedEvent, id)
+	}
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+}
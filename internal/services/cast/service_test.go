@@ -0,0 +1,175 @@
+package cast
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/cache"
+	"github.com/sivistrukov/vk-assigment/internal/services/films"
+)
+
+// fakeDB holds the actors of the films shared by the repositories.
+type fakeDB struct {
+	cast map[uint][]uint
+}
+
+type fakeCastRepo struct {
+	castRepo
+	db *fakeDB
+}
+
+func (r fakeCastRepo) AddLink(_ context.Context, actorId uint, filmId uint) error {
+	r.db.cast[filmId] = append(r.db.cast[filmId], actorId)
+	return nil
+}
+
+func (r fakeCastRepo) SetFilmActors(
+	_ context.Context, filmId uint, actorsIds []uint,
+) ([]uint, error) {
+	changed := slices.Clone(actorsIds)
+	for _, actorId := range r.db.cast[filmId] {
+		if i := slices.Index(changed, actorId); i >= 0 {
+			changed = slices.Delete(changed, i, i+1)
+		} else {
+			changed = append(changed, actorId)
+		}
+	}
+	r.db.cast[filmId] = actorsIds
+
+	return changed, nil
+}
+
+// fakeEvents records the published events.
+type fakeEvents struct {
+	published []models.Event
+}
+
+func (e *fakeEvents) Publish(_ context.Context, eventType models.EventType, id uint) {
+	e.published = append(e.published, models.Event{Type: eventType, EntityID: id})
+}
+
+type fakeFilmRepo struct {
+	db *fakeDB
+}
+
+func (r fakeFilmRepo) Create(context.Context, *models.Film, ...uint) error { return nil }
+
+func (r fakeFilmRepo) Update(context.Context, uint, map[string]any) error { return nil }
+
+func (r fakeFilmRepo) Remove(context.Context, uint) error { return nil }
+
+func (r fakeFilmRepo) GetFilmsWithActors(
+	context.Context, string, string, schemas.FilmsFilter, schemas.ListOptions,
+) ([]schemas.FilmWithActorsResponse, error) {
+	film := schemas.FilmWithActorsResponse{ID: 1, Title: "Drive"}
+	for _, actorId := range r.db.cast[1] {
+		film.Actors = append(film.Actors, schemas.ActorInfo{ID: actorId})
+	}
+
+	return []schemas.FilmWithActorsResponse{film}, nil
+}
+
+func (r fakeFilmRepo) GetFilmWithActors(
+	context.Context, uint,
+) (schemas.FilmWithActorsResponse, error) {
+	return schemas.FilmWithActorsResponse{}, nil
+}
+
+func (r fakeFilmRepo) GetDuplicateCandidates(
+	context.Context, int,
+) ([]schemas.DuplicateCandidate, error) {
+	return nil, nil
+}
+
+func TestService_AddLink_InvalidatesCache(t *testing.T) {
+	ctx := context.Background()
+	db := &fakeDB{cast: map[uint][]uint{1: {2}}}
+	c := cache.New(cache.NewLRU(10), "catalog", time.Minute)
+	filmService := films.NewService(fakeFilmRepo{db: db}, nil, nil, c)
+	s := NewService(fakeCastRepo{db: db}, &fakeEvents{}, c)
+
+	list := func() []schemas.FilmWithActorsResponse {
+		t.Helper()
+		films, err := filmService.GetFilmsWithActors(
+			ctx, "", "", schemas.FilmsFilter{}, schemas.DefaultListOptions,
+		)
+		if err != nil {
+			t.Fatalf("GetFilmsWithActors() error = %v", err)
+		}
+		return films
+	}
+
+	if films := list(); len(films[0].Actors) != 1 {
+		t.Fatalf("GetFilmsWithActors() actors = %v, want 1", films[0].Actors)
+	}
+
+	if _, err := s.AddLink(ctx, 4, 1); err != nil {
+		t.Fatalf("Service.AddLink() error = %v", err)
+	}
+
+	if films := list(); len(films[0].Actors) != 2 || films[0].Actors[1].ID != 4 {
+		t.Errorf("GetFilmsWithActors() actors = %v after AddLink, want 2 and 4", films[0].Actors)
+	}
+}
+
+func TestService_PublishesEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("add link", func(t *testing.T) {
+		events := &fakeEvents{}
+		db := &fakeDB{cast: map[uint][]uint{1: {2}}}
+		s := NewService(fakeCastRepo{db: db}, events, cache.New(cache.NewLRU(10), "catalog", time.Minute))
+
+		if _, err := s.AddLink(ctx, 4, 1); err != nil {
+			t.Fatalf("Service.AddLink() error = %v", err)
+		}
+
+		want := []models.Event{
+			{Type: models.FilmUpdated, EntityID: 1},
+			{Type: models.ActorUpdated, EntityID: 4},
+		}
+		if !reflect.DeepEqual(events.published, want) {
+			t.Errorf("published = %v, want %v", events.published, want)
+		}
+	})
+
+	t.Run("set film actors", func(t *testing.T) {
+		events := &fakeEvents{}
+		db := &fakeDB{cast: map[uint][]uint{1: {2, 3}}}
+		s := NewService(fakeCastRepo{db: db}, events, cache.New(cache.NewLRU(10), "catalog", time.Minute))
+
+		err := s.SetFilmActors(ctx, 1, schemas.SetFilmActorsRequest{ActorsIDs: []uint{3, 4}})
+		if err != nil {
+			t.Fatalf("Service.SetFilmActors() error = %v", err)
+		}
+
+		want := []models.Event{
+			{Type: models.FilmUpdated, EntityID: 1},
+			{Type: models.ActorUpdated, EntityID: 4},
+			{Type: models.ActorUpdated, EntityID: 2},
+		}
+		if !reflect.DeepEqual(events.published, want) {
+			t.Errorf("published = %v, want %v", events.published, want)
+		}
+	})
+
+	t.Run("set film actors unchanged", func(t *testing.T) {
+		events := &fakeEvents{}
+		db := &fakeDB{cast: map[uint][]uint{1: {2}}}
+		s := NewService(fakeCastRepo{db: db}, events, cache.New(cache.NewLRU(10), "catalog", time.Minute))
+
+		err := s.SetFilmActors(ctx, 1, schemas.SetFilmActorsRequest{ActorsIDs: []uint{2}})
+		if err != nil {
+			t.Fatalf("Service.SetFilmActors() error = %v", err)
+		}
+
+		if len(events.published) != 0 {
+			t.Errorf("published = %v, want none", events.published)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+type catalogRepo interface {
+	GetFilms(context.Context, uint, uint) ([]schemas.FilmInfo, error)
+	GetFilmsByIds(context.Context, []uint) ([]schemas.FilmInfo, error)
+	GetActors(context.Context, uint, uint) ([]schemas.ActorInfo, error)
+	GetActorsByIds(context.Context, []uint) ([]schemas.ActorInfo, error)
+	GetCast(context.Context, []uint) (map[uint][]schemas.ActorInfo, error)
+	GetFilmographies(context.Context, []uint) (map[uint][]schemas.FilmInfo, error)
+	CountCast(context.Context, []uint) (int, error)
+	CountFilmographies(context.Context, []uint) (int, error)
+}
+
+type Service struct {
+	catalogRepo catalogRepo
+}
+
+func NewService(catalogRepo catalogRepo) *Service {
+	return &Service{
+		catalogRepo: catalogRepo,
+	}
+}
+
+// GetFilms returns the page of films ordered by id. A nil limit means
+// DefaultLimit, limit is capped at MaxLimit.
+func (s *Service) GetFilms(
+	ctx context.Context, limit *uint, offset uint,
+) ([]schemas.FilmInfo, error) {
+	return s.catalogRepo.GetFilms(ctx, PageSize(limit), offset)
+}
+
+func (s *Service) GetFilmsByIds(
+	ctx context.Context, ids []uint,
+) ([]schemas.FilmInfo, error) {
+	return s.catalogRepo.GetFilmsByIds(ctx, ids)
+}
+
+// GetActors returns the page of actors ordered by id. A nil limit means
+// DefaultLimit, limit is capped at MaxLimit.
+func (s *Service) GetActors(
+	ctx context.Context, limit *uint, offset uint,
+) ([]schemas.ActorInfo, error) {
+	return s.catalogRepo.GetActors(ctx, PageSize(limit), offset)
+}
+
+func (s *Service) GetActorsByIds(
+	ctx context.Context, ids []uint,
+) ([]schemas.ActorInfo, error) {
+	return s.catalogRepo.GetActorsByIds(ctx, ids)
+}
+
+func (s *Service) GetCast(
+	ctx context.Context, filmsIds []uint,
+) (map[uint][]schemas.ActorInfo, error) {
+	return s.catalogRepo.GetCast(ctx, filmsIds)
+}
+
+func (s *Service) GetFilmographies(
+	ctx context.Context, actorsIds []uint,
+) (map[uint][]schemas.FilmInfo, error) {
+	return s.catalogRepo.GetFilmographies(ctx, actorsIds)
+}
+
+// CountCast returns the number of actors GetCast returns for the films,
+// so the cost of the query is known before the actors are loaded.
+func (s *Service) CountCast(ctx context.Context, filmsIds []uint) (int, error) {
+	return s.catalogRepo.CountCast(ctx, filmsIds)
+}
+
+// CountFilmographies returns the number of films GetFilmographies returns
+// for the actors.
+func (s *Service) CountFilmographies(ctx context.Context, actorsIds []uint) (int, error) {
+	return s.catalogRepo.CountFilmographies(ctx, actorsIds)
+}
+
+// PageSize returns the size of the page for the limit, DefaultLimit for
+// the nil limit and at most MaxLimit.
+func PageSize(limit *uint) uint {
+	if limit == nil {
+		return DefaultLimit
+	}
+
+	return min(*limit, MaxLimit)
+}
@@ -0,0 +1,7 @@
+package collections
+
+import "errors"
+
+var (
+	ErrForbidden = errors.New("collection is not accessible")
+)
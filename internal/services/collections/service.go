@@ -0,0 +1,185 @@
+package collections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/text"
+)
+
+type collectionRepo interface {
+	Create(context.Context, *models.Collection, ...uint) error
+	Get(context.Context, uint) (models.Collection, error)
+	Update(context.Context, uint, map[string]any) error
+	Remove(context.Context, uint) error
+	GetList(context.Context, models.User) ([]schemas.CollectionInfo, error)
+	GetFilms(context.Context, uint) ([]schemas.FilmInfo, error)
+	AddFilm(context.Context, uint, uint, *uint) error
+	RemoveFilm(context.Context, uint, uint) error
+	Reorder(context.Context, uint, []uint) error
+}
+
+type Service struct {
+	collectionRepo collectionRepo
+}
+
+func NewService(collectionRepo collectionRepo) *Service {
+	return &Service{
+		collectionRepo: collectionRepo,
+	}
+}
+
+func (s *Service) AddCollection(
+	ctx context.Context, user models.User, request schemas.AddCollectionRequest,
+) (models.Collection, error) {
+	collection := models.Collection{
+		Title:       request.Title,
+		Description: request.Description,
+		Visibility:  request.Visibility,
+		OwnerID:     user.ID,
+	}
+
+	err := s.collectionRepo.Create(ctx, &collection, request.FilmsIDs...)
+	if err != nil {
+		var notFoundErr *postgresql.ErrRecordNotFound
+		var existsErr *postgresql.ErrRecordAlreadyExists
+		if errors.As(err, &notFoundErr) || errors.As(err, &existsErr) {
+			return models.Collection{}, err
+		}
+		return models.Collection{}, fmt.Errorf("error creating collection: %v", err)
+	}
+
+	return collection, nil
+}
+
+func (s *Service) PartialUpdateCollection(
+	ctx context.Context,
+	user models.User,
+	id uint,
+	request schemas.PartialUpdateCollectionRequest,
+) error {
+	if _, err := s.getEditable(ctx, user, id); err != nil {
+		return err
+	}
+
+	reqType := reflect.TypeOf(request)
+	reqValues := reflect.ValueOf(request)
+
+	var updates = make(map[string]any, reqType.NumField())
+	for i := 0; i < reqType.NumField(); i++ {
+		field := reqType.Field(i)
+		value := reqValues.Field(i)
+
+		if value.IsNil() {
+			continue
+		}
+
+		updates[text.CamelToSnake(field.Name)] = value.Elem().Interface()
+	}
+
+	return s.collectionRepo.Update(ctx, id, updates)
+}
+
+func (s *Service) RemoveCollection(
+	ctx context.Context, user models.User, id uint,
+) error {
+	if _, err := s.getEditable(ctx, user, id); err != nil {
+		return err
+	}
+
+	return s.collectionRepo.Remove(ctx, id)
+}
+
+func (s *Service) GetCollections(
+	ctx context.Context, user models.User,
+) ([]schemas.CollectionInfo, error) {
+	return s.collectionRepo.GetList(ctx, user)
+}
+
+func (s *Service) GetCollectionWithFilms(
+	ctx context.Context, user models.User, id uint,
+) (schemas.CollectionWithFilmsResponse, error) {
+	collection, err := s.collectionRepo.Get(ctx, id)
+	if err != nil {
+		return schemas.CollectionWithFilmsResponse{}, err
+	}
+
+	if collection.Visibility != models.Public && !canEdit(user, collection) {
+		return schemas.CollectionWithFilmsResponse{}, ErrForbidden
+	}
+
+	films, err := s.collectionRepo.GetFilms(ctx, id)
+	if err != nil {
+		return schemas.CollectionWithFilmsResponse{}, err
+	}
+
+	return schemas.CollectionWithFilmsResponse{
+		ID:          collection.ID,
+		Title:       collection.Title,
+		Description: collection.Description,
+		Visibility:  collection.Visibility,
+		OwnerID:     collection.OwnerID,
+		Films:       films,
+	}, nil
+}
+
+func (s *Service) AddFilm(
+	ctx context.Context,
+	user models.User,
+	id uint,
+	request schemas.AddCollectionItemRequest,
+) error {
+	if _, err := s.getEditable(ctx, user, id); err != nil {
+		return err
+	}
+
+	return s.collectionRepo.AddFilm(ctx, id, request.FilmID, request.Position)
+}
+
+func (s *Service) RemoveFilm(
+	ctx context.Context, user models.User, id uint, filmId uint,
+) error {
+	if _, err := s.getEditable(ctx, user, id); err != nil {
+		return err
+	}
+
+	return s.collectionRepo.RemoveFilm(ctx, id, filmId)
+}
+
+func (s *Service) ReorderFilms(
+	ctx context.Context,
+	user models.User,
+	id uint,
+	request schemas.ReorderCollectionRequest,
+) error {
+	if _, err := s.getEditable(ctx, user, id); err != nil {
+		return err
+	}
+
+	return s.collectionRepo.Reorder(ctx, id, request.FilmsIDs)
+}
+
+// getEditable returns the collection if the user is allowed to modify it.
+func (s *Service) getEditable(
+	ctx context.Context, user models.User, id uint,
+) (models.Collection, error) {
+	collection, err := s.collectionRepo.Get(ctx, id)
+	if err != nil {
+		return models.Collection{}, err
+	}
+
+	if !canEdit(user, collection) {
+		return models.Collection{}, ErrForbidden
+	}
+
+	return collection, nil
+}
+
+func canEdit(user models.User, collection models.Collection) bool {
+	return user.IsAdmin || collection.OwnerID == user.ID
+}
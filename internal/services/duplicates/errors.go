@@ -0,0 +1,18 @@
+package duplicates
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidThreshold = errors.New("threshold must be between 0.3 and 1")
+
+// ErrDuplicate is returned when the created record matches an existing one.
+type ErrDuplicate struct {
+	Kind string
+	ID   uint
+}
+
+func (e *ErrDuplicate) Error() string {
+	return fmt.Sprintf("%s already exists with id %d", e.Kind, e.ID)
+}
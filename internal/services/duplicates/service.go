@@ -0,0 +1,78 @@
+package duplicates
+
+import (
+	"context"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/services/text"
+)
+
+const (
+	// MinThreshold is the default similarity threshold of pg_trgm,
+	// pairs below it are not matched by the trigram index.
+	MinThreshold     = 0.3
+	DefaultThreshold = 0.5
+
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+type duplicateRepo interface {
+	GetFilmPairs(context.Context, float64, uint) ([]schemas.DuplicatePair, error)
+	GetActorPairs(context.Context, float64, uint) ([]schemas.DuplicatePair, error)
+}
+
+type Service struct {
+	duplicateRepo duplicateRepo
+}
+
+func NewService(duplicateRepo duplicateRepo) *Service {
+	return &Service{
+		duplicateRepo: duplicateRepo,
+	}
+}
+
+// Report returns probable duplicate films and actors ranked by the
+// similarity of titles and names. A nil threshold means DefaultThreshold,
+// a nil limit of pairs of each kind means DefaultLimit.
+func (s *Service) Report(
+	ctx context.Context, threshold *float64, limit *uint,
+) (schemas.DuplicatesReport, error) {
+	t := DefaultThreshold
+	if threshold != nil {
+		t = *threshold
+	}
+	if t < MinThreshold || t > 1 {
+		return schemas.DuplicatesReport{}, ErrInvalidThreshold
+	}
+
+	n := uint(DefaultLimit)
+	if limit != nil {
+		n = min(*limit, MaxLimit)
+	}
+
+	films, err := s.duplicateRepo.GetFilmPairs(ctx, t, n)
+	if err != nil {
+		return schemas.DuplicatesReport{}, err
+	}
+
+	actors, err := s.duplicateRepo.GetActorPairs(ctx, t, n)
+	if err != nil {
+		return schemas.DuplicatesReport{}, err
+	}
+
+	return schemas.DuplicatesReport{Films: films, Actors: actors}, nil
+}
+
+// Find returns the id of the first candidate whose normalized name
+// equals the normalized name.
+func Find(name string, candidates []schemas.DuplicateCandidate) (uint, bool) {
+	normalized := text.Normalize(name)
+	for _, candidate := range candidates {
+		if text.Normalize(candidate.Name) == normalized {
+			return candidate.ID, true
+		}
+	}
+
+	return 0, false
+}
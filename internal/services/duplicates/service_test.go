@@ -0,0 +1,91 @@
+package duplicates
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+type fakeRepo struct {
+	threshold float64
+	limit     uint
+}
+
+func (r *fakeRepo) GetFilmPairs(
+	_ context.Context, threshold float64, limit uint,
+) ([]schemas.DuplicatePair, error) {
+	r.threshold, r.limit = threshold, limit
+	return []schemas.DuplicatePair{}, nil
+}
+
+func (r *fakeRepo) GetActorPairs(
+	_ context.Context, _ float64, _ uint,
+) ([]schemas.DuplicatePair, error) {
+	return []schemas.DuplicatePair{}, nil
+}
+
+func TestFind(t *testing.T) {
+	candidates := []schemas.DuplicateCandidate{
+		{ID: 1, Name: "Drive Angry"},
+		{ID: 2, Name: "Drive"},
+		{ID: 3, Name: "DRIVE!"},
+	}
+
+	tests := []struct {
+		name   string
+		in     string
+		wantID uint
+		wantOk bool
+	}{
+		{name: "first match", in: "  drive ", wantID: 2, wantOk: true},
+		{name: "no match", in: "Drive 2", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, ok := Find(tt.in, candidates)
+			if id != tt.wantID || ok != tt.wantOk {
+				t.Errorf("Find(%q) = %d, %v, want %d, %v", tt.in, id, ok, tt.wantID, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestService_Report(t *testing.T) {
+	repo := &fakeRepo{}
+	service := NewService(repo)
+
+	t.Run("defaults", func(t *testing.T) {
+		_, err := service.Report(context.Background(), nil, nil)
+		if err != nil {
+			t.Fatalf("Service.Report() error = %v", err)
+		}
+
+		if repo.threshold != DefaultThreshold || repo.limit != DefaultLimit {
+			t.Errorf("Service.Report() used %v, %d", repo.threshold, repo.limit)
+		}
+	})
+
+	t.Run("limit cap", func(t *testing.T) {
+		limit := uint(1000)
+		_, err := service.Report(context.Background(), nil, &limit)
+		if err != nil {
+			t.Fatalf("Service.Report() error = %v", err)
+		}
+
+		if repo.limit != MaxLimit {
+			t.Errorf("Service.Report() used limit %d, want %d", repo.limit, MaxLimit)
+		}
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		for _, threshold := range []float64{0.1, 1.5} {
+			_, err := service.Report(context.Background(), &threshold, nil)
+			if !errors.Is(err, ErrInvalidThreshold) {
+				t.Errorf("Service.Report(%v) error = %v, want %v", threshold, err, ErrInvalidThreshold)
+			}
+		}
+	})
+}
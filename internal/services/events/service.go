@@ -0,0 +1,133 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type eventRepo interface {
+	Create(context.Context, *models.Event) error
+	GetSince(context.Context, int64) ([]models.Event, error)
+}
+
+type eventListener interface {
+	Listen(context.Context, func(*models.Event)) error
+}
+
+// SubscriberBuffer is the number of events a subscriber may fall behind
+// before it is dropped.
+const SubscriberBuffer = 64
+
+// Service is the event bus. The published events are stored in the
+// database and delivered back to the subscribers of every instance by
+// the listener started with Run.
+type Service struct {
+	eventRepo eventRepo
+
+	mu          sync.Mutex
+	subscribers map[chan models.Event]struct{}
+	lastID      int64
+}
+
+func NewService(eventRepo eventRepo) *Service {
+	return &Service{
+		eventRepo:   eventRepo,
+		subscribers: make(map[chan models.Event]struct{}),
+	}
+}
+
+// Publish stores the event once the transaction of the context is
+// committed, so the changes which are rolled back are never published.
+func (s *Service) Publish(ctx context.Context, eventType models.EventType, id uint) {
+	postgresql.AfterCommit(ctx, func(ctx context.Context) {
+		event := models.Event{Type: eventType, EntityID: id}
+		if err := s.eventRepo.Create(ctx, &event); err != nil {
+			log.Printf("error publishing %s event of %d: %v", eventType, id, err)
+		}
+	})
+}
+
+// Subscribe returns the channel of the events and the function which
+// cancels the subscription. The channel is closed if the subscriber falls
+// behind, it is expected to resume with GetSince.
+func (s *Service) Subscribe() (<-chan models.Event, func()) {
+	ch := make(chan models.Event, SubscriberBuffer)
+
+	s.mu.Lock()
+	s.subscribers[ch] = struct{}{}
+	s.mu.Unlock()
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.subscribers[ch]; ok {
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// GetSince returns the stored events which follow the event with the id.
+func (s *Service) GetSince(ctx context.Context, id int64) ([]models.Event, error) {
+	return s.eventRepo.GetSince(ctx, id)
+}
+
+// Run delivers the events received by the listener until ctx is done.
+func (s *Service) Run(ctx context.Context, listener eventListener) {
+	err := listener.Listen(ctx, func(event *models.Event) {
+		if event == nil {
+			s.resync(ctx)
+			return
+		}
+		s.dispatch(*event)
+	})
+	if err != nil {
+		log.Printf("events listener stopped: %v", err)
+	}
+}
+
+// resync delivers the events missed while the listener was reconnecting.
+func (s *Service) resync(ctx context.Context) {
+	s.mu.Lock()
+	lastID := s.lastID
+	s.mu.Unlock()
+
+	// nothing was delivered yet, the subscribers resume on their own
+	if lastID == 0 {
+		return
+	}
+
+	events, err := s.eventRepo.GetSince(ctx, lastID)
+	if err != nil {
+		log.Printf("error loading missed events: %v", err)
+		return
+	}
+
+	for _, event := range events {
+		s.dispatch(event)
+	}
+}
+
+func (s *Service) dispatch(event models.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event.ID <= s.lastID {
+		return
+	}
+	s.lastID = event.ID
+
+	for ch := range s.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(s.subscribers, ch)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type fakeRepo struct {
+	events []models.Event
+}
+
+func (r *fakeRepo) Create(_ context.Context, event *models.Event) error {
+	event.ID = int64(len(r.events) + 1)
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (r *fakeRepo) GetSince(_ context.Context, id int64) ([]models.Event, error) {
+	var events []models.Event
+	for _, event := range r.events {
+		if event.ID > id {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// fakeListener delivers the events of the repo, nil stands for a reconnect.
+type fakeListener struct {
+	notifications []*models.Event
+}
+
+func (l *fakeListener) Listen(_ context.Context, fn func(*models.Event)) error {
+	for _, event := range l.notifications {
+		fn(event)
+	}
+	return nil
+}
+
+func receive(ch <-chan models.Event) []int64 {
+	var ids []int64
+	for {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return ids
+			}
+			ids = append(ids, event.ID)
+		default:
+			return ids
+		}
+	}
+}
+
+func TestService_Run(t *testing.T) {
+	repo := &fakeRepo{}
+	s := NewService(repo)
+	ctx := context.Background()
+
+	for i := uint(1); i <= 4; i++ {
+		s.Publish(ctx, models.FilmCreated, i)
+	}
+
+	ch, unsubscribe := s.Subscribe()
+	defer unsubscribe()
+
+	// the events 3 and 4 are published while the listener is reconnecting,
+	// the event 2 is notified twice
+	listener := &fakeListener{notifications: []*models.Event{
+		&repo.events[0], &repo.events[1], &repo.events[1], nil,
+	}}
+	s.Run(ctx, listener)
+
+	ids := receive(ch)
+	if len(ids) != 4 {
+		t.Fatalf("got events %v, want 1-4", ids)
+	}
+	for i, id := range ids {
+		if id != int64(i+1) {
+			t.Fatalf("got events %v, want 1-4", ids)
+		}
+	}
+}
+
+func TestService_SlowSubscriber(t *testing.T) {
+	s := NewService(&fakeRepo{})
+
+	slow, unsubscribeSlow := s.Subscribe()
+	defer unsubscribeSlow()
+
+	for i := 1; i <= SubscriberBuffer+1; i++ {
+		s.dispatch(models.Event{ID: int64(i)})
+	}
+
+	if ids := receive(slow); len(ids) != SubscriberBuffer {
+		t.Errorf("got %d events, want %d", len(ids), SubscriberBuffer)
+	}
+	if _, ok := <-slow; ok {
+		t.Error("the channel of the slow subscriber is not closed")
+	}
+
+	// the subscribers joining later are not affected
+	ch, unsubscribe := s.Subscribe()
+	s.dispatch(models.Event{ID: SubscriberBuffer + 2})
+	unsubscribe()
+	unsubscribe()
+
+	if ids := receive(ch); len(ids) != 1 {
+		t.Errorf("got events %v, want one", ids)
+	}
+}
@@ -0,0 +1,146 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"slices"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// emptyRecords give CSV headers of the entities.
+var emptyRecords = map[schemas.ExportEntity]schemas.ExportRecord{
+	schemas.ExportFilms:  schemas.ExportFilm{},
+	schemas.ExportActors: schemas.ExportActor{},
+	schemas.ExportLinks:  schemas.ExportLink{},
+}
+
+// encoder writes records as they are read. Records of an entity come
+// together and entities come in the requested order.
+type encoder interface {
+	encode(schemas.ExportEntity, schemas.ExportRecord) error
+	close() error
+}
+
+func newEncoder(request schemas.ExportRequest, w io.Writer) (encoder, error) {
+	switch request.Format {
+	case schemas.ExportNDJSON:
+		return &ndjsonEncoder{json: json.NewEncoder(w)}, nil
+	case schemas.ExportJSON:
+		return &jsonEncoder{w: w, entities: request.Entities, section: -1}, nil
+	case schemas.ExportCSV:
+		writer := csv.NewWriter(w)
+		err := writer.Write(emptyRecords[request.Entities[0]].CSVHeader())
+		if err != nil {
+			return nil, err
+		}
+		return &csvEncoder{csv: writer}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// ndjsonEncoder writes every record on its own line tagged with the
+// entity.
+type ndjsonEncoder struct {
+	json *json.Encoder
+}
+
+type ndjsonLine struct {
+	Type schemas.ExportEntity `json:"type"`
+	Data schemas.ExportRecord `json:"data"`
+}
+
+func (e *ndjsonEncoder) encode(
+	entity schemas.ExportEntity, record schemas.ExportRecord,
+) error {
+	return e.json.Encode(ndjsonLine{Type: entity, Data: record})
+}
+
+func (e *ndjsonEncoder) close() error {
+	return nil
+}
+
+// jsonEncoder writes an object with an array of records per entity.
+type jsonEncoder struct {
+	w        io.Writer
+	entities []schemas.ExportEntity
+	section  int
+	empty    bool
+}
+
+func (e *jsonEncoder) encode(
+	entity schemas.ExportEntity, record schemas.ExportRecord,
+) error {
+	err := e.openSection(slices.Index(e.entities, entity))
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if !e.empty {
+		data = append([]byte(","), data...)
+	}
+	e.empty = false
+
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonEncoder) close() error {
+	err := e.openSection(len(e.entities) - 1)
+	if err != nil {
+		return err
+	}
+
+	end := "]}"
+	if e.section < 0 {
+		end = "{}"
+	}
+	_, err = io.WriteString(e.w, end)
+	return err
+}
+
+// openSection closes the current array and opens arrays up to the
+// section, so that entities without records get empty arrays.
+func (e *jsonEncoder) openSection(section int) error {
+	for e.section < section {
+		prefix := "],"
+		if e.section < 0 {
+			prefix = "{"
+		}
+		e.section++
+
+		name, err := json.Marshal(e.entities[e.section])
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(e.w, prefix+string(name)+":[")
+		if err != nil {
+			return err
+		}
+		e.empty = true
+	}
+
+	return nil
+}
+
+type csvEncoder struct {
+	csv *csv.Writer
+}
+
+func (e *csvEncoder) encode(
+	_ schemas.ExportEntity, record schemas.ExportRecord,
+) error {
+	return e.csv.Write(record.CSVRecord())
+}
+
+func (e *csvEncoder) close() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
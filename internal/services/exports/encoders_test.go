@@ -0,0 +1,161 @@
+package exports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+type fakeRepo struct {
+	records map[schemas.ExportEntity][]schemas.ExportRecord
+}
+
+func (r fakeRepo) Export(
+	_ context.Context,
+	entities []schemas.ExportEntity,
+	_ *time.Time,
+	visit func(schemas.ExportEntity, schemas.ExportRecord) error,
+) error {
+	for _, entity := range entities {
+		for _, record := range r.records[entity] {
+			if err := visit(entity, record); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestService_Export(t *testing.T) {
+	updatedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	since := updatedAt.Add(-time.Hour)
+	actorId, filmId := uint(3), uint(1)
+	repo := fakeRepo{records: map[schemas.ExportEntity][]schemas.ExportRecord{
+		schemas.ExportActors: {
+			schemas.ExportActor{ID: 2, FirstName: "Ryan", LastName: "Gosling", Sex: "male", Birthday: "12-11-1980", UpdatedAt: updatedAt},
+		},
+		schemas.ExportLinks: {
+			schemas.ExportLink{ActorID: 2, FilmID: 1, UpdatedAt: updatedAt},
+			schemas.ExportLink{ActorID: 2, FilmID: 2, UpdatedAt: updatedAt},
+		},
+		schemas.ExportDeletions: {
+			schemas.ExportDeletion{Entity: schemas.ExportLinks, ActorID: &actorId, FilmID: &filmId, DeletedAt: updatedAt},
+		},
+	}}
+	service := NewService(repo)
+
+	tests := []struct {
+		name    string
+		request schemas.ExportRequest
+		want    string
+		wantErr error
+	}{
+		{
+			name: "csv",
+			request: schemas.ExportRequest{
+				Format:   schemas.ExportCSV,
+				Entities: []schemas.ExportEntity{schemas.ExportLinks},
+			},
+			want: "actorId,filmId,updatedAt\n" +
+				"2,1,2024-03-01T12:00:00Z\n" +
+				"2,2,2024-03-01T12:00:00Z\n",
+		},
+		{
+			name: "ndjson",
+			request: schemas.ExportRequest{
+				Format:   schemas.ExportNDJSON,
+				Entities: []schemas.ExportEntity{schemas.ExportLinks},
+			},
+			want: `{"type":"links","data":{"actorId":2,"filmId":1,"updatedAt":"2024-03-01T12:00:00Z"}}` + "\n" +
+				`{"type":"links","data":{"actorId":2,"filmId":2,"updatedAt":"2024-03-01T12:00:00Z"}}` + "\n",
+		},
+		{
+			name: "ndjson since",
+			request: schemas.ExportRequest{
+				Format:   schemas.ExportNDJSON,
+				Entities: []schemas.ExportEntity{schemas.ExportLinks},
+				Since:    &since,
+			},
+			want: `{"type":"links","data":{"actorId":2,"filmId":1,"updatedAt":"2024-03-01T12:00:00Z"}}` + "\n" +
+				`{"type":"links","data":{"actorId":2,"filmId":2,"updatedAt":"2024-03-01T12:00:00Z"}}` + "\n" +
+				`{"type":"deletions","data":{"entity":"links","actorId":3,"filmId":1,"deletedAt":"2024-03-01T12:00:00Z"}}` + "\n",
+		},
+		{
+			name: "csv since",
+			request: schemas.ExportRequest{
+				Format:   schemas.ExportCSV,
+				Entities: []schemas.ExportEntity{schemas.ExportLinks},
+				Since:    &since,
+			},
+			wantErr: ErrCSVSince,
+		},
+		{
+			name: "csv with several entities",
+			request: schemas.ExportRequest{
+				Format:   schemas.ExportCSV,
+				Entities: schemas.ExportEntities,
+			},
+			wantErr: ErrCSVEntities,
+		},
+		{
+			name: "unknown entity",
+			request: schemas.ExportRequest{
+				Format:   schemas.ExportJSON,
+				Entities: []schemas.ExportEntity{"users"},
+			},
+			wantErr: ErrUnknownEntity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := service.Export(context.Background(), tt.request, &buf)
+			if err != tt.wantErr {
+				t.Fatalf("Export() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Export() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_ExportJSON(t *testing.T) {
+	repo := fakeRepo{records: map[schemas.ExportEntity][]schemas.ExportRecord{
+		schemas.ExportActors: {
+			schemas.ExportActor{ID: 2},
+			schemas.ExportActor{ID: 3},
+		},
+	}}
+	service := NewService(repo)
+
+	var buf bytes.Buffer
+	request := schemas.ExportRequest{
+		Format:   schemas.ExportJSON,
+		Entities: schemas.ExportEntities,
+	}
+	if err := service.Export(context.Background(), request, &buf); err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var got map[string][]map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Export() wrote invalid json %q: %v", buf.String(), err)
+	}
+
+	if len(got["films"]) != 0 || len(got["actors"]) != 2 || len(got["links"]) != 0 {
+		t.Errorf("Export() = %s", buf.String())
+	}
+	for _, entity := range schemas.ExportEntities {
+		if _, ok := got[string(entity)]; !ok {
+			t.Errorf("Export() misses %s", entity)
+		}
+	}
+}
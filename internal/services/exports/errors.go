@@ -0,0 +1,10 @@
+package exports
+
+import "errors"
+
+var (
+	ErrUnknownFormat = errors.New("unknown export format")
+	ErrUnknownEntity = errors.New("unknown export entity")
+	ErrCSVEntities   = errors.New("csv export requires a single entity")
+	ErrCSVSince      = errors.New("csv export can not carry deletions, export rows updated since the time as ndjson or json")
+)
@@ -0,0 +1,83 @@
+package exports
+
+import (
+	"context"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+type exportRepo interface {
+	Export(
+		context.Context,
+		[]schemas.ExportEntity,
+		*time.Time,
+		func(schemas.ExportEntity, schemas.ExportRecord) error,
+	) error
+}
+
+type Service struct {
+	exportRepo exportRepo
+}
+
+func NewService(exportRepo exportRepo) *Service {
+	return &Service{
+		exportRepo: exportRepo,
+	}
+}
+
+// Validate checks the request before anything is written.
+func Validate(request schemas.ExportRequest) error {
+	switch request.Format {
+	case schemas.ExportNDJSON, schemas.ExportJSON, schemas.ExportCSV:
+	default:
+		return ErrUnknownFormat
+	}
+
+	if len(request.Entities) == 0 {
+		return ErrUnknownEntity
+	}
+	for _, entity := range request.Entities {
+		if !slices.Contains(schemas.ExportEntities, entity) {
+			return ErrUnknownEntity
+		}
+	}
+
+	if request.Format == schemas.ExportCSV && len(request.Entities) != 1 {
+		return ErrCSVEntities
+	}
+	if request.Format == schemas.ExportCSV && request.Since != nil {
+		return ErrCSVSince
+	}
+
+	return nil
+}
+
+// Export streams the rows to w as they are read from the database. The
+// rows updated after the time are followed by the deletions of the
+// entities after it.
+func (s *Service) Export(
+	ctx context.Context, request schemas.ExportRequest, w io.Writer,
+) error {
+	if err := Validate(request); err != nil {
+		return err
+	}
+
+	if request.Since != nil {
+		request.Entities = append(slices.Clip(request.Entities), schemas.ExportDeletions)
+	}
+
+	enc, err := newEncoder(request, w)
+	if err != nil {
+		return err
+	}
+
+	err = s.exportRepo.Export(ctx, request.Entities, request.Since, enc.encode)
+	if err != nil {
+		return err
+	}
+
+	return enc.close()
+}
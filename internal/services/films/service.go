@@ -4,11 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
 	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
 	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/cache"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
 	"github.com/sivistrukov/vk-assigment/internal/services/text"
 )
 
@@ -16,27 +22,71 @@ type filmRepo interface {
 	Create(context.Context, *models.Film, ...uint) error
 	Update(context.Context, uint, map[string]any) error
 	Remove(context.Context, uint) error
-	GetFilmsWithActors(context.Context, string, string) ([]schemas.FilmWithActorsResponse, error)
+	GetFilmsWithActors(context.Context, string, string, schemas.FilmsFilter, schemas.ListOptions) ([]schemas.FilmWithActorsResponse, error)
+	GetFilmWithActors(context.Context, uint) (schemas.FilmWithActorsResponse, error)
+	GetDuplicateCandidates(context.Context, int) ([]schemas.DuplicateCandidate, error)
+}
+
+type imageCleaner interface {
+	RemoveFilmImages(context.Context, uint) error
+}
+
+type eventPublisher interface {
+	Publish(context.Context, models.EventType, uint)
+}
+
+type readCache interface {
+	Load(ctx context.Context, key string, dest any, load func() error) error
+	Invalidate(context.Context)
 }
 
 type Service struct {
 	filmRepo filmRepo
+	images   imageCleaner
+	events   eventPublisher
+	cache    readCache
 }
 
-func NewService(filmRepo filmRepo) *Service {
+func NewService(
+	filmRepo filmRepo, images imageCleaner, events eventPublisher, cache readCache,
+) *Service {
 	return &Service{
 		filmRepo: filmRepo,
+		images:   images,
+		events:   events,
+		cache:    cache,
 	}
 }
 
+// AddFilm creates the film unless a film with the same normalized title
+// was released in the same year. The check is skipped if force is set.
 func (s *Service) AddFilm(
-	ctx context.Context, request schemas.AddFilmRequest,
+	ctx context.Context, request schemas.AddFilmRequest, force bool,
 ) (models.Film, error) {
+	if !force {
+		candidates, err := s.filmRepo.GetDuplicateCandidates(
+			ctx, request.ReleaseDate.ToTime().Year(),
+		)
+		if err != nil {
+			return models.Film{}, fmt.Errorf("error checking duplicates: %v", err)
+		}
+		if id, ok := duplicates.Find(request.Title, candidates); ok {
+			return models.Film{}, &duplicates.ErrDuplicate{Kind: "film", ID: id}
+		}
+	}
+
 	film := models.Film{
 		Title:       request.Title,
 		Description: request.Description,
 		ReleaseDate: request.ReleaseDate.ToTime(),
 		Rating:      request.Rating,
+		Runtime:     request.Runtime,
+		Countries:   request.Countries,
+		Language:    request.Language,
+		AgeRating:   request.AgeRating,
+		Budget:      request.Budget,
+		BoxOffice:   request.BoxOffice,
+		Currency:    request.Currency,
 	}
 
 	err := s.filmRepo.Create(ctx, &film, request.ActorsIDs...)
@@ -48,6 +98,9 @@ func (s *Service) AddFilm(
 		return models.Film{}, fmt.Errorf("error creating film: %v", err)
 	}
 
+	s.events.Publish(ctx, models.FilmCreated, film.ID)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
 	return film, nil
 }
 
@@ -85,6 +138,9 @@ func (s *Service) UpdateFilm(
 		return err
 	}
 
+	s.events.Publish(ctx, models.FilmUpdated, id)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
 	return nil
 }
 
@@ -121,15 +177,96 @@ func (s *Service) PartialUpdateFilm(
 		return err
 	}
 
+	s.events.Publish(ctx, models.FilmUpdated, id)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
 	return nil
 }
 
 func (s *Service) RemoveFilm(ctx context.Context, filmId uint) error {
-	return s.filmRepo.Remove(ctx, filmId)
+	err := s.filmRepo.Remove(ctx, filmId)
+	if err != nil {
+		return err
+	}
+
+	// image files can not be restored if the transaction is rolled back
+	postgresql.AfterCommit(ctx, func(ctx context.Context) {
+		err := s.images.RemoveFilmImages(ctx, filmId)
+		if err != nil {
+			log.Printf("error removing images of film %d: %v", filmId, err)
+		}
+	})
+
+	s.events.Publish(ctx, models.FilmDeleted, filmId)
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
 }
 
 func (s *Service) GetFilmsWithActors(
-	ctx context.Context, search string, sortBy string,
+	ctx context.Context,
+	search string,
+	sortBy string,
+	filter schemas.FilmsFilter,
+	options schemas.ListOptions,
 ) ([]schemas.FilmWithActorsResponse, error) {
-	return s.filmRepo.GetFilmsWithActors(ctx, search, sortBy)
+	params := url.Values{}
+	// the search is case insensitive
+	if search != "" {
+		params.Set("search", strings.ToLower(search))
+	}
+	if sortBy != "" {
+		params.Set("sortBy", sortBy)
+	}
+	setBool(params, "hasAwards", filter.HasAwards)
+	setBool(params, "awardWinner", filter.AwardWinner)
+	setString(params, "country", filter.Country)
+	setString(params, "language", filter.Language)
+	setString(params, "ageRating", filter.AgeRating)
+	setUint(params, "minRuntime", filter.MinRuntime)
+	setUint(params, "maxRuntime", filter.MaxRuntime)
+	cache.SetList(params, "fields", options.Fields)
+	params.Set("include", strconv.FormatBool(options.Include))
+	cache.SetList(params, "includedFields", options.IncludedFields)
+
+	var films []schemas.FilmWithActorsResponse
+	err := s.cache.Load(ctx, cache.Key(ctx, "films", params), &films, func() (err error) {
+		films, err = s.filmRepo.GetFilmsWithActors(ctx, search, sortBy, filter, options)
+		return err
+	})
+
+	return films, err
+}
+
+func (s *Service) GetFilmWithActors(
+	ctx context.Context, id uint,
+) (schemas.FilmWithActorsResponse, error) {
+	params := url.Values{}
+	params.Set("id", strconv.FormatUint(uint64(id), 10))
+
+	var film schemas.FilmWithActorsResponse
+	err := s.cache.Load(ctx, cache.Key(ctx, "film", params), &film, func() (err error) {
+		film, err = s.filmRepo.GetFilmWithActors(ctx, id)
+		return err
+	})
+
+	return film, err
+}
+
+func setBool(params url.Values, name string, value *bool) {
+	if value != nil {
+		params.Set(name, strconv.FormatBool(*value))
+	}
+}
+
+func setString(params url.Values, name string, value *string) {
+	if value != nil {
+		params.Set(name, *value)
+	}
+}
+
+func setUint(params url.Values, name string, value *uint) {
+	if value != nil {
+		params.Set(name, strconv.FormatUint(uint64(*value), 10))
+	}
 }
@@ -0,0 +1,157 @@
+package franchises
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/text"
+)
+
+type franchiseRepo interface {
+	Create(context.Context, *models.Franchise, ...uint) error
+	Get(context.Context, uint) (models.Franchise, error)
+	Update(context.Context, uint, map[string]any) error
+	Remove(context.Context, uint) error
+	GetList(context.Context) ([]schemas.FranchiseInfo, error)
+	GetFilms(context.Context, uint) ([]schemas.FilmInfo, error)
+	SetFilms(context.Context, uint, []uint) error
+	AddRelation(context.Context, models.FilmRelation) error
+	RemoveRelation(context.Context, uint, uint) error
+	GetRelated(context.Context, uint) ([]schemas.RelatedFilmInfo, error)
+}
+
+type Service struct {
+	franchiseRepo franchiseRepo
+}
+
+func NewService(franchiseRepo franchiseRepo) *Service {
+	return &Service{
+		franchiseRepo: franchiseRepo,
+	}
+}
+
+func (s *Service) AddFranchise(
+	ctx context.Context, request schemas.AddFranchiseRequest,
+) (models.Franchise, error) {
+	franchise := models.Franchise{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+
+	err := s.franchiseRepo.Create(ctx, &franchise, request.FilmsIDs...)
+	if err != nil {
+		return models.Franchise{}, wrapError("error creating franchise", err)
+	}
+
+	return franchise, nil
+}
+
+func (s *Service) PartialUpdateFranchise(
+	ctx context.Context, id uint, request schemas.PartialUpdateFranchiseRequest,
+) error {
+	reqType := reflect.TypeOf(request)
+	reqValues := reflect.ValueOf(request)
+
+	var updates = make(map[string]any, reqType.NumField())
+	for i := 0; i < reqType.NumField(); i++ {
+		field := reqType.Field(i)
+		value := reqValues.Field(i)
+
+		if value.IsNil() {
+			continue
+		}
+
+		updates[text.CamelToSnake(field.Name)] = value.Elem().Interface()
+	}
+
+	return s.franchiseRepo.Update(ctx, id, updates)
+}
+
+func (s *Service) RemoveFranchise(ctx context.Context, id uint) error {
+	return s.franchiseRepo.Remove(ctx, id)
+}
+
+func (s *Service) GetFranchises(
+	ctx context.Context,
+) ([]schemas.FranchiseInfo, error) {
+	return s.franchiseRepo.GetList(ctx)
+}
+
+func (s *Service) GetFranchiseWithFilms(
+	ctx context.Context, id uint,
+) (schemas.FranchiseWithFilmsResponse, error) {
+	franchise, err := s.franchiseRepo.Get(ctx, id)
+	if err != nil {
+		return schemas.FranchiseWithFilmsResponse{}, err
+	}
+
+	films, err := s.franchiseRepo.GetFilms(ctx, id)
+	if err != nil {
+		return schemas.FranchiseWithFilmsResponse{}, err
+	}
+
+	return schemas.FranchiseWithFilmsResponse{
+		ID:          franchise.ID,
+		Name:        franchise.Name,
+		Description: franchise.Description,
+		Films:       films,
+	}, nil
+}
+
+func (s *Service) SetFranchiseFilms(
+	ctx context.Context, id uint, request schemas.SetFranchiseFilmsRequest,
+) error {
+	err := s.franchiseRepo.SetFilms(ctx, id, request.FilmsIDs)
+	if err != nil {
+		return wrapError("error setting franchise films", err)
+	}
+
+	return nil
+}
+
+func (s *Service) AddRelation(
+	ctx context.Context, filmId uint, request schemas.AddFilmRelationRequest,
+) (models.FilmRelation, error) {
+	relation := models.FilmRelation{
+		FilmID:        filmId,
+		RelatedFilmID: request.RelatedFilmID,
+		Type:          request.Type,
+	}
+
+	err := s.franchiseRepo.AddRelation(ctx, relation)
+	if err != nil {
+		if errors.Is(err, postgresql.ErrRelationCycle) {
+			return models.FilmRelation{}, err
+		}
+		return models.FilmRelation{}, wrapError("error adding film relation", err)
+	}
+
+	return relation, nil
+}
+
+func (s *Service) RemoveRelation(
+	ctx context.Context, filmId uint, relatedFilmId uint,
+) error {
+	return s.franchiseRepo.RemoveRelation(ctx, filmId, relatedFilmId)
+}
+
+func (s *Service) GetRelatedFilms(
+	ctx context.Context, filmId uint,
+) ([]schemas.RelatedFilmInfo, error) {
+	return s.franchiseRepo.GetRelated(ctx, filmId)
+}
+
+func wrapError(msg string, err error) error {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	if errors.As(err, &notFoundErr) || errors.As(err, &existsErr) {
+		return err
+	}
+
+	return fmt.Errorf("%s: %v", msg, err)
+}
@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type localesKey struct{}
+
+var matchLocale = regexp.MustCompile("^[a-z]{2,3}(-[a-z0-9]{2,8})*$")
+
+// WithLocales returns context carrying locales ordered by preference.
+func WithLocales(ctx context.Context, locales []string) context.Context {
+	return context.WithValue(ctx, localesKey{}, locales)
+}
+
+// Locales returns locales stored in the context by WithLocales.
+func Locales(ctx context.Context) []string {
+	locales, _ := ctx.Value(localesKey{}).([]string)
+	return locales
+}
+
+// Normalize returns lower cased locale with dashes as separators,
+// e.g. "en_US" becomes "en-us".
+func Normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// IsValid reports whether the normalized locale looks like
+// a BCP 47 language tag.
+func IsValid(locale string) bool {
+	return matchLocale.MatchString(Normalize(locale))
+}
+
+// ParseAcceptLanguage returns locales from the Accept-Language header
+// ordered by their quality value. Wildcards and malformed entries
+// are skipped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		locale  string
+		quality float64
+	}
+
+	entries := make([]weighted, 0)
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		locale := Normalize(params[0])
+		if !IsValid(locale) {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(param[2:], 64)
+			if err == nil {
+				quality = value
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		entries = append(entries, weighted{locale: locale, quality: quality})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].quality > entries[j].quality
+	})
+
+	locales := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		locales = append(locales, entry.locale)
+	}
+
+	return locales
+}
+
+// FallbackChain expands locales with their parent locales keeping
+// the preference order, e.g. ["en-us", "ru"] becomes ["en-us", "en", "ru"].
+func FallbackChain(locales []string) []string {
+	chain := make([]string, 0, len(locales)*2)
+	for _, locale := range locales {
+		for locale != "" {
+			if !slices.Contains(chain, locale) {
+				chain = append(chain, locale)
+			}
+
+			i := strings.LastIndex(locale, "-")
+			if i < 0 {
+				break
+			}
+			locale = locale[:i]
+		}
+	}
+
+	return chain
+}
@@ -0,0 +1,70 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{
+			name:   "basic",
+			header: "ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7",
+			want:   []string{"ru-ru", "ru", "en-us", "en"},
+		},
+		{
+			name:   "unordered quality",
+			header: "en;q=0.5, ru",
+			want:   []string{"ru", "en"},
+		},
+		{
+			name:   "wildcard and zero quality",
+			header: "*, de;q=0, fr",
+			want:   []string{"fr"},
+		},
+		{
+			name:   "empty",
+			header: "",
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFallbackChain(t *testing.T) {
+	tests := []struct {
+		name    string
+		locales []string
+		want    []string
+	}{
+		{
+			name:    "basic",
+			locales: []string{"en-us", "ru"},
+			want:    []string{"en-us", "en", "ru"},
+		},
+		{
+			name:    "duplicates",
+			locales: []string{"en-gb", "en-us", "en"},
+			want:    []string{"en-gb", "en", "en-us"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FallbackChain(tt.locales); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FallbackChain(%v) = %v, want %v", tt.locales, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// MaxLineSize caps the length of the NDJSON line.
+const MaxLineSize = 1 << 20
+
+// listSeparator separates values of list columns in CSV files.
+const listSeparator = ";"
+
+// row is the decoded record and the line of the file it was read from.
+// Rows which could not be decoded keep the errors.
+type row[T any] struct {
+	line  uint
+	value T
+	errs  []schemas.FieldError
+}
+
+func decodeRows[T any](
+	format schemas.ImportFormat, r io.Reader,
+) ([]row[T], error) {
+	switch format {
+	case schemas.ImportCSV:
+		return decodeCSV[T](r)
+	case schemas.ImportNDJSON:
+		return decodeNDJSON[T](r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// decodeCSV reads the file with the header row naming the columns by the
+// JSON names of the row fields.
+func decodeCSV[T any](r io.Reader) ([]row[T], error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: missing header", ErrInvalidFile)
+		}
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	fields := jsonFields(reflect.TypeFor[T]())
+	columns := make([]int, len(header))
+	for i, name := range header {
+		index, ok := fields[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidFile, name)
+		}
+		columns[i] = index
+	}
+
+	rows := make([]row[T], 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+
+		decoded := row[T]{line: uint(line)}
+		switch {
+		case errors.Is(err, csv.ErrFieldCount):
+			decoded.errs = []schemas.FieldError{{
+				Code:    "columns",
+				Message: fmt.Sprintf("expected %d columns, got %d", len(header), len(record)),
+			}}
+		case err != nil:
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+		default:
+			value := reflect.ValueOf(&decoded.value).Elem()
+			for i, column := range record {
+				field := value.Field(columns[i])
+				if err := setField(field, strings.TrimSpace(column)); err != nil {
+					decoded.errs = []schemas.FieldError{{
+						Field:   strings.TrimSpace(header[i]),
+						Code:    "type",
+						Message: err.Error(),
+					}}
+					break
+				}
+			}
+		}
+
+		rows = append(rows, decoded)
+	}
+
+	return rows, nil
+}
+
+// decodeNDJSON reads the file with a JSON object on every line, empty
+// lines are skipped.
+func decodeNDJSON[T any](r io.Reader) ([]row[T], error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), MaxLineSize)
+
+	rows := make([]row[T], 0)
+	var line uint
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		decoded := row[T]{line: line}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&decoded.value); err != nil {
+			decoded.errs = jsonErrors(err)
+		}
+
+		rows = append(rows, decoded)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	return rows, nil
+}
+
+// jsonErrors converts the error of the malformed line to the field errors
+// as the problem of the malformed request body.
+func jsonErrors(err error) []schemas.FieldError {
+	problem := problems.FromError(problems.MalformedBody(err), 0)
+	if len(problem.Errors) > 0 {
+		return problem.Errors
+	}
+
+	return []schemas.FieldError{{Code: "json", Message: problem.Detail}}
+}
+
+// jsonFields maps JSON names of the struct fields to their indexes.
+func jsonFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields[name] = i
+	}
+
+	return fields
+}
+
+// setField converts the CSV value to the field type. Empty values leave
+// the field zero.
+func setField(field reflect.Value, value string) error {
+	if value == "" {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(field.Type().Elem())
+		if err := setField(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		field.SetUint(number)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", field.Type())
+		}
+		items := strings.Split(value, listSeparator)
+		list := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			list.Index(i).SetString(strings.TrimSpace(item))
+		}
+		field.Set(list)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,10 @@
+package imports
+
+import "errors"
+
+var (
+	ErrUnknownKind   = errors.New("unknown import kind")
+	ErrUnknownFormat = errors.New("unknown import format")
+	ErrUnknownMode   = errors.New("unknown import mode")
+	ErrInvalidFile   = errors.New("invalid import file")
+)
@@ -0,0 +1,285 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/problems"
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+	"github.com/sivistrukov/vk-assigment/internal/services/validator"
+)
+
+const (
+	DefaultChunkSize = 100
+	MaxChunkSize     = 1000
+)
+
+// errRollback rolls back the chunk which is not committed.
+var errRollback = errors.New("import chunk rolled back")
+
+type transactor interface {
+	WithinTransaction(context.Context, func(context.Context) error) error
+	WithinSavepoint(context.Context, func(context.Context) error) error
+}
+
+type importRepo interface {
+	ResolveFilm(context.Context, string, time.Time) (uint, error)
+	ResolveActor(context.Context, string, string, time.Time) (uint, error)
+}
+
+type actorService interface {
+	AddActor(context.Context, schemas.AddActorRequest, bool) (models.Actor, error)
+}
+
+type filmService interface {
+	AddFilm(context.Context, schemas.AddFilmRequest, bool) (models.Film, error)
+}
+
+type castService interface {
+	AddLink(context.Context, uint, uint) (schemas.CastLink, error)
+}
+
+type structValidator interface {
+	Struct(any) error
+}
+
+// Service imports the rows through the services of the records, so the
+// rows are checked for duplicates and publish events as the single
+// records do.
+type Service struct {
+	transactor transactor
+	importRepo importRepo
+	actors     actorService
+	films      filmService
+	cast       castService
+	validate   structValidator
+}
+
+func NewService(
+	transactor transactor,
+	importRepo importRepo,
+	actors actorService,
+	films filmService,
+	cast castService,
+) *Service {
+	return &Service{
+		transactor: transactor,
+		importRepo: importRepo,
+		actors:     actors,
+		films:      films,
+		cast:       cast,
+		validate:   validator.New(),
+	}
+}
+
+// Import reads rows of the file, validates and stores them. Rows failing
+// validation or storing are listed in the report instead of failing the
+// whole import.
+func (s *Service) Import(
+	ctx context.Context, request schemas.ImportRequest, file io.Reader,
+) (schemas.ImportReport, error) {
+	switch request.Mode {
+	case "":
+		request.Mode = schemas.ImportAtomic
+	case schemas.ImportAtomic, schemas.ImportChunked:
+	default:
+		return schemas.ImportReport{}, ErrUnknownMode
+	}
+
+	if request.ChunkSize == 0 {
+		request.ChunkSize = DefaultChunkSize
+	}
+	request.ChunkSize = min(request.ChunkSize, MaxChunkSize)
+
+	switch request.Kind {
+	case schemas.ImportActors:
+		return runImport(ctx, s, request, file, s.importActor)
+	case schemas.ImportFilms:
+		return runImport(ctx, s, request, file, s.importFilm)
+	case schemas.ImportCast:
+		return runImport(ctx, s, request, file, s.importCastLink)
+	default:
+		return schemas.ImportReport{}, ErrUnknownKind
+	}
+}
+
+// runImport stores rows in atomic mode in a single transaction committed
+// only if every row succeeds. In chunked mode every chunk of rows is a
+// transaction of its own. Every row is stored in a savepoint, so that all
+// failed rows are reported at once. Nothing is committed in dry-run mode.
+func runImport[T any](
+	ctx context.Context,
+	s *Service,
+	request schemas.ImportRequest,
+	file io.Reader,
+	store func(context.Context, T) error,
+) (schemas.ImportReport, error) {
+	rows, err := decodeRows[T](request.Format, file)
+	if err != nil {
+		return schemas.ImportReport{}, err
+	}
+
+	report := schemas.ImportReport{
+		Kind:   request.Kind,
+		Mode:   request.Mode,
+		DryRun: request.DryRun,
+		Rows:   uint(len(rows)),
+		Errors: make([]schemas.ImportRowError, 0),
+	}
+
+	rowErrors := make(map[int][]schemas.FieldError)
+	for i, row := range rows {
+		if row.errs != nil {
+			rowErrors[i] = row.errs
+			continue
+		}
+		if err := s.validate.Struct(row.value); err != nil {
+			rowErrors[i] = validationErrors(err)
+		}
+	}
+
+	chunkSize := len(rows)
+	if request.Mode == schemas.ImportChunked {
+		chunkSize = int(request.ChunkSize)
+	}
+
+	for start := 0; start < len(rows); start += chunkSize {
+		end := min(start+chunkSize, len(rows))
+
+		stored := 0
+		err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			for i := start; i < end; i++ {
+				if _, failed := rowErrors[i]; failed {
+					continue
+				}
+
+				err := s.transactor.WithinSavepoint(ctx, func(ctx context.Context) error {
+					return store(ctx, rows[i].value)
+				})
+				if err != nil {
+					fieldErr, ok := rowError(err)
+					if !ok {
+						return err
+					}
+					rowErrors[i] = []schemas.FieldError{fieldErr}
+					continue
+				}
+				stored++
+			}
+
+			if request.DryRun || stored < end-start {
+				return errRollback
+			}
+			return nil
+		})
+		switch {
+		case err == nil:
+			report.Imported += uint(stored)
+		case !errors.Is(err, errRollback):
+			return schemas.ImportReport{}, err
+		}
+	}
+
+	failedRows := make([]int, 0, len(rowErrors))
+	for i := range rowErrors {
+		failedRows = append(failedRows, i)
+	}
+	slices.Sort(failedRows)
+
+	for _, i := range failedRows {
+		report.Errors = append(report.Errors, schemas.ImportRowError{
+			Line:   rows[i].line,
+			Errors: rowErrors[i],
+		})
+	}
+	report.Failed = uint(len(failedRows))
+
+	return report, nil
+}
+
+func (s *Service) importActor(ctx context.Context, row schemas.ImportActorRow) error {
+	_, err := s.actors.AddActor(ctx, toActor(row), false)
+	return err
+}
+
+func (s *Service) importFilm(ctx context.Context, row schemas.ImportFilmRow) error {
+	_, err := s.films.AddFilm(ctx, toFilm(row), false)
+	return err
+}
+
+// importCastLink links the actor to the film referenced by their natural
+// keys.
+func (s *Service) importCastLink(ctx context.Context, row schemas.ImportCastRow) error {
+	filmId, err := s.importRepo.ResolveFilm(ctx, row.FilmTitle, row.FilmReleaseDate.ToTime())
+	if err != nil {
+		return err
+	}
+
+	actorId, err := s.importRepo.ResolveActor(ctx, row.FirstName, row.LastName, row.Birthday.ToTime())
+	if err != nil {
+		return err
+	}
+
+	_, err = s.cast.AddLink(ctx, actorId, filmId)
+	return err
+}
+
+// validationErrors returns the field errors of the invalid row.
+func validationErrors(err error) []schemas.FieldError {
+	if errs := problems.FieldErrors(err); errs != nil {
+		return errs
+	}
+
+	return []schemas.FieldError{{Code: "invalid", Message: err.Error()}}
+}
+
+// rowError returns the error of the row which failed to store. Other
+// errors fail the whole import.
+func rowError(err error) (schemas.FieldError, bool) {
+	var duplicateErr *duplicates.ErrDuplicate
+	var existsErr *postgresql.ErrRecordAlreadyExists
+	var notFoundErr *postgresql.ErrRecordNotFound
+	switch {
+	case errors.As(err, &duplicateErr), errors.As(err, &existsErr):
+		return schemas.FieldError{Code: "duplicate", Message: err.Error()}, true
+	case errors.As(err, &notFoundErr):
+		return schemas.FieldError{Code: "notFound", Message: err.Error()}, true
+	case errors.Is(err, postgresql.ErrAmbiguousRecord):
+		return schemas.FieldError{Code: "ambiguous", Message: err.Error()}, true
+	default:
+		return schemas.FieldError{}, false
+	}
+}
+
+func toActor(row schemas.ImportActorRow) schemas.AddActorRequest {
+	return schemas.AddActorRequest{
+		FirstName:  row.FirstName,
+		LastName:   row.LastName,
+		MiddleName: row.MiddleName,
+		Sex:        row.Sex,
+		Birthday:   row.Birthday,
+	}
+}
+
+func toFilm(row schemas.ImportFilmRow) schemas.AddFilmRequest {
+	return schemas.AddFilmRequest{
+		Title:       row.Title,
+		Description: row.Description,
+		ReleaseDate: row.ReleaseDate,
+		Rating:      row.Rating,
+		Runtime:     row.Runtime,
+		Countries:   row.Countries,
+		Language:    row.Language,
+		AgeRating:   row.AgeRating,
+		Budget:      row.Budget,
+		BoxOffice:   row.BoxOffice,
+		Currency:    row.Currency,
+		ActorsIDs:   make([]uint, 0),
+	}
+}
@@ -0,0 +1,245 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/duplicates"
+)
+
+// fakeTransactor records whether every transaction was committed, the
+// actors added in the committed transactions are stored.
+type fakeTransactor struct {
+	commits []bool
+	pending []schemas.AddActorRequest
+	stored  []schemas.AddActorRequest
+}
+
+func (t *fakeTransactor) WithinTransaction(
+	ctx context.Context, fn func(context.Context) error,
+) error {
+	err := fn(ctx)
+	t.commits = append(t.commits, err == nil)
+	if err == nil {
+		t.stored = append(t.stored, t.pending...)
+	}
+	t.pending = nil
+
+	return err
+}
+
+func (t *fakeTransactor) WithinSavepoint(
+	ctx context.Context, fn func(context.Context) error,
+) error {
+	return fn(ctx)
+}
+
+type fakeRepo struct{}
+
+func (r *fakeRepo) ResolveFilm(context.Context, string, time.Time) (uint, error) {
+	return 1, nil
+}
+
+func (r *fakeRepo) ResolveActor(context.Context, string, string, time.Time) (uint, error) {
+	return 1, nil
+}
+
+// fakeActors fails actors with the "Duplicate" last name.
+type fakeActors struct {
+	transactor *fakeTransactor
+}
+
+func (a *fakeActors) AddActor(
+	_ context.Context, request schemas.AddActorRequest, _ bool,
+) (models.Actor, error) {
+	if request.LastName == "Duplicate" {
+		return models.Actor{}, &duplicates.ErrDuplicate{Kind: "actor", ID: 1}
+	}
+	a.transactor.pending = append(a.transactor.pending, request)
+
+	return models.Actor{}, nil
+}
+
+// fakeFilms keeps the last added film.
+type fakeFilms struct {
+	added schemas.AddFilmRequest
+}
+
+func (f *fakeFilms) AddFilm(
+	_ context.Context, request schemas.AddFilmRequest, _ bool,
+) (models.Film, error) {
+	f.added = request
+	return models.Film{}, nil
+}
+
+type fakeCast struct{}
+
+func (c *fakeCast) AddLink(
+	_ context.Context, actorId uint, filmId uint,
+) (schemas.CastLink, error) {
+	return schemas.CastLink{ActorID: actorId, FilmID: filmId}, nil
+}
+
+const actorsCSV = `firstName,lastName,middleName,sex,birthday
+Ryan,Gosling,,male,12-11-1980
+Margot,Robbie,,female,02-07-1990
+John,Duplicate,,male,02-01-2006
+Emma,Stone,,unknown,06-11-1988
+`
+
+func TestService_Import(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     schemas.ImportRequest
+		file        string
+		wantCommits []bool
+		wantLines   []uint
+		wantStored  int
+		wantErr     error
+	}{
+		{
+			name: "atomic",
+			request: schemas.ImportRequest{
+				Kind: schemas.ImportActors, Format: schemas.ImportCSV,
+			},
+			file:        actorsCSV,
+			wantCommits: []bool{false},
+			wantLines:   []uint{4, 5},
+		},
+		{
+			name: "chunked",
+			request: schemas.ImportRequest{
+				Kind:      schemas.ImportActors,
+				Format:    schemas.ImportCSV,
+				Mode:      schemas.ImportChunked,
+				ChunkSize: 2,
+			},
+			file:        actorsCSV,
+			wantCommits: []bool{true, false},
+			wantLines:   []uint{4, 5},
+			wantStored:  2,
+		},
+		{
+			name: "dry run",
+			request: schemas.ImportRequest{
+				Kind:   schemas.ImportActors,
+				Format: schemas.ImportNDJSON,
+				DryRun: true,
+			},
+			file: `{"firstName":"Ryan","lastName":"Gosling","sex":"male","birthday":"12-11-1980"}
+
+{"firstName":"Ryan","lastName":"Reynolds","sex":"male","birthday":"23-10-1976","age":47}
+`,
+			wantCommits: []bool{false},
+			wantLines:   []uint{3},
+		},
+		{
+			name: "unknown column",
+			request: schemas.ImportRequest{
+				Kind: schemas.ImportActors, Format: schemas.ImportCSV,
+			},
+			file:    "firstName,age\nRyan,43\n",
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name: "unknown kind",
+			request: schemas.ImportRequest{
+				Kind: "users", Format: schemas.ImportCSV,
+			},
+			wantErr: ErrUnknownKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transactor := &fakeTransactor{}
+			service := NewService(
+				transactor, &fakeRepo{}, &fakeActors{transactor}, &fakeFilms{}, &fakeCast{},
+			)
+
+			report, err := service.Import(
+				context.Background(), tt.request, strings.NewReader(tt.file),
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Import() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(transactor.commits, tt.wantCommits) {
+				t.Errorf("commits = %v, want %v", transactor.commits, tt.wantCommits)
+			}
+
+			lines := make([]uint, 0)
+			for _, rowErr := range report.Errors {
+				lines = append(lines, rowErr.Line)
+			}
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("failed lines = %v, want %v", lines, tt.wantLines)
+			}
+
+			if len(transactor.stored) != tt.wantStored || report.Imported != uint(tt.wantStored) {
+				t.Errorf(
+					"stored %d, imported %d, want %d",
+					len(transactor.stored), report.Imported, tt.wantStored,
+				)
+			}
+		})
+	}
+}
+
+func TestService_Import_RowErrors(t *testing.T) {
+	transactor := &fakeTransactor{}
+	service := NewService(
+		transactor, &fakeRepo{}, &fakeActors{transactor}, &fakeFilms{}, &fakeCast{},
+	)
+
+	report, err := service.Import(context.Background(), schemas.ImportRequest{
+		Kind: schemas.ImportActors, Format: schemas.ImportCSV,
+	}, strings.NewReader(actorsCSV))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	want := []schemas.ImportRowError{
+		{Line: 4, Errors: []schemas.FieldError{{
+			Code: "duplicate", Message: "actor already exists with id 1",
+		}}},
+		{Line: 5, Errors: []schemas.FieldError{{
+			Field: "sex", Code: "sex", Message: "must be one of: male, female",
+		}}},
+	}
+	if !reflect.DeepEqual(report.Errors, want) {
+		t.Errorf("errors = %+v, want %+v", report.Errors, want)
+	}
+}
+
+func TestService_Import_Films(t *testing.T) {
+	films := &fakeFilms{}
+	service := NewService(&fakeTransactor{}, &fakeRepo{}, &fakeActors{}, films, &fakeCast{})
+
+	file := `{"title":"Dune","releaseDate":"22-10-2021","budget":"165000000.00","boxOffice":"402000000.00","currency":"USD"}`
+	report, err := service.Import(context.Background(), schemas.ImportRequest{
+		Kind: schemas.ImportFilms, Format: schemas.ImportNDJSON,
+	}, strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if report.Imported != 1 {
+		t.Fatalf("imported %d, want 1, errors %+v", report.Imported, report.Errors)
+	}
+
+	added := films.added
+	if added.Budget == nil || *added.Budget != "165000000.00" ||
+		added.BoxOffice == nil || *added.BoxOffice != "402000000.00" ||
+		added.Currency == nil || *added.Currency != "USD" {
+		t.Errorf("added film lost budget, box office or currency: %+v", added)
+	}
+}
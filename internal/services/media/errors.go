@@ -0,0 +1,8 @@
+package media
+
+import "errors"
+
+var (
+	ErrTooLarge        = errors.New("image is too large")
+	ErrUnsupportedType = errors.New("unsupported image type")
+)
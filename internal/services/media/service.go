@@ -0,0 +1,227 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+const (
+	// MaxImageSize is the upload size limit in bytes.
+	MaxImageSize = 5 << 20
+	// MaxImagePixels is the limit of the decoded image area, the header is
+	// checked before decoding so small files cannot expand to huge bitmaps.
+	MaxImagePixels = 40_000_000
+)
+
+// ThumbnailWidths lists widths of generated thumbnails. Thumbnails
+// wider than the original image are not generated.
+var ThumbnailWidths = []int{160, 320, 640}
+
+var extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type storage interface {
+	Put(context.Context, string, io.Reader) error
+	Remove(context.Context, string) error
+	RemovePrefix(context.Context, string) error
+	URL(string) string
+}
+
+type imageRepo interface {
+	Replace(context.Context, models.ImageOwner, uint, []models.Image) ([]string, error)
+	Remove(context.Context, models.ImageOwner, uint) ([]string, error)
+}
+
+// readCache holds the cached reads of the films and actors which embed
+// the posters and headshots.
+type readCache interface {
+	Invalidate(context.Context)
+}
+
+type Service struct {
+	storage   storage
+	imageRepo imageRepo
+	cache     readCache
+}
+
+func NewService(storage storage, imageRepo imageRepo, cache readCache) *Service {
+	return &Service{
+		storage:   storage,
+		imageRepo: imageRepo,
+		cache:     cache,
+	}
+}
+
+// UploadFilmPoster stores the poster with its thumbnails,
+// replacing the previous one.
+func (s *Service) UploadFilmPoster(
+	ctx context.Context, filmId uint, data io.Reader,
+) ([]models.Image, error) {
+	return s.upload(ctx, models.FilmImage, filmId, data)
+}
+
+// UploadActorHeadshot stores the headshot with its thumbnails,
+// replacing the previous one.
+func (s *Service) UploadActorHeadshot(
+	ctx context.Context, actorId uint, data io.Reader,
+) ([]models.Image, error) {
+	return s.upload(ctx, models.ActorImage, actorId, data)
+}
+
+func (s *Service) RemoveFilmPoster(ctx context.Context, filmId uint) error {
+	return s.remove(ctx, models.FilmImage, filmId)
+}
+
+func (s *Service) RemoveActorHeadshot(ctx context.Context, actorId uint) error {
+	return s.remove(ctx, models.ActorImage, actorId)
+}
+
+// RemoveFilmImages removes stored blobs of the film.
+func (s *Service) RemoveFilmImages(ctx context.Context, filmId uint) error {
+	return s.storage.RemovePrefix(ctx, prefix(models.FilmImage, filmId))
+}
+
+// RemoveActorImages removes stored blobs of the actor.
+func (s *Service) RemoveActorImages(ctx context.Context, actorId uint) error {
+	return s.storage.RemovePrefix(ctx, prefix(models.ActorImage, actorId))
+}
+
+func (s *Service) upload(
+	ctx context.Context, owner models.ImageOwner, ownerId uint, data io.Reader,
+) ([]models.Image, error) {
+	content, err := io.ReadAll(io.LimitReader(data, MaxImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading image: %v", err)
+	}
+	if len(content) > MaxImageSize {
+		return nil, ErrTooLarge
+	}
+
+	ext, ok := extensions[http.DetectContentType(content)]
+	if !ok {
+		return nil, ErrUnsupportedType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, ErrUnsupportedType
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, ErrUnsupportedType
+	}
+
+	// each upload is stored under its own version, so the current images
+	// are served until the replacement is committed
+	version := strconv.FormatInt(time.Now().UnixNano(), 36)
+	keyPrefix := prefix(owner, ownerId) + "/" + version
+
+	bounds := src.Bounds()
+	original := models.Image{
+		Key:      fmt.Sprintf("%s/original.%s", keyPrefix, ext),
+		Width:    uint(bounds.Dx()),
+		Height:   uint(bounds.Dy()),
+		Original: true,
+	}
+	images := []models.Image{original}
+
+	for _, width := range ThumbnailWidths {
+		if width >= bounds.Dx() {
+			continue
+		}
+
+		thumbnail := resize(src, width)
+
+		var buf bytes.Buffer
+		err = jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: 85})
+		if err != nil {
+			return nil, fmt.Errorf("error encoding thumbnail: %v", err)
+		}
+
+		key := fmt.Sprintf("%s/w%d.jpg", keyPrefix, width)
+		err = s.storage.Put(ctx, key, &buf)
+		if err != nil {
+			_ = s.storage.RemovePrefix(ctx, keyPrefix)
+			return nil, fmt.Errorf("error storing thumbnail: %v", err)
+		}
+
+		images = append(images, models.Image{
+			Key:    key,
+			Width:  uint(thumbnail.Bounds().Dx()),
+			Height: uint(thumbnail.Bounds().Dy()),
+		})
+	}
+
+	err = s.storage.Put(ctx, original.Key, bytes.NewReader(content))
+	if err != nil {
+		_ = s.storage.RemovePrefix(ctx, keyPrefix)
+		return nil, fmt.Errorf("error storing image: %v", err)
+	}
+
+	for i := range images {
+		images[i].URL = s.storage.URL(images[i].Key)
+	}
+
+	replaced, err := s.imageRepo.Replace(ctx, owner, ownerId, images)
+	if err != nil {
+		_ = s.storage.RemovePrefix(ctx, keyPrefix)
+		return nil, err
+	}
+
+	postgresql.AfterCommit(ctx, func(ctx context.Context) {
+		s.removeBlobs(ctx, replaced)
+	})
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return images, nil
+}
+
+// removeBlobs removes the blobs of the replaced images. The failures leave
+// unreferenced blobs only, so they are logged.
+func (s *Service) removeBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.storage.Remove(ctx, key); err != nil {
+			log.Printf("error removing image %s: %v", key, err)
+		}
+	}
+}
+
+func (s *Service) remove(
+	ctx context.Context, owner models.ImageOwner, ownerId uint,
+) error {
+	removed, err := s.imageRepo.Remove(ctx, owner, ownerId)
+	if err != nil {
+		return err
+	}
+
+	postgresql.AfterCommit(ctx, func(ctx context.Context) {
+		s.removeBlobs(ctx, removed)
+	})
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
+}
+
+func prefix(owner models.ImageOwner, ownerId uint) string {
+	return fmt.Sprintf("%ss/%d", owner, ownerId)
+}
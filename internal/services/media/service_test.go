@@ -0,0 +1,242 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type storageMock struct {
+	blobs map[string][]byte
+}
+
+func (s *storageMock) Put(_ context.Context, key string, data io.Reader) error {
+	content, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	s.blobs[key] = content
+	return nil
+}
+
+func (s *storageMock) Remove(_ context.Context, key string) error {
+	delete(s.blobs, key)
+	return nil
+}
+
+func (s *storageMock) RemovePrefix(_ context.Context, prefix string) error {
+	for key := range s.blobs {
+		if strings.HasPrefix(key, prefix+"/") {
+			delete(s.blobs, key)
+		}
+	}
+	return nil
+}
+
+func (s *storageMock) URL(key string) string {
+	return "http://localhost/media/" + key
+}
+
+type imageRepoMock struct {
+	images map[uint][]models.Image
+}
+
+func (r *imageRepoMock) Replace(
+	_ context.Context, _ models.ImageOwner, id uint, images []models.Image,
+) ([]string, error) {
+	replaced := imageKeys(r.images[id])
+	r.images[id] = images
+	return replaced, nil
+}
+
+func (r *imageRepoMock) Remove(
+	_ context.Context, _ models.ImageOwner, id uint,
+) ([]string, error) {
+	removed := imageKeys(r.images[id])
+	delete(r.images, id)
+	return removed, nil
+}
+
+type cacheMock struct {
+	invalidated int
+}
+
+func (c *cacheMock) Invalidate(context.Context) {
+	c.invalidated++
+}
+
+func imageKeys(images []models.Image) []string {
+	keys := make([]string, 0, len(images))
+	for _, image := range images {
+		keys = append(keys, image.Key)
+	}
+	return keys
+}
+
+// storedBlob reports whether the blob with the key suffix is stored
+// under the versioned prefix of the owner.
+func storedBlob(blobs map[string][]byte, ownerPrefix, name string) bool {
+	for key := range blobs {
+		if strings.HasPrefix(key, ownerPrefix+"/") && strings.HasSuffix(key, "/"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	if err != nil {
+		t.Fatalf("failed to encode png: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// encodeHugeGIF encodes the small gif claiming the huge logical screen in
+// its header.
+func encodeHugeGIF(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	err := gif.Encode(&buf, image.NewPaletted(image.Rect(0, 0, 1, 1), palette.Plan9), nil)
+	if err != nil {
+		t.Fatalf("failed to encode gif: %v", err)
+	}
+
+	data := buf.Bytes()
+	binary.LittleEndian.PutUint16(data[6:8], 60000)
+	binary.LittleEndian.PutUint16(data[8:10], 60000)
+
+	return data
+}
+
+func TestService_UploadFilmPoster(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		wantErr   error
+		wantBlobs []string
+	}{
+		{
+			name:    "basic",
+			data:    encodePNG(t, 400, 600),
+			wantErr: nil,
+			wantBlobs: []string{
+				"original.png",
+				"w160.jpg",
+				"w320.jpg",
+			},
+		},
+		{
+			name:    "not an image",
+			data:    []byte("plain text content"),
+			wantErr: ErrUnsupportedType,
+		},
+		{
+			name:    "too large",
+			data:    make([]byte, MaxImageSize+1),
+			wantErr: ErrTooLarge,
+		},
+		{
+			name:    "too many pixels",
+			data:    encodeHugeGIF(t),
+			wantErr: ErrTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &storageMock{blobs: make(map[string][]byte)}
+			repo := &imageRepoMock{images: make(map[uint][]models.Image)}
+			s := NewService(storage, repo, &cacheMock{})
+
+			images, err := s.UploadFilmPoster(
+				context.Background(), 1, bytes.NewReader(tt.data),
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Service.UploadFilmPoster() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(storage.blobs) != len(tt.wantBlobs) {
+				t.Errorf("Service.UploadFilmPoster() stored %d blobs, want %d", len(storage.blobs), len(tt.wantBlobs))
+			}
+			for _, key := range tt.wantBlobs {
+				if !storedBlob(storage.blobs, "films/1", key) {
+					t.Errorf("Service.UploadFilmPoster() blob %q not stored", key)
+				}
+			}
+
+			if len(images) != len(tt.wantBlobs) {
+				t.Errorf("Service.UploadFilmPoster() returned %d images, want %d", len(images), len(tt.wantBlobs))
+			}
+			for _, image := range images {
+				if !image.Original && image.Height != image.Width*3/2 {
+					t.Errorf("Service.UploadFilmPoster() thumbnail %dx%d lost aspect ratio", image.Width, image.Height)
+				}
+			}
+		})
+	}
+}
+
+func TestService_UploadFilmPoster_Replace(t *testing.T) {
+	storage := &storageMock{blobs: make(map[string][]byte)}
+	repo := &imageRepoMock{images: make(map[uint][]models.Image)}
+	cache := &cacheMock{}
+	s := NewService(storage, repo, cache)
+
+	old, err := s.UploadFilmPoster(context.Background(), 1, bytes.NewReader(encodePNG(t, 200, 300)))
+	if err != nil {
+		t.Fatalf("Service.UploadFilmPoster() error = %v", err)
+	}
+
+	images, err := s.UploadFilmPoster(context.Background(), 1, bytes.NewReader(encodePNG(t, 100, 150)))
+	if err != nil {
+		t.Fatalf("Service.UploadFilmPoster() error = %v", err)
+	}
+
+	for _, image := range old {
+		if _, ok := storage.blobs[image.Key]; ok {
+			t.Errorf("Service.UploadFilmPoster() kept replaced blob %q", image.Key)
+		}
+	}
+	for _, image := range images {
+		if _, ok := storage.blobs[image.Key]; !ok {
+			t.Errorf("Service.UploadFilmPoster() blob %q not stored", image.Key)
+		}
+	}
+	if cache.invalidated != 2 {
+		t.Errorf("Service.UploadFilmPoster() invalidated cache %d times, want 2", cache.invalidated)
+	}
+}
+
+func TestService_RemoveFilmImages(t *testing.T) {
+	storage := &storageMock{blobs: map[string][]byte{
+		"films/1/original.png":  {},
+		"films/1/w160.jpg":      {},
+		"films/12/original.png": {},
+	}}
+	s := NewService(storage, &imageRepoMock{}, &cacheMock{})
+
+	err := s.RemoveFilmImages(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Service.RemoveFilmImages() error = %v", err)
+	}
+
+	if len(storage.blobs) != 1 {
+		t.Errorf("Service.RemoveFilmImages() left %d blobs, want 1", len(storage.blobs))
+	}
+}
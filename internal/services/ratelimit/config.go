@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultReads  = 300
+	DefaultWrites = 60
+	DefaultPeriod = time.Minute
+
+	// StoreMemory keeps the buckets in the instance.
+	StoreMemory = "memory"
+	// StorePostgres shares the buckets between the instances.
+	StorePostgres = "postgres"
+)
+
+type Config struct {
+	Store  string
+	Reads  Limit
+	Writes Limit
+}
+
+func NewConfig() Config {
+	period, err := time.ParseDuration(os.Getenv("RATE_LIMIT_PERIOD"))
+	if err != nil || period <= 0 {
+		period = DefaultPeriod
+	}
+
+	store := os.Getenv("RATE_LIMIT_STORE")
+	if store != StorePostgres {
+		store = StoreMemory
+	}
+
+	return Config{
+		Store:  store,
+		Reads:  Limit{Requests: requests("RATE_LIMIT_READS", DefaultReads), Period: period},
+		Writes: Limit{Requests: requests("RATE_LIMIT_WRITES", DefaultWrites), Period: period},
+	}
+}
+
+func requests(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+
+	return value
+}
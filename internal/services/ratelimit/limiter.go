@@ -0,0 +1,158 @@
+package ratelimit
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// Bucket is the state of the token bucket of the client.
+type Bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+// Store keeps the buckets of the clients, the implementations are safe for
+// concurrent use.
+type Store interface {
+	// Update replaces the bucket of the key with the result of fn atomically,
+	// found is false if the key has no bucket yet.
+	Update(ctx context.Context, key string, fn func(bucket Bucket, found bool) Bucket) error
+	// DeleteIdle deletes the buckets not updated since the time.
+	DeleteIdle(ctx context.Context, before time.Time) error
+}
+
+// Limit allows the burst of Requests refilled evenly over the Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Result describes the bucket after the request was counted.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next request is allowed.
+	RetryAfter time.Duration
+}
+
+// refill returns the tokens of the bucket refilled since its last update.
+func (l Limit) refill(bucket Bucket, found bool, now time.Time) float64 {
+	capacity := float64(l.Requests)
+	if !found {
+		return capacity
+	}
+
+	rate := capacity / l.Period.Seconds()
+	elapsed := max(now.Sub(bucket.Updated).Seconds(), 0)
+	return min(capacity, bucket.Tokens+elapsed*rate)
+}
+
+// take counts the request in the bucket refilled since its last update.
+func (l Limit) take(bucket Bucket, found bool, now time.Time) (Bucket, Result) {
+	capacity := float64(l.Requests)
+	rate := capacity / l.Period.Seconds()
+	tokens := l.refill(bucket, found, now)
+
+	result := Result{Limit: l.Requests}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	result.Remaining = int(math.Floor(tokens))
+	result.Reset = seconds((capacity - tokens) / rate)
+
+	return Bucket{Tokens: tokens, Updated: now}, result
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
+
+// Limiter limits the reads and the writes of each client separately.
+type Limiter struct {
+	store  Store
+	reads  Limit
+	writes Limit
+	now    func() time.Time
+
+	mu          sync.Mutex
+	lastCleanup time.Time
+}
+
+func NewLimiter(store Store, reads Limit, writes Limit) *Limiter {
+	return &Limiter{
+		store:  store,
+		reads:  reads,
+		writes: writes,
+		now:    time.Now,
+	}
+}
+
+// Take counts the request of the client identified by the key.
+func (l *Limiter) Take(ctx context.Context, key string, write bool) (Result, error) {
+	limit, prefix := l.reads, "read:"
+	if write {
+		limit, prefix = l.writes, "write:"
+	}
+
+	now := l.now()
+	var result Result
+	err := l.store.Update(ctx, prefix+key, func(bucket Bucket, found bool) Bucket {
+		bucket, result = limit.take(bucket, found, now)
+		return bucket
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	l.cleanup(ctx, now)
+
+	return result, nil
+}
+
+// Check returns the result of the request of the client as if it was
+// counted, the bucket is left as it is.
+func (l *Limiter) Check(ctx context.Context, key string, write bool) (Result, error) {
+	limit, prefix := l.reads, "read:"
+	if write {
+		limit, prefix = l.writes, "write:"
+	}
+
+	now := l.now()
+	var result Result
+	err := l.store.Update(ctx, prefix+key, func(bucket Bucket, found bool) Bucket {
+		_, result = limit.take(bucket, found, now)
+		return Bucket{Tokens: limit.refill(bucket, found, now), Updated: now}
+	})
+	if err != nil {
+		return Result{}, err
+	}
+
+	return result, nil
+}
+
+// cleanup deletes the buckets idle long enough to be full once per the
+// longest period, as the full bucket equals the missing one.
+func (l *Limiter) cleanup(ctx context.Context, now time.Time) {
+	period := max(l.reads.Period, l.writes.Period)
+
+	l.mu.Lock()
+	if now.Sub(l.lastCleanup) < period {
+		l.mu.Unlock()
+		return
+	}
+	l.lastCleanup = now
+	l.mu.Unlock()
+
+	if err := l.store.DeleteIdle(ctx, now.Add(-period)); err != nil {
+		log.Printf("error deleting idle rate limit buckets: %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Take(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(
+		NewMemory(),
+		Limit{Requests: 2, Period: time.Minute},
+		Limit{Requests: 1, Period: time.Minute},
+	)
+	limiter.now = func() time.Time { return now }
+
+	take := func(key string, write bool) Result {
+		t.Helper()
+		result, err := limiter.Take(ctx, key, write)
+		if err != nil {
+			t.Fatalf("Limiter.Take() error = %v", err)
+		}
+		return result
+	}
+
+	if result := take("user:a", false); !result.Allowed || result.Remaining != 1 || result.Reset != 30*time.Second {
+		t.Errorf("Limiter.Take() = %+v, want the first read allowed", result)
+	}
+	take("user:a", false)
+
+	result := take("user:a", false)
+	if result.Allowed || result.Remaining != 0 || result.RetryAfter != 30*time.Second {
+		t.Errorf("Limiter.Take() = %+v, want the third read rejected for 30s", result)
+	}
+
+	// the budgets of the writes and of the other clients are separate
+	if result := take("user:a", true); !result.Allowed || result.Limit != 1 {
+		t.Errorf("Limiter.Take() = %+v, want the write allowed", result)
+	}
+	if result := take("user:b", false); !result.Allowed {
+		t.Errorf("Limiter.Take() = %+v, want the read of another client allowed", result)
+	}
+
+	now = now.Add(30 * time.Second)
+	if result := take("user:a", false); !result.Allowed {
+		t.Errorf("Limiter.Take() = %+v, want the read allowed after the refill", result)
+	}
+}
+
+func TestLimiter_Check(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(
+		NewMemory(),
+		Limit{Requests: 2, Period: time.Minute},
+		Limit{Requests: 1, Period: time.Minute},
+	)
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Check(ctx, "ip:a", true)
+		if err != nil {
+			t.Fatalf("Limiter.Check() error = %v", err)
+		}
+		if !result.Allowed {
+			t.Errorf("Limiter.Check() = %+v, want the write allowed", result)
+		}
+	}
+
+	_, _ = limiter.Take(ctx, "ip:a", true)
+	if result, _ := limiter.Check(ctx, "ip:a", true); result.Allowed {
+		t.Errorf("Limiter.Check() = %+v, want the write rejected after Take", result)
+	}
+}
+
+func TestMemory_DeleteIdle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemory()
+
+	for key, updated := range map[string]time.Time{"idle": now.Add(-time.Hour), "active": now} {
+		_ = store.Update(ctx, key, func(Bucket, bool) Bucket {
+			return Bucket{Tokens: 1, Updated: updated}
+		})
+	}
+
+	_ = store.DeleteIdle(ctx, now.Add(-time.Minute))
+
+	if _, found := store.buckets["idle"]; found {
+		t.Error("Memory.DeleteIdle() kept the idle bucket")
+	}
+	if _, found := store.buckets["active"]; !found {
+		t.Error("Memory.DeleteIdle() deleted the active bucket")
+	}
+}
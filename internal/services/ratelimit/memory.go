@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory keeps the buckets in the instance.
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]Bucket
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: make(map[string]Bucket),
+	}
+}
+
+func (s *Memory) Update(
+	_ context.Context, key string, fn func(bucket Bucket, found bool) Bucket,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, found := s.buckets[key]
+	s.buckets[key] = fn(bucket, found)
+
+	return nil
+}
+
+func (s *Memory) DeleteIdle(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, bucket := range s.buckets {
+		if bucket.Updated.Before(before) {
+			delete(s.buckets, key)
+		}
+	}
+
+	return nil
+}
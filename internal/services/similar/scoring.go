@@ -0,0 +1,103 @@
+package similar
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+// YearScale is the release year difference halving the year score.
+const YearScale = 5.0
+
+var ErrInvalidWeights = errors.New("weights must be non-negative and not all zero")
+
+// Weights set the contribution of each similarity signal to the score.
+type Weights struct {
+	Cast   float64
+	Year   float64
+	Rating float64
+}
+
+var DefaultWeights = Weights{
+	Cast:   0.6,
+	Year:   0.25,
+	Rating: 0.15,
+}
+
+func (w Weights) validate() error {
+	if w.Cast < 0 || w.Year < 0 || w.Rating < 0 || w.Cast+w.Year+w.Rating == 0 {
+		return ErrInvalidWeights
+	}
+
+	return nil
+}
+
+// Rank scores candidates against the target and returns the best limit
+// of them. Scores are normalized by the sum of weights, so they stay in
+// the range from 0 to 1.
+func Rank(
+	target schemas.SimilarityTarget,
+	candidates []schemas.SimilarFilmInfo,
+	weights Weights,
+	limit int,
+) ([]schemas.SimilarFilmInfo, error) {
+	if err := weights.validate(); err != nil {
+		return nil, err
+	}
+	total := weights.Cast + weights.Year + weights.Rating
+
+	ranked := make([]schemas.SimilarFilmInfo, 0, len(candidates))
+	for _, candidate := range candidates {
+		candidate.Breakdown = schemas.ScoreBreakdown{
+			Cast:   weights.Cast / total * castScore(target, candidate),
+			Year:   weights.Year / total * yearScore(target, candidate),
+			Rating: weights.Rating / total * ratingScore(candidate),
+		}
+		candidate.Score = candidate.Breakdown.Cast +
+			candidate.Breakdown.Year +
+			candidate.Breakdown.Rating
+
+		ranked = append(ranked, candidate)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Film.ID < ranked[j].Film.ID
+	})
+
+	if limit >= 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return ranked, nil
+}
+
+// castScore is the Jaccard index of the casts.
+func castScore(
+	target schemas.SimilarityTarget, candidate schemas.SimilarFilmInfo,
+) float64 {
+	union := target.CastSize + candidate.CastSize - candidate.SharedActors
+	if union == 0 {
+		return 0
+	}
+
+	return float64(candidate.SharedActors) / float64(union)
+}
+
+func yearScore(
+	target schemas.SimilarityTarget, candidate schemas.SimilarFilmInfo,
+) float64 {
+	diff := math.Abs(float64(
+		target.ReleaseDate.Year() - candidate.Film.ReleaseDate.ToTime().Year(),
+	))
+
+	return 1 / (1 + diff/YearScale)
+}
+
+func ratingScore(candidate schemas.SimilarFilmInfo) float64 {
+	return float64(candidate.Film.Rating) / 10
+}
@@ -0,0 +1,112 @@
+package similar
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+func candidate(id uint, year int, rating uint8, castSize, shared uint) schemas.SimilarFilmInfo {
+	return schemas.SimilarFilmInfo{
+		Film: schemas.FilmInfo{
+			ID:          id,
+			ReleaseDate: schemas.NewDate(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)),
+			Rating:      rating,
+		},
+		CastSize:     castSize,
+		SharedActors: shared,
+	}
+}
+
+func TestRank(t *testing.T) {
+	target := schemas.SimilarityTarget{
+		ReleaseDate: time.Date(2011, 11, 3, 0, 0, 0, 0, time.UTC),
+		CastSize:    2,
+	}
+	candidates := []schemas.SimilarFilmInfo{
+		candidate(2, 2017, 8, 1, 1),
+		candidate(3, 2023, 7, 2, 2),
+		candidate(4, 2009, 8, 1, 0),
+		candidate(5, 2011, 10, 3, 0),
+	}
+
+	tests := []struct {
+		name    string
+		weights Weights
+		limit   int
+		wantIds []uint
+		wantErr error
+	}{
+		{
+			name:    "default weights prefer shared cast",
+			weights: DefaultWeights,
+			limit:   10,
+			wantIds: []uint{3, 2, 5, 4},
+		},
+		{
+			name:    "year only",
+			weights: Weights{Year: 1},
+			limit:   2,
+			wantIds: []uint{5, 4},
+		},
+		{
+			name:    "rating only",
+			weights: Weights{Rating: 1},
+			limit:   1,
+			wantIds: []uint{5},
+		},
+		{
+			name:    "zero weights",
+			weights: Weights{},
+			wantErr: ErrInvalidWeights,
+		},
+		{
+			name:    "negative weight",
+			weights: Weights{Cast: 1, Year: -1},
+			wantErr: ErrInvalidWeights,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Rank(target, candidates, tt.weights, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Rank() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(got) != len(tt.wantIds) {
+				t.Fatalf("Rank() returned %d films, want %d", len(got), len(tt.wantIds))
+			}
+			for i, film := range got {
+				if film.Film.ID != tt.wantIds[i] {
+					t.Errorf("Rank()[%d] = film %d, want %d", i, film.Film.ID, tt.wantIds[i])
+				}
+
+				sum := film.Breakdown.Cast + film.Breakdown.Year + film.Breakdown.Rating
+				if math.Abs(sum-film.Score) > 1e-9 {
+					t.Errorf("Rank()[%d] breakdown sum = %v, score %v", i, sum, film.Score)
+				}
+				if film.Score < 0 || film.Score > 1 {
+					t.Errorf("Rank()[%d] score = %v out of range", i, film.Score)
+				}
+			}
+		})
+	}
+}
+
+func TestCastScore(t *testing.T) {
+	target := schemas.SimilarityTarget{CastSize: 3}
+
+	got := castScore(target, candidate(1, 2000, 5, 3, 2))
+	if want := 0.5; got != want {
+		t.Errorf("castScore() = %v, want %v", got, want)
+	}
+
+	got = castScore(schemas.SimilarityTarget{}, candidate(1, 2000, 5, 0, 0))
+	if got != 0 {
+		t.Errorf("castScore() of empty casts = %v, want 0", got)
+	}
+}
@@ -0,0 +1,52 @@
+package similar
+
+import (
+	"context"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 50
+)
+
+type filmRepo interface {
+	GetSimilarityCandidates(context.Context, uint) (schemas.SimilarityTarget, []schemas.SimilarFilmInfo, error)
+}
+
+type Service struct {
+	filmRepo filmRepo
+}
+
+func NewService(filmRepo filmRepo) *Service {
+	return &Service{
+		filmRepo: filmRepo,
+	}
+}
+
+// GetSimilarFilms returns films most similar to the given one. Nil
+// weights mean DefaultWeights, a nil limit means DefaultLimit.
+func (s *Service) GetSimilarFilms(
+	ctx context.Context, id uint, weights *Weights, limit *uint,
+) ([]schemas.SimilarFilmInfo, error) {
+	w := DefaultWeights
+	if weights != nil {
+		w = *weights
+	}
+	if err := w.validate(); err != nil {
+		return nil, err
+	}
+
+	n := uint(DefaultLimit)
+	if limit != nil {
+		n = min(*limit, MaxLimit)
+	}
+
+	target, candidates, err := s.filmRepo.GetSimilarityCandidates(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return Rank(target, candidates, w, int(n))
+}
@@ -0,0 +1,23 @@
+package stats
+
+import (
+	"os"
+	"time"
+)
+
+const DefaultRefreshInterval = 15 * time.Minute
+
+type Config struct {
+	RefreshInterval time.Duration
+}
+
+func NewConfig() Config {
+	interval, err := time.ParseDuration(os.Getenv("STATS_REFRESH_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	return Config{
+		RefreshInterval: interval,
+	}
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+)
+
+const (
+	DefaultActorsLimit = 10
+	MaxActorsLimit     = 100
+)
+
+type statsRepo interface {
+	Refresh(context.Context) error
+	GetStats(context.Context, uint) (schemas.StatsResponse, error)
+}
+
+type Service struct {
+	statsRepo statsRepo
+}
+
+func NewService(statsRepo statsRepo) *Service {
+	return &Service{
+		statsRepo: statsRepo,
+	}
+}
+
+// GetStats returns the catalogue statistics. A nil limit of the most
+// prolific actors means DefaultActorsLimit.
+func (s *Service) GetStats(
+	ctx context.Context, limit *uint,
+) (schemas.StatsResponse, error) {
+	n := uint(DefaultActorsLimit)
+	if limit != nil {
+		n = min(*limit, MaxActorsLimit)
+	}
+
+	return s.statsRepo.GetStats(ctx, n)
+}
+
+// RunRefresh refreshes the statistics every interval until the context
+// is cancelled.
+func (s *Service) RunRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.statsRepo.Refresh(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("statistics refresh failed: %v", err)
+			}
+		}
+	}
+}
@@ -35,3 +35,40 @@ func TestCamelToSnake(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		out  string
+	}{
+		{
+			name: "basic",
+			in:   "Drive",
+			out:  "drive",
+		},
+		{
+			name: "punctuation and spaces",
+			in:   "  Blade Runner:   2049 ",
+			out:  "blade runner 2049",
+		},
+		{
+			name: "diacritics",
+			in:   "Léon: The Professional",
+			out:  "leon the professional",
+		},
+		{
+			name: "cyrillic",
+			in:   "Ёлки-палки",
+			out:  "елки палки",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Normalize(tt.in); got != tt.out {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.out)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+package translations
+
+import "errors"
+
+var ErrInvalidLocale = errors.New("invalid locale")
@@ -0,0 +1,162 @@
+package translations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/infrastructure/postgresql"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+	"github.com/sivistrukov/vk-assigment/internal/services/i18n"
+)
+
+type translationRepo interface {
+	SetFilmTranslation(context.Context, models.FilmTranslation) error
+	RemoveFilmTranslation(context.Context, uint, string) error
+	GetFilmTranslations(context.Context, uint) ([]schemas.FilmTranslationInfo, error)
+	SetActorTranslation(context.Context, models.ActorTranslation) error
+	RemoveActorTranslation(context.Context, uint, string) error
+	GetActorTranslations(context.Context, uint) ([]schemas.ActorTranslationInfo, error)
+}
+
+// readCache holds the cached reads of the films and actors which embed
+// the translated names.
+type readCache interface {
+	Invalidate(context.Context)
+}
+
+type Service struct {
+	translationRepo translationRepo
+	cache           readCache
+}
+
+func NewService(translationRepo translationRepo, cache readCache) *Service {
+	return &Service{
+		translationRepo: translationRepo,
+		cache:           cache,
+	}
+}
+
+func (s *Service) SetFilmTranslation(
+	ctx context.Context,
+	filmId uint,
+	locale string,
+	request schemas.SetFilmTranslationRequest,
+) (models.FilmTranslation, error) {
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return models.FilmTranslation{}, err
+	}
+
+	translation := models.FilmTranslation{
+		FilmID:      filmId,
+		Locale:      locale,
+		Title:       request.Title,
+		Description: request.Description,
+	}
+
+	err = s.translationRepo.SetFilmTranslation(ctx, translation)
+	if err != nil {
+		return models.FilmTranslation{}, wrapError("error setting film translation", err)
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return translation, nil
+}
+
+func (s *Service) RemoveFilmTranslation(
+	ctx context.Context, filmId uint, locale string,
+) error {
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	err = s.translationRepo.RemoveFilmTranslation(ctx, filmId, locale)
+	if err != nil {
+		return err
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
+}
+
+func (s *Service) GetFilmTranslations(
+	ctx context.Context, filmId uint,
+) ([]schemas.FilmTranslationInfo, error) {
+	return s.translationRepo.GetFilmTranslations(ctx, filmId)
+}
+
+func (s *Service) SetActorTranslation(
+	ctx context.Context,
+	actorId uint,
+	locale string,
+	request schemas.SetActorTranslationRequest,
+) (models.ActorTranslation, error) {
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return models.ActorTranslation{}, err
+	}
+
+	translation := models.ActorTranslation{
+		ActorID:    actorId,
+		Locale:     locale,
+		FirstName:  request.FirstName,
+		LastName:   request.LastName,
+		MiddleName: request.MiddleName,
+	}
+
+	err = s.translationRepo.SetActorTranslation(ctx, translation)
+	if err != nil {
+		return models.ActorTranslation{}, wrapError("error setting actor translation", err)
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return translation, nil
+}
+
+func (s *Service) RemoveActorTranslation(
+	ctx context.Context, actorId uint, locale string,
+) error {
+	locale, err := parseLocale(locale)
+	if err != nil {
+		return err
+	}
+
+	err = s.translationRepo.RemoveActorTranslation(ctx, actorId, locale)
+	if err != nil {
+		return err
+	}
+
+	postgresql.AfterCommit(ctx, s.cache.Invalidate)
+
+	return nil
+}
+
+func (s *Service) GetActorTranslations(
+	ctx context.Context, actorId uint,
+) ([]schemas.ActorTranslationInfo, error) {
+	return s.translationRepo.GetActorTranslations(ctx, actorId)
+}
+
+func parseLocale(locale string) (string, error) {
+	locale = i18n.Normalize(locale)
+	if !i18n.IsValid(locale) {
+		return "", ErrInvalidLocale
+	}
+
+	return locale, nil
+}
+
+func wrapError(msg string, err error) error {
+	var notFoundErr *postgresql.ErrRecordNotFound
+	if errors.As(err, &notFoundErr) {
+		return err
+	}
+
+	return fmt.Errorf("%s: %v", msg, err)
+}
@@ -10,6 +10,7 @@ import (
 
 type userRepo interface {
 	Create(context.Context, *models.User) error
+	GetByID(context.Context, uint) (models.User, error)
 }
 
 type Service struct {
@@ -42,3 +43,7 @@ func (s *Service) CreateUser(
 
 	return user, nil
 }
+
+func (s *Service) GetUser(ctx context.Context, id uint) (models.User, error) {
+	return s.userRepo.GetByID(ctx, id)
+}
@@ -2,20 +2,66 @@ package validator
 
 import (
 	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator"
 	"github.com/sivistrukov/vk-assigment/internal/models"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
 )
 
+// matchMoney matches non-negative amounts fitting NUMERIC(15, 2).
+var matchMoney = regexp.MustCompile(`^\d{1,13}(\.\d{1,2})?$`)
+
+var ageRatings = []models.AgeRating{
+	models.RatedG,
+	models.RatedPG,
+	models.RatedPG13,
+	models.RatedR,
+	models.RatedNC17,
+	models.Rated0,
+	models.Rated6,
+	models.Rated12,
+	models.Rated16,
+	models.Rated18,
+}
+
 func New() *validator.Validate {
 	validate := validator.New()
+	validate.RegisterTagNameFunc(jsonName)
 	_ = validate.RegisterValidation("dateValidation", dateValidation)
+	_ = validate.RegisterValidation("isoDateValidation", isoDateValidation)
 	_ = validate.RegisterValidation("sexValidation", sexValidation)
+	_ = validate.RegisterValidation("visibilityValidation", visibilityValidation)
+	_ = validate.RegisterValidation("countryValidation", countryValidation)
+	_ = validate.RegisterValidation("languageValidation", languageValidation)
+	_ = validate.RegisterValidation("currencyValidation", currencyValidation)
+	_ = validate.RegisterValidation("moneyValidation", moneyValidation)
+	_ = validate.RegisterValidation("ageRatingValidation", ageRatingValidation)
+	_ = validate.RegisterValidation("relationTypeValidation", relationTypeValidation)
 
 	return validate
 }
 
+// jsonName names the fields in the validation errors by their JSON names,
+// the fields without JSON names are named by the query tag.
+func jsonName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return ""
+}
+
 func dateValidation(fl validator.FieldLevel) bool {
 	value := fl.Field()
 	var dateString string
@@ -34,6 +80,17 @@ func dateValidation(fl validator.FieldLevel) bool {
 	return err == nil
 }
 
+// isoDateValidation checks the ISO 8601 calendar date, e.g. "2006-01-02".
+func isoDateValidation(fl validator.FieldLevel) bool {
+	date, ok := stringValue(fl)
+	if !ok {
+		return false
+	}
+
+	_, err := time.Parse(time.DateOnly, date)
+	return err == nil
+}
+
 func sexValidation(fl validator.FieldLevel) bool {
 	value := fl.Field()
 	var sexString string
@@ -48,3 +105,101 @@ func sexValidation(fl validator.FieldLevel) bool {
 
 	return sexString == string(models.Male) || sexString == string(models.Female)
 }
+
+func visibilityValidation(fl validator.FieldLevel) bool {
+	value := fl.Field()
+	var visibilityString string
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return false
+		}
+		visibilityString = value.Elem().String()
+	} else {
+		visibilityString = fl.Field().String()
+	}
+
+	return visibilityString == string(models.Public) ||
+		visibilityString == string(models.Private)
+}
+
+// countryValidation checks the ISO 3166-1 alpha-2 country code, e.g. "US".
+func countryValidation(fl validator.FieldLevel) bool {
+	code, ok := stringValue(fl)
+	if !ok || len(code) != 2 {
+		return false
+	}
+
+	region, err := language.ParseRegion(code)
+	return err == nil && region.IsCountry() && region.String() == code
+}
+
+// languageValidation checks the ISO 639-1 language code, e.g. "en".
+func languageValidation(fl validator.FieldLevel) bool {
+	code, ok := stringValue(fl)
+	if !ok || len(code) != 2 {
+		return false
+	}
+
+	base, err := language.ParseBase(code)
+	return err == nil && base.String() == code
+}
+
+// currencyValidation checks the ISO 4217 currency code, e.g. "USD".
+// A nil code is valid, its presence is checked by required_with.
+func currencyValidation(fl validator.FieldLevel) bool {
+	if value := fl.Field(); value.Kind() == reflect.Pointer && value.IsNil() {
+		return true
+	}
+
+	code, ok := stringValue(fl)
+	if !ok {
+		return false
+	}
+
+	unit, err := currency.ParseISO(code)
+	return err == nil && unit.String() == code
+}
+
+// moneyValidation checks the decimal amount with at most two fraction
+// digits, e.g. "185000000.00".
+func moneyValidation(fl validator.FieldLevel) bool {
+	amount, ok := stringValue(fl)
+	return ok && matchMoney.MatchString(amount)
+}
+
+func ageRatingValidation(fl validator.FieldLevel) bool {
+	rating, ok := stringValue(fl)
+	return ok && slices.Contains(ageRatings, models.AgeRating(rating))
+}
+
+// relationTypeValidation accepts the relation types which can be stored.
+func relationTypeValidation(fl validator.FieldLevel) bool {
+	relation, ok := stringValue(fl)
+	if !ok {
+		return false
+	}
+
+	switch models.RelationType(relation) {
+	case models.SequelOf, models.PrequelOf, models.RemakeOf, models.SpinOffOf:
+		return true
+	default:
+		return false
+	}
+}
+
+// stringValue returns the string value of the field dereferencing pointers.
+func stringValue(fl validator.FieldLevel) (string, bool) {
+	value := fl.Field()
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.String {
+		return "", false
+	}
+
+	return value.String(), true
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestMetadataValidations(t *testing.T) {
+	tests := []struct {
+		name  string
+		tag   string
+		value string
+		valid bool
+	}{
+		{name: "country", tag: "countryValidation", value: "US", valid: true},
+		{name: "country lowercase", tag: "countryValidation", value: "us", valid: false},
+		{name: "country unknown", tag: "countryValidation", value: "ZZ", valid: false},
+		{name: "country alpha-3", tag: "countryValidation", value: "USA", valid: false},
+		{name: "language", tag: "languageValidation", value: "en", valid: true},
+		{name: "language alpha-3", tag: "languageValidation", value: "eng", valid: false},
+		{name: "language unknown", tag: "languageValidation", value: "qa", valid: false},
+		{name: "currency", tag: "currencyValidation", value: "USD", valid: true},
+		{name: "currency unknown", tag: "currencyValidation", value: "ABC", valid: false},
+		{name: "currency lowercase", tag: "currencyValidation", value: "rub", valid: false},
+		{name: "money", tag: "moneyValidation", value: "185000000", valid: true},
+		{name: "money with cents", tag: "moneyValidation", value: "12.5", valid: true},
+		{name: "money negative", tag: "moneyValidation", value: "-1", valid: false},
+		{name: "money too precise", tag: "moneyValidation", value: "1.005", valid: false},
+		{name: "money too large", tag: "moneyValidation", value: "10000000000000", valid: false},
+		{name: "age rating", tag: "ageRatingValidation", value: "PG-13", valid: true},
+		{name: "age rating unknown", tag: "ageRatingValidation", value: "21+", valid: false},
+		{name: "relation type", tag: "relationTypeValidation", value: "sequel_of", valid: true},
+		{name: "iso date", tag: "isoDateValidation", value: "2006-01-02", valid: true},
+		{name: "iso date legacy format", tag: "isoDateValidation", value: "02-01-2006", valid: false},
+		{name: "iso date with time", tag: "isoDateValidation", value: "2006-01-02T15:04:05Z", valid: false},
+		{name: "relation type inverse", tag: "relationTypeValidation", value: "original_of", valid: false},
+	}
+
+	validate := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Var(tt.value, tt.tag)
+			if (err == nil) != tt.valid {
+				t.Errorf("%s(%q) error = %v, want valid %v", tt.tag, tt.value, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestOptionalCurrency(t *testing.T) {
+	type request struct {
+		Budget   *string `validate:"omitempty,moneyValidation"`
+		Currency *string `validate:"required_with=Budget,omitempty,currencyValidation"`
+	}
+
+	budget, usd, unknown := "100.00", "USD", "ABC"
+	tests := []struct {
+		name    string
+		request request
+		valid   bool
+	}{
+		{name: "no budget", request: request{}, valid: true},
+		{name: "budget with currency", request: request{Budget: &budget, Currency: &usd}, valid: true},
+		{name: "budget without currency", request: request{Budget: &budget}, valid: false},
+		{name: "unknown currency", request: request{Currency: &unknown}, valid: false},
+	}
+
+	validate := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Struct(tt.request)
+			if (err == nil) != tt.valid {
+				t.Errorf("Struct(%+v) error = %v, want valid %v", tt.request, err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package webhooks
+
+import (
+	"os"
+	"time"
+)
+
+const DefaultPollInterval = 5 * time.Second
+
+type Config struct {
+	PollInterval time.Duration
+}
+
+func NewConfig() Config {
+	interval, err := time.ParseDuration(os.Getenv("WEBHOOKS_POLL_INTERVAL"))
+	if err != nil || interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return Config{
+		PollInterval: interval,
+	}
+}
@@ -0,0 +1,187 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+const (
+	// MaxAttempts is the number of attempts before the delivery fails.
+	MaxAttempts = 8
+
+	// InitialBackoff is the delay after the first failed attempt, it is
+	// doubled after every attempt up to MaxBackoff.
+	InitialBackoff = 30 * time.Second
+	MaxBackoff     = time.Hour
+
+	DeliveryTimeout = 10 * time.Second
+
+	// the lease keeps the claimed deliveries from other instances
+	// until the attempts are recorded
+	deliveryLease = 5 * DeliveryTimeout
+
+	fanOutBatch   = 500
+	deliveryBatch = 20
+)
+
+// Signature headers of the webhook calls. The signature is the hex encoded
+// HMAC-SHA256 of the timestamp, a dot and the body keyed with the secret.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	EventIDHeader   = "X-Webhook-Id"
+)
+
+// Sign returns the value of the signature header.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay after the failed attempt.
+func Backoff(attempt uint) time.Duration {
+	delay := InitialBackoff
+	for i := uint(1); i < attempt && delay < MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, MaxBackoff)
+}
+
+// Run dispatches the changes written to the outbox until ctx is done.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Dispatch(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("webhooks dispatch failed: %v", err)
+			}
+		}
+	}
+}
+
+// Dispatch moves the changes from the outbox to the deliveries and makes
+// the attempts which are due.
+func (s *Service) Dispatch(ctx context.Context) error {
+	if err := s.webhookRepo.FanOut(ctx, fanOutBatch); err != nil {
+		return fmt.Errorf("error reading outbox: %v", err)
+	}
+
+	deliveries, webhooks, err := s.webhookRepo.ClaimDeliveries(
+		ctx, deliveryBatch, deliveryLease,
+	)
+	if err != nil {
+		return fmt.Errorf("error claiming deliveries: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery models.WebhookDelivery) {
+			defer wg.Done()
+
+			delivery = s.deliver(ctx, webhooks[delivery.WebhookID], delivery)
+			if err := s.webhookRepo.RecordAttempt(ctx, delivery); err != nil {
+				log.Printf("error recording delivery %d: %v", delivery.ID, err)
+			}
+		}(delivery)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// deliver calls the webhook and returns the delivery updated with the
+// result of the attempt.
+func (s *Service) deliver(
+	ctx context.Context, webhook models.Webhook, delivery models.WebhookDelivery,
+) models.WebhookDelivery {
+	status, err := s.send(ctx, webhook, delivery)
+
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	delivery.Error = nil
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliveryDelivered
+		return delivery
+	case delivery.Attempts >= MaxAttempts:
+		delivery.Status = models.DeliveryFailed
+	default:
+		delivery.Status = models.DeliveryPending
+		delivery.NextAttemptAt = time.Now().Add(Backoff(delivery.Attempts))
+	}
+
+	message := err.Error()
+	delivery.Error = &message
+
+	return delivery
+}
+
+func (s *Service) send(
+	ctx context.Context, webhook models.Webhook, delivery models.WebhookDelivery,
+) (int, error) {
+	body, err := json.Marshal(schemas.WebhookPayload{
+		ID:         delivery.EventID,
+		Type:       delivery.Type,
+		FilmID:     delivery.FilmID,
+		OccurredAt: delivery.OccurredAt,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodPost, webhook.URL, bytes.NewReader(body),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(delivery.Type))
+	req.Header.Set(EventIDHeader, strconv.FormatInt(delivery.EventID, 10))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
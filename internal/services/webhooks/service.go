@@ -0,0 +1,118 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+type webhookRepo interface {
+	Create(context.Context, *models.Webhook) error
+	Update(context.Context, *models.Webhook) error
+	Remove(context.Context, uint) error
+	GetList(context.Context) ([]models.Webhook, error)
+	GetDeliveries(context.Context, uint, uint) ([]models.WebhookDelivery, error)
+	FanOut(context.Context, uint) error
+	ClaimDeliveries(context.Context, uint, time.Duration) ([]models.WebhookDelivery, map[uint]models.Webhook, error)
+	RecordAttempt(context.Context, models.WebhookDelivery) error
+}
+
+const (
+	DefaultDeliveriesLimit = 50
+	MaxDeliveriesLimit     = 200
+)
+
+type Service struct {
+	webhookRepo webhookRepo
+	client      *http.Client
+}
+
+func NewService(webhookRepo webhookRepo) *Service {
+	return &Service{
+		webhookRepo: webhookRepo,
+		client: &http.Client{
+			Timeout: DeliveryTimeout,
+			// a redirect is not followed and counts as a failed attempt
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *Service) CreateWebhook(
+	ctx context.Context, request schemas.WebhookRequest,
+) (schemas.WebhookResponse, error) {
+	webhook := models.Webhook{
+		URL:        request.URL,
+		EventTypes: request.EventTypes,
+		Secret:     request.Secret,
+	}
+
+	if err := s.webhookRepo.Create(ctx, &webhook); err != nil {
+		return schemas.WebhookResponse{}, err
+	}
+
+	return schemas.NewWebhookResponse(webhook), nil
+}
+
+func (s *Service) UpdateWebhook(
+	ctx context.Context, id uint, request schemas.WebhookRequest,
+) (schemas.WebhookResponse, error) {
+	webhook := models.Webhook{
+		ID:         id,
+		URL:        request.URL,
+		EventTypes: request.EventTypes,
+		Secret:     request.Secret,
+	}
+
+	if err := s.webhookRepo.Update(ctx, &webhook); err != nil {
+		return schemas.WebhookResponse{}, err
+	}
+
+	return schemas.NewWebhookResponse(webhook), nil
+}
+
+func (s *Service) RemoveWebhook(ctx context.Context, id uint) error {
+	return s.webhookRepo.Remove(ctx, id)
+}
+
+func (s *Service) GetWebhooks(ctx context.Context) ([]schemas.WebhookResponse, error) {
+	webhooks, err := s.webhookRepo.GetList(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]schemas.WebhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		response = append(response, schemas.NewWebhookResponse(webhook))
+	}
+
+	return response, nil
+}
+
+// GetDeliveries returns the latest deliveries of the webhook. A nil limit
+// means DefaultDeliveriesLimit, limit is capped at MaxDeliveriesLimit.
+func (s *Service) GetDeliveries(
+	ctx context.Context, id uint, limit *uint,
+) ([]schemas.WebhookDelivery, error) {
+	n := uint(DefaultDeliveriesLimit)
+	if limit != nil {
+		n = min(*limit, MaxDeliveriesLimit)
+	}
+
+	deliveries, err := s.webhookRepo.GetDeliveries(ctx, id, n)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]schemas.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, schemas.NewWebhookDelivery(delivery))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,186 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sivistrukov/vk-assigment/internal/entrypoints/http/schemas"
+	"github.com/sivistrukov/vk-assigment/internal/models"
+)
+
+const secret = "0123456789abcdef"
+
+// fakeRepo keeps a single delivery which is due on every claim.
+type fakeRepo struct {
+	webhookRepo
+
+	mu       sync.Mutex
+	webhook  models.Webhook
+	delivery models.WebhookDelivery
+	recorded []models.WebhookDelivery
+}
+
+func newFakeRepo(url string) *fakeRepo {
+	return &fakeRepo{
+		webhook: models.Webhook{ID: 1, URL: url, Secret: secret},
+		delivery: models.WebhookDelivery{
+			ID:         10,
+			WebhookID:  1,
+			EventID:    42,
+			Type:       models.FilmUpdated,
+			FilmID:     7,
+			OccurredAt: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			Status:     models.DeliveryPending,
+		},
+	}
+}
+
+func (r *fakeRepo) FanOut(context.Context, uint) error {
+	return nil
+}
+
+func (r *fakeRepo) ClaimDeliveries(
+	context.Context, uint, time.Duration,
+) ([]models.WebhookDelivery, map[uint]models.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.delivery.Status != models.DeliveryPending {
+		return nil, nil, nil
+	}
+	r.delivery.Attempts++
+
+	return []models.WebhookDelivery{r.delivery}, map[uint]models.Webhook{1: r.webhook}, nil
+}
+
+func (r *fakeRepo) RecordAttempt(_ context.Context, delivery models.WebhookDelivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.delivery = delivery
+	r.recorded = append(r.recorded, delivery)
+	return nil
+}
+
+func TestService_Dispatch(t *testing.T) {
+	var received []*http.Request
+	var bodies [][]byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, r)
+		bodies = append(bodies, body)
+	}))
+	defer receiver.Close()
+
+	repo := newFakeRepo(receiver.URL)
+	s := NewService(repo)
+
+	if err := s.Dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("got %d calls, want 1", len(received))
+	}
+
+	r, body := received[0], bodies[0]
+	timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid timestamp header: %v", err)
+	}
+	if r.Header.Get(SignatureHeader) != Sign(secret, timestamp, body) {
+		t.Errorf("invalid signature %q", r.Header.Get(SignatureHeader))
+	}
+	if r.Header.Get(EventHeader) != "film.updated" || r.Header.Get(EventIDHeader) != "42" {
+		t.Errorf("unexpected headers %v", r.Header)
+	}
+
+	var payload schemas.WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.ID != 42 || payload.FilmID != 7 || payload.Type != models.FilmUpdated {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	if repo.delivery.Status != models.DeliveryDelivered || *repo.delivery.ResponseStatus != 200 {
+		t.Errorf("unexpected delivery %+v", repo.delivery)
+	}
+
+	// delivered changes are not sent again
+	if err := s.Dispatch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 1 {
+		t.Errorf("got %d calls, want 1", len(received))
+	}
+}
+
+func TestService_DispatchRetries(t *testing.T) {
+	calls := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	repo := newFakeRepo(receiver.URL)
+	s := NewService(repo)
+
+	for i := 0; i < MaxAttempts+2; i++ {
+		if err := s.Dispatch(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls != MaxAttempts {
+		t.Errorf("got %d calls, want %d", calls, MaxAttempts)
+	}
+
+	first := repo.recorded[0]
+	if first.Status != models.DeliveryPending || *first.ResponseStatus != 503 || first.Error == nil {
+		t.Errorf("unexpected first attempt %+v", first)
+	}
+	if delay := time.Until(first.NextAttemptAt); delay <= 0 || delay > InitialBackoff {
+		t.Errorf("unexpected delay of the second attempt %s", delay)
+	}
+
+	if repo.delivery.Status != models.DeliveryFailed || repo.delivery.Attempts != MaxAttempts {
+		t.Errorf("unexpected delivery %+v", repo.delivery)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt uint
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 4, want: 4 * time.Minute},
+		{attempt: 7, want: 32 * time.Minute},
+		{attempt: 8, want: time.Hour},
+		{attempt: 20, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '1700000000.{}' | openssl dgst -sha256 -hmac 0123456789abcdef
+	want := "sha256=e4f8e2ecae2295b2ddb2f0b5584c8275e226c0ebe9b3b819e70156bb67122e3e"
+	if got := Sign(secret, 1700000000, []byte("{}")); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(150) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    visibility VARCHAR DEFAULT 'private' NOT NULL,
+    owner_id INTEGER REFERENCES users (id) ON DELETE CASCADE NOT NULL
+);
+CREATE TABLE IF NOT EXISTS collection_items (
+    id SERIAL PRIMARY KEY,
+    collection_id INTEGER REFERENCES collections (id) ON DELETE CASCADE NOT NULL,
+    film_id INTEGER REFERENCES films (id) ON DELETE CASCADE NOT NULL,
+    position INTEGER NOT NULL,
+    CONSTRAINT uniq_collection_films UNIQUE (collection_id, film_id)
+);
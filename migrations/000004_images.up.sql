@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS images (
+    id SERIAL PRIMARY KEY,
+    film_id INTEGER REFERENCES films (id) ON DELETE CASCADE NULL,
+    actor_id INTEGER REFERENCES actors (id) ON DELETE CASCADE NULL,
+    key VARCHAR NOT NULL,
+    url VARCHAR NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    original bool DEFAULT false NOT NULL,
+    CONSTRAINT images_single_owner CHECK ((film_id IS NULL) <> (actor_id IS NULL))
+);
+CREATE INDEX IF NOT EXISTS images_film_id_idx ON images (film_id);
+CREATE INDEX IF NOT EXISTS images_actor_id_idx ON images (actor_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS nominations;
+DROP TABLE IF EXISTS award_categories;
+DROP TABLE IF EXISTS award_ceremonies;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS award_ceremonies (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    CONSTRAINT uniq_award_ceremonies_name UNIQUE (name)
+);
+CREATE TABLE IF NOT EXISTS award_categories (
+    id SERIAL PRIMARY KEY,
+    ceremony_id INTEGER REFERENCES award_ceremonies (id) ON DELETE CASCADE NOT NULL,
+    name VARCHAR(150) NOT NULL,
+    CONSTRAINT uniq_award_categories_name UNIQUE (ceremony_id, name)
+);
+CREATE TABLE IF NOT EXISTS nominations (
+    id SERIAL PRIMARY KEY,
+    category_id INTEGER REFERENCES award_categories (id) ON DELETE CASCADE NOT NULL,
+    year INTEGER NOT NULL,
+    film_id INTEGER REFERENCES films (id) ON DELETE CASCADE NULL,
+    actor_id INTEGER REFERENCES actors (id) ON DELETE CASCADE NULL,
+    won bool DEFAULT false NOT NULL,
+    CONSTRAINT nominations_nominee CHECK (film_id IS NOT NULL OR actor_id IS NOT NULL)
+);
+CREATE INDEX IF NOT EXISTS nominations_film_id_idx ON nominations (film_id);
+CREATE INDEX IF NOT EXISTS nominations_actor_id_idx ON nominations (actor_id);
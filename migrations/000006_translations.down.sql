@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS actor_translations;
+DROP TABLE IF EXISTS film_translations;
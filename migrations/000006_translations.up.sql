@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS film_translations (
+    id SERIAL PRIMARY KEY,
+    film_id INTEGER REFERENCES films (id) ON DELETE CASCADE NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    title VARCHAR(150) NOT NULL,
+    description VARCHAR(1000) NULL,
+    CONSTRAINT uniq_film_translations UNIQUE (film_id, locale)
+);
+CREATE TABLE IF NOT EXISTS actor_translations (
+    id SERIAL PRIMARY KEY,
+    actor_id INTEGER REFERENCES actors (id) ON DELETE CASCADE NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    first_name VARCHAR NOT NULL,
+    last_name VARCHAR NOT NULL,
+    middle_name VARCHAR NULL,
+    CONSTRAINT uniq_actor_translations UNIQUE (actor_id, locale)
+);
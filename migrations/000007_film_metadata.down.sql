@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS films_countries_idx;
+ALTER TABLE films
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS box_office,
+    DROP COLUMN IF EXISTS budget,
+    DROP COLUMN IF EXISTS age_rating,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS countries,
+    DROP COLUMN IF EXISTS runtime;
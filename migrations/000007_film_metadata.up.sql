@@ -0,0 +1,9 @@
+ALTER TABLE films
+    ADD COLUMN IF NOT EXISTS runtime INTEGER NULL,
+    ADD COLUMN IF NOT EXISTS countries VARCHAR(2)[] DEFAULT '{}' NOT NULL,
+    ADD COLUMN IF NOT EXISTS language VARCHAR(2) NULL,
+    ADD COLUMN IF NOT EXISTS age_rating VARCHAR(10) NULL,
+    ADD COLUMN IF NOT EXISTS budget NUMERIC(15, 2) NULL,
+    ADD COLUMN IF NOT EXISTS box_office NUMERIC(15, 2) NULL,
+    ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NULL;
+CREATE INDEX IF NOT EXISTS films_countries_idx ON films USING GIN (countries);
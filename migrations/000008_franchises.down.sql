@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS film_relations;
+DROP TABLE IF EXISTS franchise_films;
+DROP TABLE IF EXISTS franchises;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS franchises (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(150) NOT NULL,
+    description VARCHAR(1000) DEFAULT '' NOT NULL,
+    CONSTRAINT uniq_franchises_name UNIQUE (name)
+);
+CREATE TABLE IF NOT EXISTS franchise_films (
+    id SERIAL PRIMARY KEY,
+    franchise_id INTEGER REFERENCES franchises (id) ON DELETE CASCADE NOT NULL,
+    film_id INTEGER REFERENCES films (id) ON DELETE CASCADE NOT NULL,
+    position INTEGER NOT NULL,
+    CONSTRAINT uniq_franchise_films UNIQUE (franchise_id, film_id)
+);
+CREATE TABLE IF NOT EXISTS film_relations (
+    id SERIAL PRIMARY KEY,
+    film_id INTEGER REFERENCES films (id) ON DELETE CASCADE NOT NULL,
+    related_film_id INTEGER REFERENCES films (id) ON DELETE CASCADE NOT NULL,
+    type VARCHAR NOT NULL,
+    CONSTRAINT film_relations_not_self CHECK (film_id <> related_film_id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uniq_film_relations
+    ON film_relations (LEAST(film_id, related_film_id), GREATEST(film_id, related_film_id));
+CREATE INDEX IF NOT EXISTS film_relations_related_film_id_idx ON film_relations (related_film_id);
@@ -0,0 +1,4 @@
+DROP MATERIALIZED VIEW IF EXISTS stats_summary;
+DROP MATERIALIZED VIEW IF EXISTS stats_actor_films;
+DROP MATERIALIZED VIEW IF EXISTS stats_rating_histogram;
+DROP MATERIALIZED VIEW IF EXISTS stats_films_by_year;
@@ -0,0 +1,41 @@
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_films_by_year AS
+SELECT EXTRACT(YEAR FROM release_date)::INTEGER AS year, COUNT(*) AS films
+FROM films
+GROUP BY year;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_films_by_year_idx ON stats_films_by_year (year);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_rating_histogram AS
+SELECT buckets.rating, COUNT(films.id) AS films
+FROM generate_series(0, 10) AS buckets (rating)
+LEFT JOIN films ON films.rating = buckets.rating
+GROUP BY buckets.rating;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_rating_histogram_idx ON stats_rating_histogram (rating);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_actor_films AS
+SELECT actors.id AS actor_id, COUNT(actors_and_films.film_id) AS films
+FROM actors
+LEFT JOIN actors_and_films ON actors_and_films.actor_id = actors.id
+GROUP BY actors.id;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_actor_films_idx ON stats_actor_films (actor_id);
+CREATE INDEX IF NOT EXISTS stats_actor_films_films_idx ON stats_actor_films (films DESC);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_summary AS
+SELECT 1 AS id,
+    (
+        SELECT AVG(cast_size) FROM (
+            SELECT COUNT(actors_and_films.actor_id) AS cast_size
+            FROM films
+            LEFT JOIN actors_and_films ON actors_and_films.film_id = films.id
+            GROUP BY films.id
+        ) AS casts
+    ) AS avg_cast_size,
+    (SELECT COUNT(*) FROM actors WHERE sex = 'male') AS male_actors,
+    (SELECT COUNT(*) FROM actors WHERE sex = 'female') AS female_actors,
+    (
+        SELECT AVG(DATE_PART('year', AGE(films.release_date, actors.birthday)))
+        FROM actors_and_films
+        INNER JOIN films ON films.id = actors_and_films.film_id
+        INNER JOIN actors ON actors.id = actors_and_films.actor_id
+    ) AS avg_age_at_release,
+    now() AS refreshed_at;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_summary_idx ON stats_summary (id);
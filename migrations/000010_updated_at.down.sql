@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS actors_and_films_updated_at ON actors_and_films;
+DROP TRIGGER IF EXISTS actors_updated_at ON actors;
+DROP TRIGGER IF EXISTS films_updated_at ON films;
+ALTER TABLE actors_and_films DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE actors DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE films DROP COLUMN IF EXISTS updated_at;
+DROP FUNCTION IF EXISTS set_updated_at();
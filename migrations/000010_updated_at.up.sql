@@ -0,0 +1,21 @@
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = now();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE films ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT now() NOT NULL;
+ALTER TABLE actors ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT now() NOT NULL;
+ALTER TABLE actors_and_films ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ DEFAULT now() NOT NULL;
+
+CREATE INDEX IF NOT EXISTS films_updated_at_idx ON films (updated_at);
+CREATE INDEX IF NOT EXISTS actors_updated_at_idx ON actors (updated_at);
+CREATE INDEX IF NOT EXISTS actors_and_films_updated_at_idx ON actors_and_films (updated_at);
+
+CREATE TRIGGER films_updated_at BEFORE UPDATE ON films
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER actors_updated_at BEFORE UPDATE ON actors
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER actors_and_films_updated_at BEFORE UPDATE ON actors_and_films
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
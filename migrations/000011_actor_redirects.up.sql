@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS actor_redirects (
+    source_id INTEGER PRIMARY KEY,
+    target_id INTEGER REFERENCES actors (id) ON DELETE CASCADE NOT NULL,
+    merged_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+CREATE INDEX IF NOT EXISTS actor_redirects_target_id_idx ON actor_redirects (target_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS actors_name_trgm_idx;
+DROP INDEX IF EXISTS films_title_trgm_idx;
+DROP EXTENSION IF EXISTS pg_trgm;
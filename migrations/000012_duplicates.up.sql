@@ -0,0 +1,6 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS films_title_trgm_idx
+    ON films USING gin (LOWER(title) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS actors_name_trgm_idx
+    ON actors USING gin (LOWER(first_name || ' ' || last_name) gin_trgm_ops);
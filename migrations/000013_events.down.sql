@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS events_publish ON events;
+DROP TABLE IF EXISTS events;
+DROP FUNCTION IF EXISTS publish_event();
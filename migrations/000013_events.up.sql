@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS events (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(30) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+-- the table keeps the last 1000 events for the clients resuming the stream,
+-- the listeners of all instances are notified on commit
+CREATE OR REPLACE FUNCTION publish_event() RETURNS TRIGGER AS $$
+BEGIN
+    DELETE FROM events WHERE id <= NEW.id - 1000;
+    PERFORM pg_notify('events', json_build_object(
+        'id', NEW.id,
+        'type', NEW.type,
+        'entityId', NEW.entity_id,
+        'createdAt', NEW.created_at
+    )::text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_publish AFTER INSERT ON events
+    FOR EACH ROW EXECUTE FUNCTION publish_event();
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    event_types VARCHAR(30)[] NOT NULL,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+-- film changes written in the transaction of the change, the dispatcher
+-- moves them to the deliveries of the matching webhooks
+CREATE TABLE IF NOT EXISTS webhook_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    type VARCHAR(30) NOT NULL,
+    film_id INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id INTEGER REFERENCES webhooks (id) ON DELETE CASCADE NOT NULL,
+    event_id BIGINT NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    film_id INTEGER NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    status VARCHAR(10) DEFAULT 'pending' NOT NULL,
+    attempts INTEGER DEFAULT 0 NOT NULL,
+    next_attempt_at TIMESTAMPTZ DEFAULT now() NOT NULL,
+    last_attempt_at TIMESTAMPTZ,
+    response_status INTEGER,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_pending_idx ON webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
@@ -0,0 +1,6 @@
+-- the cache is shared by the instances and is not worth the WAL
+CREATE UNLOGGED TABLE IF NOT EXISTS cache_entries (
+    key TEXT PRIMARY KEY,
+    value BYTEA NOT NULL,
+    expires_at TIMESTAMPTZ
+);
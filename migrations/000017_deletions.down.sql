@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS actors_and_films_move ON actors_and_films;
+DROP TRIGGER IF EXISTS actors_and_films_deletion ON actors_and_films;
+DROP TRIGGER IF EXISTS actors_deletion ON actors;
+DROP TRIGGER IF EXISTS films_deletion ON films;
+DROP FUNCTION IF EXISTS record_deletion();
+DROP TABLE IF EXISTS deletions;
@@ -0,0 +1,36 @@
+-- the tombstones of the exported entities, so the incremental export can
+-- tell the consumers what to delete
+CREATE TABLE IF NOT EXISTS deletions (
+    id BIGSERIAL PRIMARY KEY,
+    entity TEXT NOT NULL,
+    record_id INTEGER,
+    actor_id INTEGER,
+    film_id INTEGER,
+    deleted_at TIMESTAMPTZ DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS deletions_deleted_at_idx ON deletions (deleted_at);
+
+CREATE OR REPLACE FUNCTION record_deletion() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_ARGV[0] = 'links' THEN
+        INSERT INTO deletions (entity, actor_id, film_id)
+        VALUES ('links', OLD.actor_id, OLD.film_id);
+    ELSE
+        INSERT INTO deletions (entity, record_id) VALUES (TG_ARGV[0], OLD.id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER films_deletion AFTER DELETE ON films
+    FOR EACH ROW EXECUTE FUNCTION record_deletion('films');
+CREATE TRIGGER actors_deletion AFTER DELETE ON actors
+    FOR EACH ROW EXECUTE FUNCTION record_deletion('actors');
+CREATE TRIGGER actors_and_films_deletion AFTER DELETE ON actors_and_films
+    FOR EACH ROW EXECUTE FUNCTION record_deletion('links');
+-- merging actors moves the links, the old ones are gone for the consumers
+CREATE TRIGGER actors_and_films_move AFTER UPDATE OF actor_id, film_id ON actors_and_films
+    FOR EACH ROW
+    WHEN (OLD.actor_id <> NEW.actor_id OR OLD.film_id <> NEW.film_id)
+    EXECUTE FUNCTION record_deletion('links');